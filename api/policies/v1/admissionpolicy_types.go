@@ -25,6 +25,13 @@ import (
 // AdmissionPolicySpec defines the desired state of AdmissionPolicy.
 type AdmissionPolicySpec struct {
 	PolicySpec `json:""`
+
+	// List of namespaced Kubernetes resources the policy is allowed to access
+	// at evaluation time. The access is restricted to the namespace of the
+	// policy, and is done using the `ServiceAccount` of the PolicyServer the
+	// policy is assigned to.
+	// +optional
+	ContextAwareResources []ContextAwareResource `json:"contextAwareResources,omitempty"`
 }
 
 // AdmissionPolicy is the Schema for the admissionpolicies API
@@ -70,6 +77,26 @@ func (r *AdmissionPolicy) GetPolicyMode() PolicyMode {
 	return r.Spec.Mode
 }
 
+func (r *AdmissionPolicy) IsSuspended() bool {
+	return r.Spec.Suspended
+}
+
+func (r *AdmissionPolicy) GetEnforcement() *PolicyEnforcement {
+	return r.Spec.Enforcement
+}
+
+func (r *AdmissionPolicy) GetExpiration() *PolicyExpiration {
+	return r.Spec.Expiration
+}
+
+func (r *AdmissionPolicy) SetPolicyMode(policyMode PolicyMode) {
+	r.Spec.Mode = policyMode
+}
+
+func (r *AdmissionPolicy) SetSuspended(suspended bool) {
+	r.Spec.Suspended = suspended
+}
+
 func (r *AdmissionPolicy) SetPolicyModeStatus(policyMode PolicyModeStatus) {
 	r.Status.PolicyMode = policyMode
 }
@@ -78,12 +105,16 @@ func (r *AdmissionPolicy) GetModule() string {
 	return r.Spec.Module
 }
 
+func (r *AdmissionPolicy) GetModuleRef() string {
+	return r.Spec.ModuleRef
+}
+
 func (r *AdmissionPolicy) IsMutating() bool {
 	return r.Spec.Mutating
 }
 
 func (r *AdmissionPolicy) IsContextAware() bool {
-	return false
+	return len(r.Spec.ContextAwareResources) > 0
 }
 
 func (r *AdmissionPolicy) GetSettings() runtime.RawExtension {
@@ -126,6 +157,14 @@ func (r *AdmissionPolicy) GetMatchConditions() []admissionregistrationv1.MatchCo
 	return r.Spec.MatchConditions
 }
 
+func (r *AdmissionPolicy) GetReinvocationPolicy() *admissionregistrationv1.ReinvocationPolicyType {
+	return r.Spec.ReinvocationPolicy
+}
+
+func (r *AdmissionPolicy) GetPriority() *int32 {
+	return r.Spec.Priority
+}
+
 // GetNamespaceSelector returns the namespace of the AdmissionPolicy since it is the only namespace we want the policy to be applied to.
 func (r *AdmissionPolicy) GetNamespaceSelector() *metav1.LabelSelector {
 	return &metav1.LabelSelector{
@@ -150,11 +189,15 @@ func (r *AdmissionPolicy) GetPolicyServer() string {
 }
 
 func (r *AdmissionPolicy) GetUniqueName() string {
-	return "namespaced-" + r.Namespace + "-" + r.Name
+	return uniqueName(admissionPolicyUniqueNamePrefix, r.Namespace, r.Name)
+}
+
+func (r *AdmissionPolicy) GetLegacyUniqueName() string {
+	return legacyUniqueName(admissionPolicyUniqueNamePrefix, r.Namespace, r.Name)
 }
 
 func (r *AdmissionPolicy) GetContextAwareResources() []ContextAwareResource {
-	return []ContextAwareResource{}
+	return r.Spec.ContextAwareResources
 }
 
 func (r *AdmissionPolicy) GetBackgroundAudit() bool {
@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -29,16 +31,24 @@ import (
 )
 
 // SetupWebhookWithManager registers the AdmissionPolicy webhook with the controller manager.
-func (r *AdmissionPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *AdmissionPolicy) SetupWebhookWithManager(mgr ctrl.Manager, defaultPolicyServer string, sensitiveResources SensitiveResources, breakGlassGroup string, strictServedResources bool, warningsSettings PolicyWarningsSettings) error {
 	logger := mgr.GetLogger().WithName("admissionpolicy-webhook")
 
 	err := ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		WithDefaulter(&admissionPolicyDefaulter{
-			logger: logger,
+			k8sReader:           mgr.GetAPIReader(),
+			defaultPolicyServer: defaultPolicyServer,
+			logger:              logger,
 		}).
 		WithValidator(&admissionPolicyValidator{
-			logger: logger,
+			k8sReader:             mgr.GetAPIReader(),
+			sensitiveResources:    sensitiveResources,
+			breakGlassGroup:       breakGlassGroup,
+			restMapper:            mgr.GetRESTMapper(),
+			strictServedResources: strictServedResources,
+			warningsSettings:      &warningsSettings,
+			logger:                logger,
 		}).
 		Complete()
 	if err != nil {
@@ -51,21 +61,24 @@ func (r *AdmissionPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 // admissionPolicyDefaulter sets default values of AdmissionPolicy objects when they are created or updated.
 type admissionPolicyDefaulter struct {
-	logger logr.Logger
+	k8sReader           client.Reader
+	defaultPolicyServer string
+	logger              logr.Logger
 }
 
 var _ webhook.CustomDefaulter = &admissionPolicyDefaulter{}
 
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the type.
-func (d *admissionPolicyDefaulter) Default(_ context.Context, obj runtime.Object) error {
+func (d *admissionPolicyDefaulter) Default(ctx context.Context, obj runtime.Object) error {
 	admissionPolicy, ok := obj.(*AdmissionPolicy)
 	if !ok {
 		return fmt.Errorf("expected an AdmissionPolicy object, got %T", obj)
 	}
 
-	if admissionPolicy.Spec.PolicyServer == "" {
-		admissionPolicy.Spec.PolicyServer = constants.DefaultPolicyServer
+	if err := defaultPolicyServer(ctx, d.k8sReader, &admissionPolicy.ObjectMeta, &admissionPolicy.Spec.PolicyServer, d.defaultPolicyServer); err != nil {
+		return err
 	}
+	defaultEnforcementMode(&admissionPolicy.ObjectMeta, &admissionPolicy.Spec.Mode, admissionPolicy.Spec.Enforcement)
 	if admissionPolicy.ObjectMeta.DeletionTimestamp == nil {
 		controllerutil.AddFinalizer(admissionPolicy, constants.KubewardenFinalizer)
 	}
@@ -77,13 +90,19 @@ func (d *admissionPolicyDefaulter) Default(_ context.Context, obj runtime.Object
 
 // admissionPolicyValidator validates AdmissionPolicy objects when they are created, updated, or deleted.
 type admissionPolicyValidator struct {
-	logger logr.Logger
+	k8sReader             client.Reader
+	sensitiveResources    SensitiveResources
+	breakGlassGroup       string
+	restMapper            meta.RESTMapper
+	strictServedResources bool
+	warningsSettings      *PolicyWarningsSettings
+	logger                logr.Logger
 }
 
 var _ webhook.CustomValidator = &admissionPolicyValidator{}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (v *admissionPolicyValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *admissionPolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	admissionPolicy, ok := obj.(*AdmissionPolicy)
 	if !ok {
 		return nil, fmt.Errorf("expected an AdmissionPolicy object, got %T", obj)
@@ -91,16 +110,20 @@ func (v *admissionPolicyValidator) ValidateCreate(_ context.Context, obj runtime
 
 	v.logger.Info("Validating AdmissionPolicy creation", "name", admissionPolicy.GetName())
 
-	allErrors := validatePolicyCreate(admissionPolicy)
+	allErrors := validatePolicyCreate(admissionPolicy, v.sensitiveResources, v.restMapper)
+	warnings, servedResourcesErrors := servedResourcesWarnings(admissionPolicy, v.restMapper, v.strictServedResources, v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
 	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(admissionPolicy, allErrors)
 	}
 
-	return nil, nil
+	warnings = append(warnings, policyWarnings(admissionPolicy, v.warningsSettings)...)
+
+	return append(warnings, mutatingPolicyOrderingWarnings(ctx, v.k8sReader, admissionPolicy, v.logger)...), nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (v *admissionPolicyValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *admissionPolicyValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldAdmissionPolicy, ok := oldObj.(*AdmissionPolicy)
 	if !ok {
 		return nil, fmt.Errorf("expected an AdmissionPolicy object, got %T", oldObj)
@@ -112,12 +135,16 @@ func (v *admissionPolicyValidator) ValidateUpdate(_ context.Context, oldObj, new
 
 	v.logger.Info("Validating ClusterAdmissionPolicy update", "name", newAdmissionPolicy.GetName())
 
-	allErrors := validatePolicyUpdate(oldAdmissionPolicy, newAdmissionPolicy)
+	allErrors := validatePolicyUpdate(oldAdmissionPolicy, newAdmissionPolicy, v.sensitiveResources, v.restMapper, newBreakGlassAuthorization(ctx, v.breakGlassGroup))
+	warnings, servedResourcesErrors := servedResourcesWarnings(newAdmissionPolicy, v.restMapper, strictServedResourcesOnUpdate(v.strictServedResources, oldAdmissionPolicy, newAdmissionPolicy), v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
 	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(newAdmissionPolicy, allErrors)
 	}
 
-	return nil, nil
+	warnings = append(warnings, policyWarnings(newAdmissionPolicy, v.warningsSettings)...)
+
+	return append(warnings, mutatingPolicyOrderingWarnings(ctx, v.k8sReader, newAdmissionPolicy, v.logger)...), nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
@@ -35,6 +35,7 @@ func TestAdmissionPolicyDefault(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, constants.DefaultPolicyServer, policy.GetPolicyServer())
+	assert.Equal(t, constants.PolicyServerDefaultedFromBuiltin, policy.GetAnnotations()[constants.PolicyServerDefaultedFromAnnotationKey])
 	assert.Contains(t, policy.GetFinalizers(), constants.KubewardenFinalizer)
 }
 
@@ -70,12 +70,36 @@ func (r *AdmissionPolicyGroup) GetPolicyMode() PolicyMode {
 	return r.Spec.Mode
 }
 
+func (r *AdmissionPolicyGroup) IsSuspended() bool {
+	return r.Spec.Suspended
+}
+
+func (r *AdmissionPolicyGroup) GetEnforcement() *PolicyEnforcement {
+	return r.Spec.Enforcement
+}
+
+func (r *AdmissionPolicyGroup) GetExpiration() *PolicyExpiration {
+	return r.Spec.Expiration
+}
+
+func (r *AdmissionPolicyGroup) SetPolicyMode(policyMode PolicyMode) {
+	r.Spec.Mode = policyMode
+}
+
+func (r *AdmissionPolicyGroup) SetSuspended(suspended bool) {
+	r.Spec.Suspended = suspended
+}
+
 func (r *AdmissionPolicyGroup) SetPolicyModeStatus(policyMode PolicyModeStatus) {
 	r.Status.PolicyMode = policyMode
 }
 
 func (r *AdmissionPolicyGroup) IsContextAware() bool {
-	// We return false here because AdmissionPolicyGroup have no access to context aware resources.
+	for _, policy := range r.Spec.Policies {
+		if len(policy.ContextAwareResources) > 0 {
+			return true
+		}
+	}
 	return false
 }
 
@@ -87,17 +111,14 @@ func (r *AdmissionPolicyGroup) GetModule() string {
 	return ""
 }
 
-func (r *AdmissionPolicyGroup) GetPolicyGroupMembersWithContext() PolicyGroupMembersWithContext {
-	policies := make(PolicyGroupMembersWithContext)
-	for name, policy := range r.Spec.Policies {
-		policies[name] = PolicyGroupMemberWithContext{
-			PolicyGroupMember: policy,
-			// AdmissionPolicyGroup does not have access to context aware resources.
-			ContextAwareResources: []ContextAwareResource{},
-		}
-	}
+// GetModuleRef returns an empty string: the members of the group reference
+// their own modules.
+func (r *AdmissionPolicyGroup) GetModuleRef() string {
+	return ""
+}
 
-	return policies
+func (r *AdmissionPolicyGroup) GetPolicyGroupMembersWithContext() PolicyGroupMembersWithContext {
+	return r.Spec.Policies
 }
 
 func (r *AdmissionPolicyGroup) GetSettings() runtime.RawExtension {
@@ -114,6 +135,15 @@ func (r *AdmissionPolicyGroup) GetExpression() string {
 	return r.Spec.Expression
 }
 
+// GetExpressionVersion returns the version of the environment of the
+// expression, which is "v1" when not set.
+func (r *AdmissionPolicyGroup) GetExpressionVersion() GroupExpressionVersion {
+	if r.Spec.ExpressionVersion == "" {
+		return GroupExpressionVersionV1
+	}
+	return r.Spec.ExpressionVersion
+}
+
 func (r *AdmissionPolicyGroup) GetMessage() string {
 	return r.Spec.Message
 }
@@ -150,6 +180,16 @@ func (r *AdmissionPolicyGroup) GetMatchConditions() []admissionregistrationv1.Ma
 	return r.Spec.MatchConditions
 }
 
+func (r *AdmissionPolicyGroup) GetReinvocationPolicy() *admissionregistrationv1.ReinvocationPolicyType {
+	// Policy groups are never mutating.
+	return nil
+}
+
+func (r *AdmissionPolicyGroup) GetPriority() *int32 {
+	// Policy groups are never mutating.
+	return nil
+}
+
 // GetNamespaceSelector returns the namespace of the AdmissionPolicyGroup since it is the only namespace we want the policy to be applied to.
 func (r *AdmissionPolicyGroup) GetNamespaceSelector() *metav1.LabelSelector {
 	return &metav1.LabelSelector{
@@ -174,7 +214,11 @@ func (r *AdmissionPolicyGroup) GetPolicyServer() string {
 }
 
 func (r *AdmissionPolicyGroup) GetUniqueName() string {
-	return "namespaced-group-" + r.Namespace + "-" + r.Name
+	return uniqueName(admissionPolicyGroupUniqueNamePrefix, r.Namespace, r.Name)
+}
+
+func (r *AdmissionPolicyGroup) GetLegacyUniqueName() string {
+	return legacyUniqueName(admissionPolicyGroupUniqueNamePrefix, r.Namespace, r.Name)
 }
 
 func (r *AdmissionPolicyGroup) GetContextAwareResources() []ContextAwareResource {
@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -29,16 +31,23 @@ import (
 )
 
 // SetupWebhookWithManager registers the AdmissionPolicyGroup webhook with the controller manager.
-func (r *AdmissionPolicyGroup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *AdmissionPolicyGroup) SetupWebhookWithManager(mgr ctrl.Manager, defaultPolicyServer string, sensitiveResources SensitiveResources, breakGlassGroup string, strictServedResources bool, warningsSettings PolicyWarningsSettings) error {
 	logger := mgr.GetLogger().WithName("admissionpolicygroup-webhook")
 
 	err := ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		WithDefaulter(&admissionPolicyGroupDefaulter{
-			logger: logger,
+			k8sReader:           mgr.GetAPIReader(),
+			defaultPolicyServer: defaultPolicyServer,
+			logger:              logger,
 		}).
 		WithValidator(&admissionPolicyGroupValidator{
-			logger: logger,
+			sensitiveResources:    sensitiveResources,
+			breakGlassGroup:       breakGlassGroup,
+			restMapper:            mgr.GetRESTMapper(),
+			strictServedResources: strictServedResources,
+			warningsSettings:      &warningsSettings,
+			logger:                logger,
 		}).
 		Complete()
 	if err != nil {
@@ -52,13 +61,15 @@ func (r *AdmissionPolicyGroup) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 // admissionPolicyGroupDefaulter sets default values of AdmissionPolicyGroup objects when they are created or updated.
 type admissionPolicyGroupDefaulter struct {
-	logger logr.Logger
+	k8sReader           client.Reader
+	defaultPolicyServer string
+	logger              logr.Logger
 }
 
 var _ webhook.CustomDefaulter = &admissionPolicyGroupDefaulter{}
 
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the type.
-func (d *admissionPolicyGroupDefaulter) Default(_ context.Context, obj runtime.Object) error {
+func (d *admissionPolicyGroupDefaulter) Default(ctx context.Context, obj runtime.Object) error {
 	admissionPolicyGroup, ok := obj.(*AdmissionPolicyGroup)
 	if !ok {
 		return fmt.Errorf("expected an AdmissionPolicyGroup object, got %T", obj)
@@ -66,9 +77,10 @@ func (d *admissionPolicyGroupDefaulter) Default(_ context.Context, obj runtime.O
 
 	d.logger.Info("Defaulting AdmissionPolicyGroup", "name", admissionPolicyGroup.GetName())
 
-	if admissionPolicyGroup.Spec.PolicyServer == "" {
-		admissionPolicyGroup.Spec.PolicyServer = constants.DefaultPolicyServer
+	if err := defaultPolicyServer(ctx, d.k8sReader, &admissionPolicyGroup.ObjectMeta, &admissionPolicyGroup.Spec.PolicyServer, d.defaultPolicyServer); err != nil {
+		return err
 	}
+	defaultEnforcementMode(&admissionPolicyGroup.ObjectMeta, &admissionPolicyGroup.Spec.Mode, admissionPolicyGroup.Spec.Enforcement)
 	if admissionPolicyGroup.ObjectMeta.DeletionTimestamp == nil {
 		controllerutil.AddFinalizer(admissionPolicyGroup, constants.KubewardenFinalizer)
 	}
@@ -80,7 +92,12 @@ func (d *admissionPolicyGroupDefaulter) Default(_ context.Context, obj runtime.O
 
 // admissionPolicyGroupValidator validates AdmissionPolicyGroup objects when they are created, updated, or deleted.
 type admissionPolicyGroupValidator struct {
-	logger logr.Logger
+	sensitiveResources    SensitiveResources
+	breakGlassGroup       string
+	restMapper            meta.RESTMapper
+	strictServedResources bool
+	warningsSettings      *PolicyWarningsSettings
+	logger                logr.Logger
 }
 
 var _ webhook.CustomValidator = &admissionPolicyGroupValidator{}
@@ -94,17 +111,18 @@ func (v *admissionPolicyGroupValidator) ValidateCreate(_ context.Context, obj ru
 
 	v.logger.Info("Validating AdmissionPolicyGroup creation", "name", admissionPolicyGroup.GetName())
 
-	allErrors := validatePolicyGroupCreate(admissionPolicyGroup)
-
+	allErrors := validatePolicyGroupCreate(admissionPolicyGroup, v.sensitiveResources, v.restMapper)
+	warnings, servedResourcesErrors := servedResourcesWarnings(admissionPolicyGroup, v.restMapper, v.strictServedResources, v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
 	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(admissionPolicyGroup, allErrors)
 	}
 
-	return nil, nil
+	return append(warnings, policyWarnings(admissionPolicyGroup, v.warningsSettings)...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (v *admissionPolicyGroupValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *admissionPolicyGroupValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldAdmissionPolicyGroup, ok := oldObj.(*AdmissionPolicyGroup)
 	if !ok {
 		return nil, fmt.Errorf("expected an AdmissionPolicyGroup object, got %T", oldObj)
@@ -116,11 +134,14 @@ func (v *admissionPolicyGroupValidator) ValidateUpdate(_ context.Context, oldObj
 
 	v.logger.Info("Validating AdmissionPolicyGroup update", "name", newAdmissionPolicyGroup.GetName())
 
-	if allErrors := validatePolicyGroupUpdate(oldAdmissionPolicyGroup, newAdmissionPolicyGroup); len(allErrors) != 0 {
+	allErrors := validatePolicyGroupUpdate(oldAdmissionPolicyGroup, newAdmissionPolicyGroup, v.sensitiveResources, v.restMapper, newBreakGlassAuthorization(ctx, v.breakGlassGroup))
+	warnings, servedResourcesErrors := servedResourcesWarnings(newAdmissionPolicyGroup, v.restMapper, strictServedResourcesOnUpdate(v.strictServedResources, oldAdmissionPolicyGroup, newAdmissionPolicyGroup), v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
+	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(newAdmissionPolicyGroup, allErrors)
 	}
 
-	return nil, nil
+	return append(warnings, policyWarnings(newAdmissionPolicyGroup, v.warningsSettings)...), nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
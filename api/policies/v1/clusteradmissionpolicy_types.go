@@ -29,8 +29,44 @@ type ContextAwareResource struct {
 
 	// Singular PascalCase name of the resource
 	Kind string `json:"kind"`
+
+	// Namespaces restricts the access to the resources defined inside of
+	// the given namespaces. When not set, the resources of all the namespaces
+	// can be accessed.
+	// This field cannot be set for cluster-scoped resources.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector restricts the access to the resources defined inside
+	// of the namespaces matching the selector.
+	// This field cannot be set for cluster-scoped resources, nor by namespaced
+	// policies.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// LabelSelector restricts the access to the resources matching the
+	// selector.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Names restricts the access to the resources with the given names.
+	// +optional
+	Names []string `json:"names,omitempty"`
 }
 
+// PolicyBackend is the admission backend enforcing a policy.
+// +kubebuilder:validation:Enum=webhook;validatingAdmissionPolicy
+type PolicyBackend string
+
+const (
+	// PolicyBackendWebhook sends the admission requests to the PolicyServer
+	// of the policy.
+	PolicyBackendWebhook PolicyBackend = "webhook"
+	// PolicyBackendValidatingAdmissionPolicy translates the policy into a
+	// native ValidatingAdmissionPolicy, evaluated by the API server.
+	PolicyBackendValidatingAdmissionPolicy PolicyBackend = "validatingAdmissionPolicy"
+)
+
 // ClusterAdmissionPolicySpec defines the desired state of ClusterAdmissionPolicy.
 type ClusterAdmissionPolicySpec struct {
 	PolicySpec `json:""`
@@ -88,6 +124,17 @@ type ClusterAdmissionPolicySpec struct {
 	// the policy is assigned to.
 	// +optional
 	ContextAwareResources []ContextAwareResource `json:"contextAwareResources,omitempty"`
+
+	// Backend selects how the policy is enforced. With "webhook", the
+	// default, the admission requests are sent to the PolicyServer of the
+	// policy. With "validatingAdmissionPolicy", a validating policy running
+	// the Kubewarden CEL policy module is translated into a native
+	// ValidatingAdmissionPolicy and its binding, evaluated by the API
+	// server. The policy falls back to the webhook backend when it cannot
+	// be translated, or when the cluster does not serve
+	// ValidatingAdmissionPolicies.
+	// +optional
+	Backend PolicyBackend `json:"backend,omitempty"`
 }
 
 // ClusterAdmissionPolicy is the Schema for the clusteradmissionpolicies API
@@ -132,6 +179,35 @@ func (r *ClusterAdmissionPolicy) GetPolicyMode() PolicyMode {
 	return r.Spec.Mode
 }
 
+func (r *ClusterAdmissionPolicy) IsSuspended() bool {
+	return r.Spec.Suspended
+}
+
+// GetBackend returns the admission backend requested for the policy.
+func (r *ClusterAdmissionPolicy) GetBackend() PolicyBackend {
+	if r.Spec.Backend == "" {
+		return PolicyBackendWebhook
+	}
+
+	return r.Spec.Backend
+}
+
+func (r *ClusterAdmissionPolicy) GetEnforcement() *PolicyEnforcement {
+	return r.Spec.Enforcement
+}
+
+func (r *ClusterAdmissionPolicy) GetExpiration() *PolicyExpiration {
+	return r.Spec.Expiration
+}
+
+func (r *ClusterAdmissionPolicy) SetPolicyMode(policyMode PolicyMode) {
+	r.Spec.Mode = policyMode
+}
+
+func (r *ClusterAdmissionPolicy) SetSuspended(suspended bool) {
+	r.Spec.Suspended = suspended
+}
+
 func (r *ClusterAdmissionPolicy) SetPolicyModeStatus(policyMode PolicyModeStatus) {
 	r.Status.PolicyMode = policyMode
 }
@@ -140,6 +216,10 @@ func (r *ClusterAdmissionPolicy) GetModule() string {
 	return r.Spec.Module
 }
 
+func (r *ClusterAdmissionPolicy) GetModuleRef() string {
+	return r.Spec.ModuleRef
+}
+
 func (r *ClusterAdmissionPolicy) IsMutating() bool {
 	return r.Spec.Mutating
 }
@@ -180,6 +260,14 @@ func (r *ClusterAdmissionPolicy) GetMatchConditions() []admissionregistrationv1.
 	return r.Spec.MatchConditions
 }
 
+func (r *ClusterAdmissionPolicy) GetReinvocationPolicy() *admissionregistrationv1.ReinvocationPolicyType {
+	return r.Spec.ReinvocationPolicy
+}
+
+func (r *ClusterAdmissionPolicy) GetPriority() *int32 {
+	return r.Spec.Priority
+}
+
 func (r *ClusterAdmissionPolicy) GetNamespaceSelector() *metav1.LabelSelector {
 	return r.Spec.NamespaceSelector
 }
@@ -201,7 +289,11 @@ func (r *ClusterAdmissionPolicy) GetPolicyServer() string {
 }
 
 func (r *ClusterAdmissionPolicy) GetUniqueName() string {
-	return "clusterwide-" + r.Name
+	return uniqueName(clusterAdmissionPolicyUniqueNamePrefix, "", r.Name)
+}
+
+func (r *ClusterAdmissionPolicy) GetLegacyUniqueName() string {
+	return legacyUniqueName(clusterAdmissionPolicyUniqueNamePrefix, "", r.Name)
 }
 
 func (r *ClusterAdmissionPolicy) GetContextAwareResources() []ContextAwareResource {
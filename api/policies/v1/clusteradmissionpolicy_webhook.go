@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -31,16 +33,23 @@ import (
 )
 
 // SetupWebhookWithManager registers the ClusterAdmissionPolicy webhook with the controller manager.
-func (r *ClusterAdmissionPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *ClusterAdmissionPolicy) SetupWebhookWithManager(mgr ctrl.Manager, defaultPolicyServer string, sensitiveResources SensitiveResources, breakGlassGroup string, strictServedResources bool, warningsSettings PolicyWarningsSettings) error {
 	logger := mgr.GetLogger().WithName("clusteradmissionpolicy-webhook")
 
 	err := ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		WithDefaulter(&clusterAdmissionPolicyDefaulter{
-			logger: logger,
+			defaultPolicyServer: defaultPolicyServer,
+			logger:              logger,
 		}).
 		WithValidator(&clusterAdmissionPolicyValidator{
-			logger: logger,
+			k8sReader:             mgr.GetAPIReader(),
+			sensitiveResources:    sensitiveResources,
+			breakGlassGroup:       breakGlassGroup,
+			restMapper:            mgr.GetRESTMapper(),
+			strictServedResources: strictServedResources,
+			warningsSettings:      &warningsSettings,
+			logger:                logger,
 		}).
 		Complete()
 	if err != nil {
@@ -54,13 +63,14 @@ func (r *ClusterAdmissionPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error
 
 // clusterAdmissionPolicyDefaulter sets default values of ClusterAdmissionPolicy objects when they are created or updated.
 type clusterAdmissionPolicyDefaulter struct {
-	logger logr.Logger
+	defaultPolicyServer string
+	logger              logr.Logger
 }
 
 var _ webhook.CustomDefaulter = &clusterAdmissionPolicyDefaulter{}
 
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the type.
-func (d *clusterAdmissionPolicyDefaulter) Default(_ context.Context, obj runtime.Object) error {
+func (d *clusterAdmissionPolicyDefaulter) Default(ctx context.Context, obj runtime.Object) error {
 	clusterAdmissionPolicy, ok := obj.(*ClusterAdmissionPolicy)
 	if !ok {
 		return fmt.Errorf("expected a ClusterAdmissionPolicy object, got %T", obj)
@@ -68,9 +78,10 @@ func (d *clusterAdmissionPolicyDefaulter) Default(_ context.Context, obj runtime
 
 	d.logger.Info("Defaulting ClusterAdmissionPolicy", "name", clusterAdmissionPolicy.GetName())
 
-	if clusterAdmissionPolicy.Spec.PolicyServer == "" {
-		clusterAdmissionPolicy.Spec.PolicyServer = constants.DefaultPolicyServer
+	if err := defaultPolicyServer(ctx, nil, &clusterAdmissionPolicy.ObjectMeta, &clusterAdmissionPolicy.Spec.PolicyServer, d.defaultPolicyServer); err != nil {
+		return err
 	}
+	defaultEnforcementMode(&clusterAdmissionPolicy.ObjectMeta, &clusterAdmissionPolicy.Spec.Mode, clusterAdmissionPolicy.Spec.Enforcement)
 	if clusterAdmissionPolicy.ObjectMeta.DeletionTimestamp == nil {
 		controllerutil.AddFinalizer(clusterAdmissionPolicy, constants.KubewardenFinalizer)
 	}
@@ -82,13 +93,19 @@ func (d *clusterAdmissionPolicyDefaulter) Default(_ context.Context, obj runtime
 
 // clusterAdmissionPolicyValidator validates ClusterAdmissionPolicy objects when they are created, updated, or deleted.
 type clusterAdmissionPolicyValidator struct {
-	logger logr.Logger
+	k8sReader             client.Reader
+	sensitiveResources    SensitiveResources
+	breakGlassGroup       string
+	restMapper            meta.RESTMapper
+	strictServedResources bool
+	warningsSettings      *PolicyWarningsSettings
+	logger                logr.Logger
 }
 
 var _ webhook.CustomValidator = &clusterAdmissionPolicyValidator{}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (v *clusterAdmissionPolicyValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *clusterAdmissionPolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	clusterAdmissionPolicy, ok := obj.(*ClusterAdmissionPolicy)
 	if !ok {
 		return nil, fmt.Errorf("expected a ClusterAdmissionPolicy object, got %T", obj)
@@ -96,16 +113,21 @@ func (v *clusterAdmissionPolicyValidator) ValidateCreate(_ context.Context, obj
 
 	v.logger.Info("Validating ClusterAdmissionPolicy creation", "name", clusterAdmissionPolicy.GetName())
 
-	allErrors := validatePolicyCreate(clusterAdmissionPolicy)
+	allErrors := validatePolicyCreate(clusterAdmissionPolicy, v.sensitiveResources, v.restMapper)
+	allErrors = append(allErrors, validateBackendField(clusterAdmissionPolicy)...)
+	warnings, servedResourcesErrors := servedResourcesWarnings(clusterAdmissionPolicy, v.restMapper, v.strictServedResources, v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
 	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(clusterAdmissionPolicy, allErrors)
 	}
 
-	return nil, nil
+	warnings = append(warnings, policyWarnings(clusterAdmissionPolicy, v.warningsSettings)...)
+
+	return append(warnings, mutatingPolicyOrderingWarnings(ctx, v.k8sReader, clusterAdmissionPolicy, v.logger)...), nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (v *clusterAdmissionPolicyValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *clusterAdmissionPolicyValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldClusterAdmissionPolicy, ok := oldObj.(*ClusterAdmissionPolicy)
 	if !ok {
 		return nil, fmt.Errorf("expected a ClusterAdmissionPolicy object, got %T", oldObj)
@@ -117,12 +139,17 @@ func (v *clusterAdmissionPolicyValidator) ValidateUpdate(_ context.Context, oldO
 
 	v.logger.Info("Validating ClusterAdmissionPolicy update", "name", newClusterAdmissionPolicy.GetName())
 
-	allErrors := validatePolicyUpdate(oldClusterAdmissionPolicy, newClusterAdmissionPolicy)
+	allErrors := validatePolicyUpdate(oldClusterAdmissionPolicy, newClusterAdmissionPolicy, v.sensitiveResources, v.restMapper, newBreakGlassAuthorization(ctx, v.breakGlassGroup))
+	allErrors = append(allErrors, validateBackendField(newClusterAdmissionPolicy)...)
+	warnings, servedResourcesErrors := servedResourcesWarnings(newClusterAdmissionPolicy, v.restMapper, strictServedResourcesOnUpdate(v.strictServedResources, oldClusterAdmissionPolicy, newClusterAdmissionPolicy), v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
 	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(newClusterAdmissionPolicy, allErrors)
 	}
 
-	return nil, nil
+	warnings = append(warnings, policyWarnings(newClusterAdmissionPolicy, v.warningsSettings)...)
+
+	return append(warnings, mutatingPolicyOrderingWarnings(ctx, v.k8sReader, newClusterAdmissionPolicy, v.logger)...), nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
@@ -24,6 +24,7 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/kubewarden/kubewarden-controller/internal/constants"
 )
@@ -36,6 +37,7 @@ func TestClusterAdmissionPolicyDefault(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, constants.DefaultPolicyServer, policy.GetPolicyServer())
+	assert.Equal(t, constants.PolicyServerDefaultedFromBuiltin, policy.GetAnnotations()[constants.PolicyServerDefaultedFromAnnotationKey])
 	assert.Contains(t, policy.GetFinalizers(), constants.KubewardenFinalizer)
 }
 
@@ -56,6 +58,21 @@ func TestClusterAdmissionPolicyValidateCreate(t *testing.T) {
 	assert.Empty(t, warnings)
 }
 
+func TestClusterAdmissionPolicyValidateCreateWithWarnings(t *testing.T) {
+	validator := clusterAdmissionPolicyValidator{
+		warningsSettings: &PolicyWarningsSettings{DeploymentsNamespace: "kubewarden"},
+		logger:           logr.Discard(),
+	}
+	policy := NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build()
+	policy.Spec.FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+
+	warnings, err := validator.ValidateCreate(context.Background(), policy)
+	require.NoError(t, err)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "spec.failurePolicy")
+	assert.Contains(t, warnings[1], "spec.matchConditions")
+}
+
 func TestClusterAdmissionPolicyValidateCreateWithErrors(t *testing.T) {
 	policy := NewClusterAdmissionPolicyFactory().
 		WithPolicyServer("").
@@ -230,3 +247,25 @@ func TestClusterAdmissionPolicyValidateDeleteWithInvalidType(t *testing.T) {
 	require.ErrorContains(t, err, "expected a ClusterAdmissionPolicy object, got *v1.Pod")
 	assert.Empty(t, warnings)
 }
+
+func TestValidateBackendField(t *testing.T) {
+	tests := []struct {
+		name           string
+		backend        PolicyBackend
+		mutating       bool
+		expectedErrors int
+	}{
+		{"default backend", "", true, 0},
+		{"validating admission policy backend", PolicyBackendValidatingAdmissionPolicy, false, 0},
+		{"validating admission policy backend on a mutating policy", PolicyBackendValidatingAdmissionPolicy, true, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewClusterAdmissionPolicyFactory().WithMutating(test.mutating).Build()
+			policy.Spec.Backend = test.backend
+
+			assert.Len(t, validateBackendField(policy), test.expectedErrors)
+		})
+	}
+}
@@ -127,6 +127,26 @@ func (r *ClusterAdmissionPolicyGroup) GetPolicyMode() PolicyMode {
 	return r.Spec.Mode
 }
 
+func (r *ClusterAdmissionPolicyGroup) IsSuspended() bool {
+	return r.Spec.Suspended
+}
+
+func (r *ClusterAdmissionPolicyGroup) GetEnforcement() *PolicyEnforcement {
+	return r.Spec.Enforcement
+}
+
+func (r *ClusterAdmissionPolicyGroup) GetExpiration() *PolicyExpiration {
+	return r.Spec.Expiration
+}
+
+func (r *ClusterAdmissionPolicyGroup) SetPolicyMode(policyMode PolicyMode) {
+	r.Spec.Mode = policyMode
+}
+
+func (r *ClusterAdmissionPolicyGroup) SetSuspended(suspended bool) {
+	r.Spec.Suspended = suspended
+}
+
 func (r *ClusterAdmissionPolicyGroup) SetPolicyModeStatus(policyMode PolicyModeStatus) {
 	r.Status.PolicyMode = policyMode
 }
@@ -135,6 +155,12 @@ func (r *ClusterAdmissionPolicyGroup) GetModule() string {
 	return ""
 }
 
+// GetModuleRef returns an empty string: the members of the group reference
+// their own modules.
+func (r *ClusterAdmissionPolicyGroup) GetModuleRef() string {
+	return ""
+}
+
 func (r *ClusterAdmissionPolicyGroup) IsMutating() bool {
 	// By design, AdmissionPolicyGroup is always non-mutating.
 	// Policy groups can be used only for validating admission requests
@@ -166,6 +192,15 @@ func (r *ClusterAdmissionPolicyGroup) GetExpression() string {
 	return r.Spec.Expression
 }
 
+// GetExpressionVersion returns the version of the environment of the
+// expression, which is "v1" when not set.
+func (r *ClusterAdmissionPolicyGroup) GetExpressionVersion() GroupExpressionVersion {
+	if r.Spec.ExpressionVersion == "" {
+		return GroupExpressionVersionV1
+	}
+	return r.Spec.ExpressionVersion
+}
+
 func (r *ClusterAdmissionPolicyGroup) GetMessage() string {
 	return r.Spec.Message
 }
@@ -194,6 +229,16 @@ func (r *ClusterAdmissionPolicyGroup) GetMatchConditions() []admissionregistrati
 	return r.Spec.MatchConditions
 }
 
+func (r *ClusterAdmissionPolicyGroup) GetReinvocationPolicy() *admissionregistrationv1.ReinvocationPolicyType {
+	// Policy groups are never mutating.
+	return nil
+}
+
+func (r *ClusterAdmissionPolicyGroup) GetPriority() *int32 {
+	// Policy groups are never mutating.
+	return nil
+}
+
 func (r *ClusterAdmissionPolicyGroup) GetNamespaceSelector() *metav1.LabelSelector {
 	return r.Spec.NamespaceSelector
 }
@@ -215,7 +260,11 @@ func (r *ClusterAdmissionPolicyGroup) GetPolicyServer() string {
 }
 
 func (r *ClusterAdmissionPolicyGroup) GetUniqueName() string {
-	return "clusterwide-group-" + r.Name
+	return uniqueName(clusterAdmissionPolicyGroupUniqueNamePrefix, "", r.Name)
+}
+
+func (r *ClusterAdmissionPolicyGroup) GetLegacyUniqueName() string {
+	return legacyUniqueName(clusterAdmissionPolicyGroupUniqueNamePrefix, "", r.Name)
 }
 
 func (r *ClusterAdmissionPolicyGroup) GetContextAwareResources() []ContextAwareResource {
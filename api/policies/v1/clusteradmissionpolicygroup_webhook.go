@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -30,16 +31,22 @@ import (
 	"github.com/kubewarden/kubewarden-controller/internal/constants"
 )
 
-func (r *ClusterAdmissionPolicyGroup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *ClusterAdmissionPolicyGroup) SetupWebhookWithManager(mgr ctrl.Manager, defaultPolicyServer string, sensitiveResources SensitiveResources, breakGlassGroup string, strictServedResources bool, warningsSettings PolicyWarningsSettings) error {
 	logger := mgr.GetLogger().WithName("clusteradmissionpolicygroup-webhook")
 
 	err := ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		WithDefaulter(&clusterAdmissionPolicyGroupDefaulter{
-			logger: logger,
+			defaultPolicyServer: defaultPolicyServer,
+			logger:              logger,
 		}).
 		WithValidator(&clusterAdmissionPolicyGroupValidator{
-			logger: logger,
+			sensitiveResources:    sensitiveResources,
+			breakGlassGroup:       breakGlassGroup,
+			restMapper:            mgr.GetRESTMapper(),
+			strictServedResources: strictServedResources,
+			warningsSettings:      &warningsSettings,
+			logger:                logger,
 		}).
 		Complete()
 	if err != nil {
@@ -53,13 +60,14 @@ func (r *ClusterAdmissionPolicyGroup) SetupWebhookWithManager(mgr ctrl.Manager)
 
 // clusterAdmissionPolicyGroupDefaulter sets default values of ClusterAdmissionPolicyGroup objects when they are created or updated.
 type clusterAdmissionPolicyGroupDefaulter struct {
-	logger logr.Logger
+	defaultPolicyServer string
+	logger              logr.Logger
 }
 
 var _ webhook.CustomDefaulter = &clusterAdmissionPolicyGroupDefaulter{}
 
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the type.
-func (d *clusterAdmissionPolicyGroupDefaulter) Default(_ context.Context, obj runtime.Object) error {
+func (d *clusterAdmissionPolicyGroupDefaulter) Default(ctx context.Context, obj runtime.Object) error {
 	clusterAdmissionPolicyGroup, ok := obj.(*ClusterAdmissionPolicyGroup)
 	if !ok {
 		return fmt.Errorf("expected a ClusterAdmissionPolicyGroup object, got %T", obj)
@@ -67,9 +75,10 @@ func (d *clusterAdmissionPolicyGroupDefaulter) Default(_ context.Context, obj ru
 
 	d.logger.Info("Defaulting ClusterAdmissionPolicyGroup", "name", clusterAdmissionPolicyGroup.GetName())
 
-	if clusterAdmissionPolicyGroup.Spec.PolicyServer == "" {
-		clusterAdmissionPolicyGroup.Spec.PolicyServer = constants.DefaultPolicyServer
+	if err := defaultPolicyServer(ctx, nil, &clusterAdmissionPolicyGroup.ObjectMeta, &clusterAdmissionPolicyGroup.Spec.PolicyServer, d.defaultPolicyServer); err != nil {
+		return err
 	}
+	defaultEnforcementMode(&clusterAdmissionPolicyGroup.ObjectMeta, &clusterAdmissionPolicyGroup.Spec.Mode, clusterAdmissionPolicyGroup.Spec.Enforcement)
 	if clusterAdmissionPolicyGroup.ObjectMeta.DeletionTimestamp == nil {
 		controllerutil.AddFinalizer(clusterAdmissionPolicyGroup, constants.KubewardenFinalizer)
 	}
@@ -81,7 +90,12 @@ func (d *clusterAdmissionPolicyGroupDefaulter) Default(_ context.Context, obj ru
 
 // clusterAdmissionPolicyGroupValidator validates ClusterAdmissionPolicyGroup objects when they are created, updated, or deleted.
 type clusterAdmissionPolicyGroupValidator struct {
-	logger logr.Logger
+	sensitiveResources    SensitiveResources
+	breakGlassGroup       string
+	restMapper            meta.RESTMapper
+	strictServedResources bool
+	warningsSettings      *PolicyWarningsSettings
+	logger                logr.Logger
 }
 
 var _ webhook.CustomValidator = &clusterAdmissionPolicyGroupValidator{}
@@ -95,16 +109,18 @@ func (v *clusterAdmissionPolicyGroupValidator) ValidateCreate(_ context.Context,
 
 	v.logger.Info("Validating ClusterAdmissionPolicyGroup creation", "name", clusterAdmissionPolicyGroup.GetName())
 
-	allErrors := validatePolicyGroupCreate(clusterAdmissionPolicyGroup)
+	allErrors := validatePolicyGroupCreate(clusterAdmissionPolicyGroup, v.sensitiveResources, v.restMapper)
+	warnings, servedResourcesErrors := servedResourcesWarnings(clusterAdmissionPolicyGroup, v.restMapper, v.strictServedResources, v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
 	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(clusterAdmissionPolicyGroup, allErrors)
 	}
 
-	return nil, nil
+	return append(warnings, policyWarnings(clusterAdmissionPolicyGroup, v.warningsSettings)...), nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (v *clusterAdmissionPolicyGroupValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *clusterAdmissionPolicyGroupValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldclusterAdmissionPolicyGroup, ok := oldObj.(*ClusterAdmissionPolicyGroup)
 	if !ok {
 		return nil, fmt.Errorf("expected a ClusterAdmissionPolicyGroup object, got %T", oldObj)
@@ -116,11 +132,14 @@ func (v *clusterAdmissionPolicyGroupValidator) ValidateUpdate(_ context.Context,
 
 	v.logger.Info("Validating ClusterAdmissionPolicyGroup update", "name", newclusterAdmissionPolicyGroup.GetName())
 
-	if allErrors := validatePolicyGroupUpdate(oldclusterAdmissionPolicyGroup, newclusterAdmissionPolicyGroup); len(allErrors) != 0 {
+	allErrors := validatePolicyGroupUpdate(oldclusterAdmissionPolicyGroup, newclusterAdmissionPolicyGroup, v.sensitiveResources, v.restMapper, newBreakGlassAuthorization(ctx, v.breakGlassGroup))
+	warnings, servedResourcesErrors := servedResourcesWarnings(newclusterAdmissionPolicyGroup, v.restMapper, strictServedResourcesOnUpdate(v.strictServedResources, oldclusterAdmissionPolicyGroup, newclusterAdmissionPolicyGroup), v.logger)
+	allErrors = append(allErrors, servedResourcesErrors...)
+	if len(allErrors) != 0 {
 		return nil, prepareInvalidAPIError(newclusterAdmissionPolicyGroup, allErrors)
 	}
 
-	return nil, nil
+	return append(warnings, policyWarnings(newclusterAdmissionPolicyGroup, v.warningsSettings)...), nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
@@ -36,6 +36,7 @@ func TestClusterAdmissionPolicyGroupDefault(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, constants.DefaultPolicyServer, policy.GetPolicyServer())
+	assert.Equal(t, constants.PolicyServerDefaultedFromBuiltin, policy.GetAnnotations()[constants.PolicyServerDefaultedFromAnnotationKey])
 	assert.Contains(t, policy.GetFinalizers(), constants.KubewardenFinalizer)
 }
 
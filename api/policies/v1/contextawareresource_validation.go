@@ -0,0 +1,166 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// clusterScopedGroupKinds lists the well-known cluster-scoped kinds. They are
+// used when the scope of a kind cannot be looked up in the RESTMapper.
+//
+//nolint:gochecknoglobals // the list is never modified
+var clusterScopedGroupKinds = sets.New(
+	schema.GroupKind{Group: "", Kind: "Namespace"},
+	schema.GroupKind{Group: "", Kind: "Node"},
+	schema.GroupKind{Group: "", Kind: "PersistentVolume"},
+	schema.GroupKind{Group: "", Kind: "ComponentStatus"},
+	schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+	schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+	schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+	schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"},
+	schema.GroupKind{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "StorageClass"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "CSIDriver"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "CSINode"},
+	schema.GroupKind{Group: "storage.k8s.io", Kind: "VolumeAttachment"},
+	schema.GroupKind{Group: "scheduling.k8s.io", Kind: "PriorityClass"},
+	schema.GroupKind{Group: "node.k8s.io", Kind: "RuntimeClass"},
+	schema.GroupKind{Group: "networking.k8s.io", Kind: "IngressClass"},
+	schema.GroupKind{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"},
+	schema.GroupKind{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"},
+	schema.GroupKind{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"},
+	GroupVersion.WithKind(ClusterAdmissionPolicyKind).GroupKind(),
+	GroupVersion.WithKind(ClusterAdmissionPolicyGroupKind).GroupKind(),
+	GroupVersion.WithKind(ClusterPolicyExceptionKind).GroupKind(),
+	GroupVersion.WithKind(ClusterPolicyBindingKind).GroupKind(),
+	GroupVersion.WithKind(PolicyTemplateKind).GroupKind(),
+	GroupVersion.WithKind(PolicyModuleKind).GroupKind(),
+	GroupVersion.WithKind("PolicyServer").GroupKind(),
+)
+
+// validateContextAwareResourcesField validates the context aware resources of
+// the policy, or of the members of the policy group. The context aware
+// resources of the namespaced policies are restricted to the namespace of the
+// policy, hence they cannot reference cluster-scoped kinds nor other
+// namespaces.
+func validateContextAwareResourcesField(policy Policy, restMapper meta.RESTMapper) field.ErrorList {
+	var allErrors field.ErrorList
+
+	policyGroup, ok := policy.(PolicyGroup)
+	if !ok {
+		return validateContextAwareResources(policy.GetContextAwareResources(), policy.GetNamespace(), restMapper, field.NewPath("spec", "contextAwareResources"))
+	}
+
+	for name, member := range policyGroup.GetPolicyGroupMembersWithContext() {
+		allErrors = append(allErrors, validateContextAwareResources(
+			member.ContextAwareResources, policy.GetNamespace(), restMapper, field.NewPath("spec", "policies").Key(name).Child("contextAwareResources"))...)
+	}
+
+	return allErrors
+}
+
+// isClusterScopedKind returns true when the kind is cluster-scoped. Its scope
+// is looked up in the RESTMapper, which knows the kinds served by the cluster,
+// including the custom resources. The well-known cluster-scoped kinds are used
+// when there is no RESTMapper, or when the kind is not served.
+func isClusterScopedKind(groupVersionKind schema.GroupVersionKind, restMapper meta.RESTMapper) bool {
+	if restMapper != nil {
+		var versions []string
+		if groupVersionKind.Version != "" {
+			versions = append(versions, groupVersionKind.Version)
+		}
+		if mapping, err := restMapper.RESTMapping(groupVersionKind.GroupKind(), versions...); err == nil {
+			return mapping.Scope.Name() == meta.RESTScopeNameRoot
+		}
+	}
+
+	return clusterScopedGroupKinds.Has(groupVersionKind.GroupKind())
+}
+
+// validateContextAwareResources validates the context aware resources. The
+// cluster-scoped kinds cannot be accessed by namespaced policies, because
+// their context aware resources are restricted to the namespace of the
+// policy, and they cannot be restricted to namespaces.
+func validateContextAwareResources(resources []ContextAwareResource, policyNamespace string, restMapper meta.RESTMapper, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	for i, resource := range resources {
+		resourcePath := fldPath.Index(i)
+		groupVersion, err := schema.ParseGroupVersion(resource.APIVersion)
+		if err != nil {
+			allErrors = append(allErrors, field.Invalid(resourcePath.Child("apiVersion"), resource.APIVersion, err.Error()))
+			continue
+		}
+
+		if isClusterScopedKind(groupVersion.WithKind(resource.Kind), restMapper) {
+			if policyNamespace != "" {
+				allErrors = append(allErrors, field.Forbidden(resourcePath,
+					"cluster-scoped resources cannot be accessed by namespaced policies: "+resource.APIVersion+"/"+resource.Kind))
+				continue
+			}
+			if len(resource.Namespaces) != 0 {
+				allErrors = append(allErrors, field.Forbidden(resourcePath.Child("namespaces"), "cannot be set for cluster-scoped resources"))
+			}
+			if resource.NamespaceSelector != nil {
+				allErrors = append(allErrors, field.Forbidden(resourcePath.Child("namespaceSelector"), "cannot be set for cluster-scoped resources"))
+			}
+		}
+
+		for j, namespace := range resource.Namespaces {
+			namespacePath := resourcePath.Child("namespaces").Index(j)
+			if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+				allErrors = append(allErrors, field.Invalid(namespacePath, namespace, strings.Join(errs, ", ")))
+				continue
+			}
+			if policyNamespace != "" && namespace != policyNamespace {
+				allErrors = append(allErrors, field.Forbidden(namespacePath,
+					"namespaced policies can only access the resources of their own namespace: "+policyNamespace))
+			}
+		}
+		if resource.NamespaceSelector != nil {
+			if policyNamespace != "" {
+				allErrors = append(allErrors, field.Forbidden(resourcePath.Child("namespaceSelector"),
+					"namespaced policies can only access the resources of their own namespace"))
+			} else {
+				allErrors = append(allErrors, metav1validation.ValidateLabelSelector(
+					resource.NamespaceSelector, metav1validation.LabelSelectorValidationOptions{}, resourcePath.Child("namespaceSelector"))...)
+			}
+		}
+		if resource.LabelSelector != nil {
+			allErrors = append(allErrors, metav1validation.ValidateLabelSelector(
+				resource.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, resourcePath.Child("labelSelector"))...)
+		}
+		for j, name := range resource.Names {
+			if name == "" {
+				allErrors = append(allErrors, field.Required(resourcePath.Child("names").Index(j), "must be non-empty"))
+			}
+		}
+	}
+
+	return allErrors
+}
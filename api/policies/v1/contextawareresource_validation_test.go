@@ -0,0 +1,224 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestValidateContextAwareResourcesField(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         Policy
+		expectedErrors []string
+	}{
+		{
+			"admission policy with namespaced resources",
+			NewAdmissionPolicyFactory().
+				WithContextAwareResources([]ContextAwareResource{
+					{APIVersion: "v1", Kind: "ConfigMap"},
+					{APIVersion: "apps/v1", Kind: "Deployment"},
+				}).
+				Build(),
+			nil,
+		},
+		{
+			"admission policy with cluster-scoped resources",
+			NewAdmissionPolicyFactory().
+				WithContextAwareResources([]ContextAwareResource{
+					{APIVersion: "v1", Kind: "ConfigMap"},
+					{APIVersion: "v1", Kind: "Namespace"},
+					{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+				}).
+				Build(),
+			[]string{
+				"spec.contextAwareResources[1]: Forbidden: cluster-scoped resources cannot be accessed by namespaced policies: v1/Namespace",
+				"spec.contextAwareResources[2]: Forbidden: cluster-scoped resources cannot be accessed by namespaced policies: rbac.authorization.k8s.io/v1/ClusterRole",
+			},
+		},
+		{
+			"admission policy with an invalid apiVersion",
+			NewAdmissionPolicyFactory().
+				WithContextAwareResources([]ContextAwareResource{
+					{APIVersion: "apps/v1/beta", Kind: "Deployment"},
+				}).
+				Build(),
+			[]string{"spec.contextAwareResources[0].apiVersion: Invalid value"},
+		},
+		{
+			"admission policy group with cluster-scoped resources",
+			NewAdmissionPolicyGroupFactory().
+				WithMembers(PolicyGroupMembersWithContext{
+					"pod_privileged": {
+						PolicyGroupMember: PolicyGroupMember{
+							Module: "registry://ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+						},
+						ContextAwareResources: []ContextAwareResource{
+							{APIVersion: "policies.kubewarden.io/v1", Kind: "PolicyServer"},
+						},
+					},
+				}).
+				Build(),
+			[]string{
+				"spec.policies[pod_privileged].contextAwareResources[0]: Forbidden: cluster-scoped resources cannot be accessed by namespaced policies: policies.kubewarden.io/v1/PolicyServer",
+			},
+		},
+		{
+			"admission policy restricted to its own namespace",
+			NewAdmissionPolicyFactory().
+				WithNamespace("team-a").
+				WithContextAwareResources([]ContextAwareResource{
+					{
+						APIVersion:    "v1",
+						Kind:          "ConfigMap",
+						Namespaces:    []string{"team-a"},
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "settings"}},
+						Names:         []string{"settings"},
+					},
+				}).
+				Build(),
+			nil,
+		},
+		{
+			"admission policy accessing other namespaces",
+			NewAdmissionPolicyFactory().
+				WithNamespace("team-a").
+				WithContextAwareResources([]ContextAwareResource{
+					{
+						APIVersion:        "v1",
+						Kind:              "ConfigMap",
+						Namespaces:        []string{"team-a", "team-b"},
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+					},
+				}).
+				Build(),
+			[]string{
+				"spec.contextAwareResources[0].namespaces[1]: Forbidden: namespaced policies can only access the resources of their own namespace: team-a",
+				"spec.contextAwareResources[0].namespaceSelector: Forbidden: namespaced policies can only access the resources of their own namespace",
+			},
+		},
+		{
+			"cluster admission policy with fine-grained constraints",
+			NewClusterAdmissionPolicyFactory().
+				WithContextAwareResources([]ContextAwareResource{
+					{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Namespaces: []string{"kube-system"},
+						Names:      []string{"kube-root-ca.crt"},
+					},
+					{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "environment", Operator: metav1.LabelSelectorOpIn, Values: []string{"production"}},
+							},
+						},
+					},
+				}).
+				Build(),
+			nil,
+		},
+		{
+			"cluster admission policy with invalid constraints",
+			NewClusterAdmissionPolicyFactory().
+				WithContextAwareResources([]ContextAwareResource{
+					{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Namespaces: []string{"Invalid_Namespace"},
+						LabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "app", Operator: metav1.LabelSelectorOpExists, Values: []string{"settings"}},
+							},
+						},
+						Names: []string{""},
+					},
+				}).
+				Build(),
+			[]string{
+				"spec.contextAwareResources[0].namespaces[0]: Invalid value",
+				"spec.contextAwareResources[0].labelSelector.matchExpressions[0].values: Forbidden",
+				"spec.contextAwareResources[0].names[0]: Required value",
+			},
+		},
+		{
+			"cluster admission policy restricting cluster-scoped resources to namespaces",
+			NewClusterAdmissionPolicyFactory().
+				WithContextAwareResources([]ContextAwareResource{
+					{
+						APIVersion:        "v1",
+						Kind:              "Namespace",
+						Namespaces:        []string{"default"},
+						NamespaceSelector: &metav1.LabelSelector{},
+					},
+				}).
+				Build(),
+			[]string{
+				"spec.contextAwareResources[0].namespaces: Forbidden: cannot be set for cluster-scoped resources",
+				"spec.contextAwareResources[0].namespaceSelector: Forbidden: cannot be set for cluster-scoped resources",
+			},
+		},
+		{
+			"cluster admission policy with cluster-scoped resources",
+			NewClusterAdmissionPolicyFactory().
+				WithContextAwareResources([]ContextAwareResource{
+					{APIVersion: "v1", Kind: "Namespace"},
+				}).
+				Build(),
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allErrors := validateContextAwareResourcesField(test.policy, nil)
+
+			require.Len(t, allErrors, len(test.expectedErrors))
+			for i, expectedError := range test.expectedErrors {
+				require.ErrorContains(t, allErrors[i], expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateContextAwareResourcesFieldWithRESTMapper(t *testing.T) {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}, {Group: "example.com", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, meta.RESTScopeRoot)
+	restMapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}, meta.RESTScopeNamespace)
+
+	policy := NewAdmissionPolicyFactory().
+		WithContextAwareResources([]ContextAwareResource{
+			{APIVersion: "v1", Kind: "ConfigMap"},
+			{APIVersion: "example.com/v1", Kind: "Gadget"},
+			{APIVersion: "example.com/v1", Kind: "Widget"},
+			{APIVersion: "v1", Kind: "Namespace"},
+		}).
+		Build()
+
+	allErrors := validateContextAwareResourcesField(policy, restMapper)
+
+	require.Len(t, allErrors, 2)
+	require.ErrorContains(t, allErrors[0], "spec.contextAwareResources[2]: Forbidden: cluster-scoped resources cannot be accessed by namespaced policies: example.com/v1/Widget")
+	require.ErrorContains(t, allErrors[1], "spec.contextAwareResources[3]: Forbidden: cluster-scoped resources cannot be accessed by namespaced policies: v1/Namespace")
+}
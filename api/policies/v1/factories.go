@@ -22,14 +22,15 @@ const (
 )
 
 type AdmissionPolicyFactory struct {
-	name         string
-	namespace    string
-	policyServer string
-	mutating     bool
-	rules        []admissionregistrationv1.RuleWithOperations
-	module       string
-	matchConds   []admissionregistrationv1.MatchCondition
-	mode         PolicyMode
+	name                  string
+	namespace             string
+	policyServer          string
+	mutating              bool
+	rules                 []admissionregistrationv1.RuleWithOperations
+	module                string
+	contextAwareResources []ContextAwareResource
+	matchConds            []admissionregistrationv1.MatchCondition
+	mode                  PolicyMode
 }
 
 func NewAdmissionPolicyFactory() *AdmissionPolicyFactory {
@@ -79,6 +80,11 @@ func (f *AdmissionPolicyFactory) WithMutating(mutating bool) *AdmissionPolicyFac
 	return f
 }
 
+func (f *AdmissionPolicyFactory) WithContextAwareResources(resources []ContextAwareResource) *AdmissionPolicyFactory {
+	f.contextAwareResources = resources
+	return f
+}
+
 func (f *AdmissionPolicyFactory) WithRules(rules []admissionregistrationv1.RuleWithOperations) *AdmissionPolicyFactory {
 	f.rules = rules
 	return f
@@ -118,6 +124,7 @@ func (f *AdmissionPolicyFactory) Build() *AdmissionPolicy {
 				MatchConditions: f.matchConds,
 				Mode:            f.mode,
 			},
+			ContextAwareResources: f.contextAwareResources,
 		},
 	}
 	return &policy
@@ -132,6 +139,7 @@ type ClusterAdmissionPolicyFactory struct {
 	contextAwareResources []ContextAwareResource
 	matchConds            []admissionregistrationv1.MatchCondition
 	mode                  PolicyMode
+	annotations           map[string]string
 }
 
 func NewClusterAdmissionPolicyFactory() *ClusterAdmissionPolicyFactory {
@@ -196,10 +204,16 @@ func (f *ClusterAdmissionPolicyFactory) WithMode(mode PolicyMode) *ClusterAdmiss
 	return f
 }
 
+func (f *ClusterAdmissionPolicyFactory) WithAnnotations(annotations map[string]string) *ClusterAdmissionPolicyFactory {
+	f.annotations = annotations
+	return f
+}
+
 func (f *ClusterAdmissionPolicyFactory) Build() *ClusterAdmissionPolicy {
 	clusterAdmissionPolicy := ClusterAdmissionPolicy{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: f.name,
+			Name:        f.name,
+			Annotations: f.annotations,
 			Finalizers: []string{
 				// On a real cluster the Kubewarden finalizer is added by our mutating
 				// webhook. This is not running now, hence we have to manually add the finalizer
@@ -231,7 +245,7 @@ type AdmissionPolicyGroupFactory struct {
 	policyServer  string
 	rules         []admissionregistrationv1.RuleWithOperations
 	expression    string
-	policyMembers PolicyGroupMembers
+	policyMembers PolicyGroupMembersWithContext
 	matchConds    []admissionregistrationv1.MatchCondition
 	mode          PolicyMode
 }
@@ -255,9 +269,11 @@ func NewAdmissionPolicyGroupFactory() *AdmissionPolicyGroupFactory {
 			},
 		},
 		expression: "pod_privileged()",
-		policyMembers: PolicyGroupMembers{
+		policyMembers: PolicyGroupMembersWithContext{
 			"pod_privileged": {
-				Module: "registry://ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+				PolicyGroupMember: PolicyGroupMember{
+					Module: "registry://ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+				},
 			},
 		},
 		matchConds: []admissionregistrationv1.MatchCondition{
@@ -282,6 +298,11 @@ func (f *AdmissionPolicyGroupFactory) WithPolicyServer(policyServer string) *Adm
 	return f
 }
 
+func (f *AdmissionPolicyGroupFactory) WithMembers(members PolicyGroupMembersWithContext) *AdmissionPolicyGroupFactory {
+	f.policyMembers = members
+	return f
+}
+
 func (f *AdmissionPolicyGroupFactory) WithRules(rules []admissionregistrationv1.RuleWithOperations) *AdmissionPolicyGroupFactory {
 	f.rules = rules
 	return f
@@ -7,7 +7,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// +kubebuilder:validation:Enum=unscheduled;scheduled;pending;active
+// +kubebuilder:validation:Enum=unscheduled;scheduled;pending;active;suspended
 type PolicyStatusEnum string
 
 const (
@@ -25,13 +25,18 @@ const (
 	// PolicyStatusActive informs that the k8s API server should be
 	// forwarding admission review objects to the policy.
 	PolicyStatusActive PolicyStatusEnum = "active"
+	// PolicyStatusSuspended informs that the policy is suspended: its
+	// webhook is removed, the k8s API server does not forward any admission
+	// review object to the policy.
+	PolicyStatusSuspended PolicyStatusEnum = "suspended"
 )
 
-// +kubebuilder:validation:Enum=protect;monitor;unknown
+// +kubebuilder:validation:Enum=protect;warn;monitor;unknown
 type PolicyModeStatus string
 
 const (
 	PolicyModeStatusProtect PolicyModeStatus = "protect"
+	PolicyModeStatusWarn    PolicyModeStatus = "warn"
 	PolicyModeStatusMonitor PolicyModeStatus = "monitor"
 	PolicyModeStatusUnknown PolicyModeStatus = "unknown"
 )
@@ -52,6 +57,26 @@ const (
 	// for this policy, only the latest instance of the policy can be
 	// reached through policy server where it is scheduled.
 	PolicyUniquelyReachable PolicyConditionType = "PolicyUniquelyReachable"
+	// PolicyBreakGlass represents the condition of the policy mode being
+	// downgraded through the break-glass annotations, until the deadline
+	// at which the policy is reverted to "protect" mode.
+	PolicyBreakGlass PolicyConditionType = "PolicyBreakGlass"
+	// PolicyExpiring represents the condition of the policy being about to
+	// expire, or being expired.
+	PolicyExpiring PolicyConditionType = "PolicyExpiring"
+	// PolicyModeTransitionImminent represents the condition of the next
+	// mode transition of the policy enforcement being about to happen.
+	PolicyModeTransitionImminent PolicyConditionType = "PolicyModeTransitionImminent"
+	// PolicyValidatingAdmissionPolicy represents the condition of the
+	// policy being enforced through a native ValidatingAdmissionPolicy.
+	PolicyValidatingAdmissionPolicy PolicyConditionType = "PolicyValidatingAdmissionPolicy"
+	// PolicyRefsResolved represents the condition of the resolution of the
+	// policies referenced by the members of a policy group.
+	PolicyRefsResolved PolicyConditionType = "PolicyRefsResolved"
+	// PolicyResourcesServed represents the condition of the resources
+	// targeted by the rules and the context aware resources of the policy
+	// being served by the cluster.
+	PolicyResourcesServed PolicyConditionType = "PolicyResourcesServed"
 )
 
 const (
@@ -68,6 +93,19 @@ type PolicyStatus struct {
 	// PolicyMode represents the observed policy mode of this policy in
 	// the associated PolicyServer configuration
 	PolicyMode PolicyModeStatus `json:"mode,omitempty"`
+	// EffectiveNamespaceSelector is the namespaceSelector of the policy
+	// webhook. For the cluster-wide policies, it is the namespaceSelector of
+	// the policy merged with the namespaces excluded by the controller.
+	// +optional
+	EffectiveNamespaceSelector *metav1.LabelSelector `json:"effectiveNamespaceSelector,omitempty"`
+	// Exceptions are the PolicyExceptions and ClusterPolicyExceptions applied
+	// to the policy webhook.
+	// +optional
+	Exceptions []PolicyExceptionReference `json:"exceptions,omitempty"`
+	// NextModeTransition is the next mode transition scheduled by the
+	// enforcement of the policy.
+	// +optional
+	NextModeTransition *PolicyModeTransition `json:"nextModeTransition,omitempty"`
 	// Conditions represent the observed conditions of the
 	// ClusterAdmissionPolicy resource.  Known .status.conditions.types
 	// are: "PolicyServerSecretReconciled",
@@ -82,10 +120,35 @@ type PolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// PolicyExceptionReference identifies an exception applied to a policy.
+type PolicyExceptionReference struct {
+	// Kind of the exception, PolicyException or ClusterPolicyException.
+	Kind string `json:"kind"`
+	// Name of the exception.
+	Name string `json:"name"`
+	// Namespace of the PolicyException.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// ExpiresAt is the time after which the exception no longer applies.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// PolicyModeTransition is a scheduled transition of the policy mode.
+type PolicyModeTransition struct {
+	// Mode is the mode of the policy after the transition.
+	Mode PolicyMode `json:"mode"`
+	// Time is the time of the transition.
+	Time metav1.Time `json:"time"`
+}
+
 // +kubebuilder:object:generate:=false
 type PolicySettings interface {
 	GetPolicyMode() PolicyMode
+	GetEnforcement() *PolicyEnforcement
+	GetExpiration() *PolicyExpiration
 	GetModule() string
+	GetModuleRef() string
 	GetSettings() runtime.RawExtension
 	GetContextAwareResources() []ContextAwareResource
 	GetBackgroundAudit() bool
@@ -100,6 +163,9 @@ type PolicySettings interface {
 type PolicyIdentifier interface {
 	GetPolicyServer() string
 	GetUniqueName() string
+	// GetLegacyUniqueName returns the unique name generated by the previous
+	// naming scheme, used to migrate the resources created with it.
+	GetLegacyUniqueName() string
 }
 
 // +kubebuilder:object:generate:=false
@@ -109,6 +175,8 @@ type PolicyAdmissionRegistrationSettings interface {
 	GetFailurePolicy() *admissionregistrationv1.FailurePolicyType
 	GetMatchPolicy() *admissionregistrationv1.MatchPolicyType
 	GetMatchConditions() []admissionregistrationv1.MatchCondition
+	GetReinvocationPolicy() *admissionregistrationv1.ReinvocationPolicyType
+	GetPriority() *int32
 }
 
 // +kubebuilder:object:generate:=false
@@ -122,10 +190,13 @@ type PolicySelectors interface {
 type PolicyBehavior interface {
 	IsMutating() bool
 	IsContextAware() bool
+	IsSuspended() bool
 }
 
 // +kubebuilder:object:generate:=false
 type PolicyLifecycle interface {
+	SetPolicyMode(policyMode PolicyMode)
+	SetSuspended(suspended bool)
 	SetPolicyModeStatus(policyMode PolicyModeStatus)
 	GetStatus() *PolicyStatus
 	SetStatus(status PolicyStatusEnum)
@@ -153,5 +224,6 @@ type PolicyGroup interface {
 	Policy
 	GetPolicyGroupMembersWithContext() PolicyGroupMembersWithContext
 	GetExpression() string
+	GetExpressionVersion() GroupExpressionVersion
 	GetMessage() string
 }
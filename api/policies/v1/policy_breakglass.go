@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+// BreakGlass is the break-glass downgrade of a policy mode: the policy can be
+// switched from "protect" to a less strict mode, for the given reason, until
+// the deadline at which it is reverted to "protect" mode.
+// +kubebuilder:object:generate:=false
+type BreakGlass struct {
+	Reason string
+	Until  time.Time
+}
+
+// GetBreakGlass returns the break-glass downgrade set by the annotations of
+// the policy, or nil when the annotations are not set.
+func GetBreakGlass(policy client.Object) (*BreakGlass, error) {
+	annotations := policy.GetAnnotations()
+	reason, hasReason := annotations[constants.BreakGlassReasonAnnotationKey]
+	until, hasUntil := annotations[constants.BreakGlassUntilAnnotationKey]
+	if !hasReason && !hasUntil {
+		return nil, nil
+	}
+
+	if reason == "" {
+		return nil, fmt.Errorf("the %s annotation must be set", constants.BreakGlassReasonAnnotationKey)
+	}
+	untilTime, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("the %s annotation must be a RFC3339 time", constants.BreakGlassUntilAnnotationKey), err)
+	}
+
+	return &BreakGlass{Reason: reason, Until: untilTime}, nil
+}
+
+// breakGlassAuthorization holds what is needed to authorize a break-glass
+// downgrade: the group allowed to perform it and the user requesting it.
+// +kubebuilder:object:generate:=false
+type breakGlassAuthorization struct {
+	group    string
+	userInfo authenticationv1.UserInfo
+	now      time.Time
+}
+
+// newBreakGlassAuthorization returns the authorization of the user of the
+// admission request in the context.
+func newBreakGlassAuthorization(ctx context.Context, group string) breakGlassAuthorization {
+	authorization := breakGlassAuthorization{group: group, now: time.Now()}
+	if request, err := admission.RequestFromContext(ctx); err == nil {
+		authorization.userInfo = request.UserInfo
+	}
+
+	return authorization
+}
+
+func (a breakGlassAuthorization) isAuthorized() bool {
+	return a.group != "" && slices.Contains(a.userInfo.Groups, a.group)
+}
+
+// validatePolicyModeUpdate validates the transition of the policy mode. A
+// transition to a less strict mode is only allowed together with the
+// break-glass annotations, set by a member of the break-glass group.
+func validatePolicyModeUpdate(oldPolicy, newPolicy Policy, authorization breakGlassAuthorization) field.ErrorList {
+	var allErrors field.ErrorList
+
+	modeErr := validatePolicyModeField(oldPolicy, newPolicy)
+	if !breakGlassAnnotationsChanged(oldPolicy, newPolicy) {
+		if modeErr != nil {
+			allErrors = append(allErrors, modeErr)
+		}
+		return allErrors
+	}
+
+	// The break-glass annotations are removed when the policy is reverted to
+	// protect mode.
+	newBreakGlassAnnotations := hasBreakGlassAnnotations(newPolicy)
+	if !newBreakGlassAnnotations && newPolicy.GetPolicyMode() == PolicyModeProtect {
+		return allErrors
+	}
+
+	annotationsPath := field.NewPath("metadata").Child("annotations")
+	if !authorization.isAuthorized() {
+		detail := "the break-glass policy mode downgrade is not enabled"
+		if authorization.group != "" {
+			detail = fmt.Sprintf("only the members of the %q group can set the break-glass annotations", authorization.group)
+		}
+		allErrors = append(allErrors, field.Forbidden(annotationsPath, detail))
+		if modeErr != nil {
+			allErrors = append(allErrors, modeErr)
+		}
+		return allErrors
+	}
+
+	// Removing the annotations without reverting to protect mode would leave
+	// the policy downgraded without a deadline.
+	if !newBreakGlassAnnotations {
+		return append(allErrors, field.Forbidden(annotationsPath, "the break-glass annotations can only be removed when reverting to protect mode"))
+	}
+
+	breakGlass, err := GetBreakGlass(newPolicy)
+	if err != nil {
+		return append(allErrors, field.Invalid(annotationsPath, newPolicy.GetAnnotations(), err.Error()))
+	}
+	if !breakGlass.Until.After(authorization.now) {
+		allErrors = append(allErrors, field.Invalid(annotationsPath.Key(constants.BreakGlassUntilAnnotationKey),
+			newPolicy.GetAnnotations()[constants.BreakGlassUntilAnnotationKey], "must be in the future"))
+	}
+
+	return allErrors
+}
+
+func hasBreakGlassAnnotations(policy Policy) bool {
+	annotations := policy.GetAnnotations()
+	_, hasReason := annotations[constants.BreakGlassReasonAnnotationKey]
+	_, hasUntil := annotations[constants.BreakGlassUntilAnnotationKey]
+
+	return hasReason || hasUntil
+}
+
+func breakGlassAnnotationsChanged(oldPolicy, newPolicy Policy) bool {
+	for _, key := range []string{constants.BreakGlassReasonAnnotationKey, constants.BreakGlassUntilAnnotationKey} {
+		oldValue, oldOk := oldPolicy.GetAnnotations()[key]
+		newValue, newOk := newPolicy.GetAnnotations()[key]
+		if oldOk != newOk || oldValue != newValue {
+			return true
+		}
+	}
+
+	return false
+}
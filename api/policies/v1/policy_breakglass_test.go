@@ -0,0 +1,177 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+func TestValidatePolicyModeUpdate(t *testing.T) {
+	now := time.Now()
+	until := now.Add(time.Hour).Format(time.RFC3339)
+	member := breakGlassAuthorization{
+		group:    "sre",
+		userInfo: authenticationv1.UserInfo{Username: "alice", Groups: []string{"system:authenticated", "sre"}},
+		now:      now,
+	}
+	nonMember := breakGlassAuthorization{
+		group:    "sre",
+		userInfo: authenticationv1.UserInfo{Username: "bob", Groups: []string{"system:authenticated"}},
+		now:      now,
+	}
+	breakGlassAnnotations := func(reason, until string) map[string]string {
+		return map[string]string{
+			constants.BreakGlassReasonAnnotationKey: reason,
+			constants.BreakGlassUntilAnnotationKey:  until,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		oldPolicy      Policy
+		newPolicy      Policy
+		authorization  breakGlassAuthorization
+		expectedErrors int
+	}{
+		{
+			"downgrade without break-glass annotations",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).Build(),
+			member,
+			1,
+		},
+		{
+			"break-glass downgrade by a member of the group",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", until)).Build(),
+			member,
+			0,
+		},
+		{
+			"break-glass downgrade by a user not member of the group",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", until)).Build(),
+			nonMember,
+			2,
+		},
+		{
+			"break-glass downgrade when disabled",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", until)).Build(),
+			breakGlassAuthorization{userInfo: member.userInfo, now: now},
+			2,
+		},
+		{
+			"break-glass downgrade without reason",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeWarn).WithAnnotations(breakGlassAnnotations("", until)).Build(),
+			member,
+			1,
+		},
+		{
+			"break-glass downgrade with a past deadline",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", now.Add(-time.Hour).Format(time.RFC3339))).Build(),
+			member,
+			1,
+		},
+		{
+			"break-glass deadline extended by a user not member of the group",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", until)).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", now.Add(48*time.Hour).Format(time.RFC3339))).Build(),
+			nonMember,
+			1,
+		},
+		{
+			"break-glass annotations removed without reverting to protect mode",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", until)).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).Build(),
+			member,
+			1,
+		},
+		{
+			"revert to protect mode",
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).WithAnnotations(breakGlassAnnotations("incident", until)).Build(),
+			NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build(),
+			nonMember,
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Len(t, validatePolicyModeUpdate(test.oldPolicy, test.newPolicy, test.authorization), test.expectedErrors)
+		})
+	}
+}
+
+func TestClusterAdmissionPolicyValidateBreakGlassUpdate(t *testing.T) {
+	validator := clusterAdmissionPolicyValidator{breakGlassGroup: "sre", logger: logr.Discard()}
+	oldPolicy := NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build()
+	newPolicy := NewClusterAdmissionPolicyFactory().
+		WithMode(PolicyModeMonitor).
+		WithAnnotations(map[string]string{
+			constants.BreakGlassReasonAnnotationKey: "incident",
+			constants.BreakGlassUntilAnnotationKey:  time.Now().Add(time.Hour).Format(time.RFC3339),
+		}).
+		Build()
+
+	_, err := validator.ValidateUpdate(context.Background(), oldPolicy, newPolicy)
+	require.Error(t, err)
+
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "alice", Groups: []string{"sre"}},
+		},
+	})
+	_, err = validator.ValidateUpdate(ctx, oldPolicy, newPolicy)
+	require.NoError(t, err)
+}
+
+func TestGetBreakGlass(t *testing.T) {
+	policy := NewClusterAdmissionPolicyFactory().Build()
+	breakGlass, err := GetBreakGlass(policy)
+	require.NoError(t, err)
+	assert.Nil(t, breakGlass)
+
+	policy = NewClusterAdmissionPolicyFactory().
+		WithAnnotations(map[string]string{
+			constants.BreakGlassReasonAnnotationKey: "incident",
+			constants.BreakGlassUntilAnnotationKey:  "2025-01-01T10:00:00Z",
+		}).
+		Build()
+	breakGlass, err = GetBreakGlass(policy)
+	require.NoError(t, err)
+	assert.Equal(t, "incident", breakGlass.Reason)
+	assert.Equal(t, time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC), breakGlass.Until.UTC())
+
+	policy = NewClusterAdmissionPolicyFactory().
+		WithAnnotations(map[string]string{constants.BreakGlassReasonAnnotationKey: "incident"}).
+		Build()
+	_, err = GetBreakGlass(policy)
+	require.Error(t, err)
+}
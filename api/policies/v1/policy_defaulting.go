@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get
+
+// resolveDefaultPolicyServer returns the name of the PolicyServer to be used
+// by a policy that does not set one, together with the source of that choice.
+// The lookup order is:
+//  1. the kubewarden.io/default-policy-server annotation of the policy
+//     namespace (only for namespaced policies, when a reader is given)
+//  2. the cluster-wide default configured on the controller
+//  3. the built-in "default" PolicyServer
+func resolveDefaultPolicyServer(ctx context.Context, k8sReader client.Reader, namespace, clusterDefaultPolicyServer string) (string, string, error) {
+	if namespace != "" && k8sReader != nil {
+		ns := &corev1.Namespace{}
+		err := k8sReader.Get(ctx, client.ObjectKey{Name: namespace}, ns)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", "", fmt.Errorf("cannot get namespace %s: %w", namespace, err)
+		}
+		if policyServer := ns.GetAnnotations()[constants.NamespaceDefaultPolicyServerAnnotationKey]; policyServer != "" {
+			return policyServer, constants.PolicyServerDefaultedFromNamespace, nil
+		}
+	}
+
+	if clusterDefaultPolicyServer != "" {
+		return clusterDefaultPolicyServer, constants.PolicyServerDefaultedFromController, nil
+	}
+
+	return constants.DefaultPolicyServer, constants.PolicyServerDefaultedFromBuiltin, nil
+}
+
+// defaultPolicyServer sets the PolicyServer of a policy when it is empty and
+// records where the value came from in an annotation.
+func defaultPolicyServer(ctx context.Context, k8sReader client.Reader, objectMeta *metav1.ObjectMeta, policyServer *string, clusterDefaultPolicyServer string) error {
+	if *policyServer != "" {
+		return nil
+	}
+
+	resolved, source, err := resolveDefaultPolicyServer(ctx, k8sReader, objectMeta.GetNamespace(), clusterDefaultPolicyServer)
+	if err != nil {
+		return err
+	}
+
+	*policyServer = resolved
+	if objectMeta.Annotations == nil {
+		objectMeta.Annotations = make(map[string]string)
+	}
+	objectMeta.Annotations[constants.PolicyServerDefaultedFromAnnotationKey] = source
+
+	return nil
+}
+
+// defaultEnforcementMode sets the mode of a new policy with an enforcement
+// schedule to the mode scheduled at its creation. Only the "protect" default
+// mode is replaced: the other modes are explicit, and they are rejected by
+// the validation when they conflict with the schedule. The CRD defaults the
+// mode to "protect" before this webhook runs, hence an explicit "protect"
+// mode is replaced as well. The mode of the existing policies is driven by
+// the controller.
+func defaultEnforcementMode(objectMeta *metav1.ObjectMeta, mode *PolicyMode, enforcement *PolicyEnforcement) {
+	if enforcement == nil || !objectMeta.CreationTimestamp.IsZero() || (*mode != "" && *mode != PolicyModeProtect) {
+		return
+	}
+
+	now := time.Now()
+	*mode = enforcement.ScheduledMode(now, now)
+}
@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+func TestAdmissionPolicyDefaultPolicyServerSelection(t *testing.T) {
+	annotatedNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "annotated",
+			Annotations: map[string]string{
+				constants.NamespaceDefaultPolicyServerAnnotationKey: "team-a",
+			},
+		},
+	}
+	plainNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "plain",
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithObjects(annotatedNamespace, plainNamespace).Build()
+
+	tests := []struct {
+		name                 string
+		namespace            string
+		policyServer         string
+		clusterDefault       string
+		expectedPolicyServer string
+		expectedSource       string
+	}{
+		{"namespace annotation wins over the cluster default", "annotated", "", "cluster-default", "team-a", constants.PolicyServerDefaultedFromNamespace},
+		{"cluster default", "plain", "", "cluster-default", "cluster-default", constants.PolicyServerDefaultedFromController},
+		{"built-in default", "plain", "", "", constants.DefaultPolicyServer, constants.PolicyServerDefaultedFromBuiltin},
+		{"missing namespace falls back to the cluster default", "missing", "", "cluster-default", "cluster-default", constants.PolicyServerDefaultedFromController},
+		{"explicit policy server is kept", "annotated", "explicit", "cluster-default", "explicit", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defaulter := admissionPolicyDefaulter{
+				k8sReader:           k8sClient,
+				defaultPolicyServer: test.clusterDefault,
+				logger:              logr.Discard(),
+			}
+			policy := &AdmissionPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "policy",
+					Namespace: test.namespace,
+				},
+				Spec: AdmissionPolicySpec{
+					PolicySpec: PolicySpec{
+						PolicyServer: test.policyServer,
+					},
+				},
+			}
+
+			err := defaulter.Default(context.Background(), policy)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedPolicyServer, policy.GetPolicyServer())
+			assert.Equal(t, test.expectedSource, policy.GetAnnotations()[constants.PolicyServerDefaultedFromAnnotationKey])
+		})
+	}
+}
+
+func TestClusterAdmissionPolicyGroupDefaultPolicyServerFromController(t *testing.T) {
+	defaulter := clusterAdmissionPolicyGroupDefaulter{
+		defaultPolicyServer: "cluster-default",
+		logger:              logr.Discard(),
+	}
+	policy := &ClusterAdmissionPolicyGroup{}
+
+	err := defaulter.Default(context.Background(), policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, "cluster-default", policy.GetPolicyServer())
+	assert.Equal(t, constants.PolicyServerDefaultedFromController, policy.GetAnnotations()[constants.PolicyServerDefaultedFromAnnotationKey])
+}
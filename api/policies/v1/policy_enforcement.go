@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// policyModeStrictness orders the policy modes from the least to the most
+// strict one. A policy can only transition to a stricter mode.
+var policyModeStrictness = map[PolicyMode]int{
+	PolicyModeMonitor: 0,
+	PolicyModeWarn:    1,
+	PolicyModeProtect: 2,
+}
+
+// IsStricterThan returns true when the mode is stricter than the other one.
+// Unknown modes are not comparable.
+func (m PolicyMode) IsStricterThan(other PolicyMode) bool {
+	strictness, known := policyModeStrictness[m]
+	otherStrictness, otherKnown := policyModeStrictness[other]
+
+	return known && otherKnown && strictness > otherStrictness
+}
+
+// Transitions returns the mode transitions of the schedule, in order, for a
+// policy created at the given time.
+func (e *PolicyEnforcement) Transitions(creationTime time.Time) []PolicyModeTransition {
+	var protectTime time.Time
+	switch {
+	case e.ProtectAt != nil:
+		protectTime = e.ProtectAt.Time
+	case e.MonitorPeriod != nil:
+		protectTime = creationTime.Add(e.MonitorPeriod.Duration)
+		if e.WarnPeriod != nil {
+			protectTime = protectTime.Add(e.WarnPeriod.Duration)
+		}
+	default:
+		return nil
+	}
+
+	transitions := []PolicyModeTransition{}
+	if e.WarnPeriod != nil {
+		transitions = append(transitions, newPolicyModeTransition(PolicyModeWarn, protectTime.Add(-e.WarnPeriod.Duration)))
+	}
+
+	return append(transitions, newPolicyModeTransition(PolicyModeProtect, protectTime))
+}
+
+// ScheduledMode returns the mode scheduled at the given time for a policy
+// created at creationTime.
+func (e *PolicyEnforcement) ScheduledMode(creationTime, now time.Time) PolicyMode {
+	mode := PolicyModeMonitor
+	for _, transition := range e.Transitions(creationTime) {
+		if !transition.Time.After(now) {
+			mode = transition.Mode
+		}
+	}
+
+	return mode
+}
+
+// NextTransition returns the first transition scheduled after the given time
+// to a mode stricter than the current one, or nil when there is none.
+func (e *PolicyEnforcement) NextTransition(creationTime, now time.Time, currentMode PolicyMode) *PolicyModeTransition {
+	for _, transition := range e.Transitions(creationTime) {
+		if transition.Time.After(now) && transition.Mode.IsStricterThan(currentMode) {
+			return &transition
+		}
+	}
+
+	return nil
+}
+
+// newPolicyModeTransition returns a transition with the time truncated to
+// the second, as it is serialized.
+func newPolicyModeTransition(mode PolicyMode, transitionTime time.Time) PolicyModeTransition {
+	return PolicyModeTransition{
+		Mode: mode,
+		Time: metav1.NewTime(transitionTime).Rfc3339Copy(),
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestPolicyEnforcementSchedule(t *testing.T) {
+	creationTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name               string
+		enforcement        PolicyEnforcement
+		now                time.Time
+		currentMode        PolicyMode
+		expectedMode       PolicyMode
+		expectedTransition *PolicyModeTransition
+	}{
+		{
+			"monitor period",
+			PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: 7 * day}},
+			creationTime.Add(day),
+			PolicyModeMonitor,
+			PolicyModeMonitor,
+			&PolicyModeTransition{Mode: PolicyModeProtect, Time: metav1.NewTime(creationTime.Add(7 * day))},
+		},
+		{
+			"monitor period elapsed",
+			PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: 7 * day}},
+			creationTime.Add(7 * day),
+			PolicyModeMonitor,
+			PolicyModeProtect,
+			nil,
+		},
+		{
+			"monitor and warn periods",
+			PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: 7 * day}, WarnPeriod: &metav1.Duration{Duration: 3 * day}},
+			creationTime.Add(day),
+			PolicyModeMonitor,
+			PolicyModeMonitor,
+			&PolicyModeTransition{Mode: PolicyModeWarn, Time: metav1.NewTime(creationTime.Add(7 * day))},
+		},
+		{
+			"warn period",
+			PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: 7 * day}, WarnPeriod: &metav1.Duration{Duration: 3 * day}},
+			creationTime.Add(8 * day),
+			PolicyModeWarn,
+			PolicyModeWarn,
+			&PolicyModeTransition{Mode: PolicyModeProtect, Time: metav1.NewTime(creationTime.Add(10 * day))},
+		},
+		{
+			"warn period before the protect date",
+			PolicyEnforcement{ProtectAt: &metav1.Time{Time: creationTime.Add(30 * day)}, WarnPeriod: &metav1.Duration{Duration: 10 * day}},
+			creationTime.Add(25 * day),
+			PolicyModeMonitor,
+			PolicyModeWarn,
+			&PolicyModeTransition{Mode: PolicyModeProtect, Time: metav1.NewTime(creationTime.Add(30 * day))},
+		},
+		{
+			"policy already switched to protect mode",
+			PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: 7 * day}, WarnPeriod: &metav1.Duration{Duration: 3 * day}},
+			creationTime.Add(day),
+			PolicyModeProtect,
+			PolicyModeMonitor,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedMode, test.enforcement.ScheduledMode(creationTime, test.now))
+
+			transition := test.enforcement.NextTransition(creationTime, test.now, test.currentMode)
+			if test.expectedTransition == nil {
+				require.Nil(t, transition)
+				return
+			}
+			require.NotNil(t, transition)
+			assert.Equal(t, test.expectedTransition.Mode, transition.Mode)
+			assert.True(t, test.expectedTransition.Time.Equal(&transition.Time))
+		})
+	}
+}
+
+func TestPolicyModeIsStricterThan(t *testing.T) {
+	assert.True(t, PolicyModeProtect.IsStricterThan(PolicyModeWarn))
+	assert.True(t, PolicyModeWarn.IsStricterThan(PolicyModeMonitor))
+	assert.False(t, PolicyModeMonitor.IsStricterThan(PolicyModeWarn))
+	assert.False(t, PolicyModeProtect.IsStricterThan(PolicyModeProtect))
+	assert.False(t, PolicyModeProtect.IsStricterThan(""))
+}
+
+func TestValidateEnforcementField(t *testing.T) {
+	tests := []struct {
+		name           string
+		enforcement    *PolicyEnforcement
+		expectedErrors int
+	}{
+		{"no enforcement", nil, 0},
+		{"monitor period", &PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: time.Hour}, WarnPeriod: &metav1.Duration{Duration: time.Hour}}, 0},
+		{"protect date", &PolicyEnforcement{ProtectAt: &metav1.Time{Time: time.Now()}}, 0},
+		{"empty schedule", &PolicyEnforcement{WarnPeriod: &metav1.Duration{Duration: time.Hour}}, 1},
+		{"monitor period and protect date", &PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: time.Hour}, ProtectAt: &metav1.Time{Time: time.Now()}}, 1},
+		{"negative periods", &PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: -time.Hour}, WarnPeriod: &metav1.Duration{}}, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewClusterAdmissionPolicyFactory().Build()
+			policy.Spec.Enforcement = test.enforcement
+
+			assert.Len(t, validateEnforcementField(policy), test.expectedErrors)
+		})
+	}
+}
+
+func TestDefaultEnforcementMode(t *testing.T) {
+	enforcement := &PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: time.Hour}}
+
+	tests := []struct {
+		name         string
+		mode         PolicyMode
+		enforcement  *PolicyEnforcement
+		expectedMode PolicyMode
+	}{
+		{"no enforcement", PolicyModeProtect, nil, PolicyModeProtect},
+		{"default mode", PolicyModeProtect, enforcement, PolicyModeMonitor},
+		{"empty mode", "", enforcement, PolicyModeMonitor},
+		{"explicit mode", PolicyModeWarn, enforcement, PolicyModeWarn},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mode := test.mode
+			defaultEnforcementMode(&metav1.ObjectMeta{}, &mode, test.enforcement)
+
+			assert.Equal(t, test.expectedMode, mode)
+		})
+	}
+}
+
+func TestValidateEnforcementModeAtCreation(t *testing.T) {
+	now := time.Now()
+	enforcement := &PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: time.Hour}}
+
+	tests := []struct {
+		name        string
+		mode        PolicyMode
+		enforcement *PolicyEnforcement
+		expectError bool
+	}{
+		{"no enforcement", PolicyModeProtect, nil, false},
+		{"scheduled mode", PolicyModeMonitor, enforcement, false},
+		{"conflicting mode", PolicyModeWarn, enforcement, true},
+		{"protect date reached", PolicyModeProtect, &PolicyEnforcement{ProtectAt: &metav1.Time{Time: now.Add(-time.Hour)}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewClusterAdmissionPolicyFactory().WithMode(test.mode).Build()
+			policy.Spec.Enforcement = test.enforcement
+
+			err := validateEnforcementModeAtCreation(policy, now)
+			if test.expectError {
+				require.NotNil(t, err)
+				assert.Equal(t, field.ErrorTypeInvalid, err.Type)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
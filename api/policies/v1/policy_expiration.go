@@ -0,0 +1,42 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+)
+
+// ExpiryTime returns the time at which a policy created at the given time
+// expires, or the zero time when the expiration is not set.
+func (e *PolicyExpiration) ExpiryTime(creationTime time.Time) time.Time {
+	switch {
+	case e.ExpiresAt != nil:
+		return e.ExpiresAt.Time
+	case e.TTL != nil:
+		return creationTime.Add(e.TTL.Duration)
+	default:
+		return time.Time{}
+	}
+}
+
+// GetAction returns the action taken once the policy expires, defaulting to
+// suspend.
+func (e *PolicyExpiration) GetAction() PolicyExpirationAction {
+	if e.Action == "" {
+		return PolicyExpirationActionSuspend
+	}
+
+	return e.Action
+}
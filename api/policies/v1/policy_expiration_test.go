@@ -0,0 +1,72 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyExpiryTime(t *testing.T) {
+	creationTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := metav1.NewTime(creationTime.Add(48 * time.Hour))
+
+	assert.Equal(t, expiresAt.Time, (&PolicyExpiration{ExpiresAt: &expiresAt}).ExpiryTime(creationTime))
+	assert.Equal(t, creationTime.Add(time.Hour), (&PolicyExpiration{TTL: &metav1.Duration{Duration: time.Hour}}).ExpiryTime(creationTime))
+	assert.True(t, (&PolicyExpiration{}).ExpiryTime(creationTime).IsZero())
+
+	assert.Equal(t, PolicyExpirationActionSuspend, (&PolicyExpiration{}).GetAction())
+	assert.Equal(t, PolicyExpirationActionDelete, (&PolicyExpiration{Action: PolicyExpirationActionDelete}).GetAction())
+}
+
+func TestValidateExpirationField(t *testing.T) {
+	tests := []struct {
+		name           string
+		expiration     *PolicyExpiration
+		expectedErrors int
+	}{
+		{"no expiration", nil, 0},
+		{"expiration date", &PolicyExpiration{ExpiresAt: &metav1.Time{Time: time.Now()}, Action: PolicyExpirationActionDelete}, 0},
+		{"ttl", &PolicyExpiration{TTL: &metav1.Duration{Duration: time.Hour}}, 0},
+		{"empty expiration", &PolicyExpiration{Action: PolicyExpirationActionSuspend}, 1},
+		{"expiration date and ttl", &PolicyExpiration{ExpiresAt: &metav1.Time{Time: time.Now()}, TTL: &metav1.Duration{Duration: time.Hour}}, 1},
+		{"negative ttl", &PolicyExpiration{TTL: &metav1.Duration{Duration: -time.Hour}}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewClusterAdmissionPolicyFactory().Build()
+			policy.Spec.Expiration = test.expiration
+
+			assert.Len(t, validateExpirationField(policy), test.expectedErrors)
+		})
+	}
+}
+
+func TestValidateExpiresAtInTheFuture(t *testing.T) {
+	now := time.Now()
+	policy := NewClusterAdmissionPolicyFactory().Build()
+	assert.Nil(t, validateExpiresAtInTheFuture(policy, now))
+
+	policy.Spec.Expiration = &PolicyExpiration{ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)}}
+	assert.Nil(t, validateExpiresAtInTheFuture(policy, now))
+
+	policy.Spec.Expiration = &PolicyExpiration{ExpiresAt: &metav1.Time{Time: now.Add(-time.Hour)}}
+	assert.NotNil(t, validateExpiresAtInTheFuture(policy, now))
+}
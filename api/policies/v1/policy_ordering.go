@@ -0,0 +1,182 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/go-logr/logr"
+)
+
+// mutatingPolicyOrderingWarnings warns about the mutating policies
+// overlapping with the given one, on the same resources, without an explicit
+// invocation order. Failing to list the policies is only logged, as the
+// warnings are not required to admit the policy.
+func mutatingPolicyOrderingWarnings(ctx context.Context, k8sReader client.Reader, policy Policy, logger logr.Logger) admission.Warnings {
+	if k8sReader == nil || !policy.IsMutating() {
+		return nil
+	}
+
+	candidates, err := listMutatingPolicyCandidates(ctx, k8sReader, policy)
+	if err != nil {
+		logger.Error(err, "Cannot check the ordering of the mutating policies", "name", policy.GetName())
+		return nil
+	}
+
+	var warnings admission.Warnings
+	for _, candidate := range candidates {
+		if !candidate.IsMutating() || candidate.GetUniqueName() == policy.GetUniqueName() {
+			continue
+		}
+		if hasExplicitOrdering(policy, candidate) || !rulesOverlap(policy.GetRules(), candidate.GetRules()) {
+			continue
+		}
+
+		warnings = append(warnings, fmt.Sprintf(
+			"the mutating policy overlaps with the mutating %s on the same resources, without an explicit ordering: "+
+				"set different priorities to make their invocation order deterministic", policyDisplayName(candidate)))
+	}
+
+	return warnings
+}
+
+// listMutatingPolicyCandidates lists the policies that can be invoked on the
+// same requests as the given one: the namespaced policies are only invoked
+// on the requests of their namespace.
+func listMutatingPolicyCandidates(ctx context.Context, k8sReader client.Reader, policy Policy) ([]Policy, error) {
+	var candidates []Policy
+
+	clusterAdmissionPolicies := &ClusterAdmissionPolicyList{}
+	if err := k8sReader.List(ctx, clusterAdmissionPolicies); err != nil {
+		return nil, fmt.Errorf("cannot list ClusterAdmissionPolicies: %w", err)
+	}
+	for i := range clusterAdmissionPolicies.Items {
+		candidates = append(candidates, &clusterAdmissionPolicies.Items[i])
+	}
+
+	admissionPolicies := &AdmissionPolicyList{}
+	if err := k8sReader.List(ctx, admissionPolicies, client.InNamespace(policy.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("cannot list AdmissionPolicies: %w", err)
+	}
+	for i := range admissionPolicies.Items {
+		candidates = append(candidates, &admissionPolicies.Items[i])
+	}
+
+	return candidates, nil
+}
+
+// hasExplicitOrdering returns true when the invocation order of the two
+// policies is given by their priorities.
+func hasExplicitOrdering(policy, other Policy) bool {
+	priority, otherPriority := policy.GetPriority(), other.GetPriority()
+	if priority == nil || otherPriority == nil {
+		return priority != nil || otherPriority != nil
+	}
+
+	return *priority != *otherPriority
+}
+
+func policyDisplayName(policy Policy) string {
+	switch policy.(type) {
+	case *AdmissionPolicy:
+		return fmt.Sprintf("AdmissionPolicy %s/%s", policy.GetNamespace(), policy.GetName())
+	default:
+		return "ClusterAdmissionPolicy " + policy.GetName()
+	}
+}
+
+// rulesOverlap returns true when a request can match both the rules.
+func rulesOverlap(rules, otherRules []admissionregistrationv1.RuleWithOperations) bool {
+	for _, rule := range rules {
+		for _, otherRule := range otherRules {
+			if ruleOverlaps(rule, otherRule) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func ruleOverlaps(rule, other admissionregistrationv1.RuleWithOperations) bool {
+	operations := make([]string, 0, len(rule.Operations))
+	for _, operation := range rule.Operations {
+		operations = append(operations, string(operation))
+	}
+	otherOperations := make([]string, 0, len(other.Operations))
+	for _, operation := range other.Operations {
+		otherOperations = append(otherOperations, string(operation))
+	}
+
+	if !valuesOverlap(operations, otherOperations) ||
+		!valuesOverlap(rule.APIGroups, other.APIGroups) ||
+		!valuesOverlap(rule.APIVersions, other.APIVersions) {
+		return false
+	}
+
+	for _, resource := range rule.Resources {
+		if slices.ContainsFunc(other.Resources, func(otherResource string) bool {
+			return resourcesOverlap(resource, otherResource)
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// valuesOverlap returns true when the lists share a value, "*" matching any
+// value.
+func valuesOverlap(values, otherValues []string) bool {
+	for _, value := range values {
+		for _, otherValue := range otherValues {
+			if value == otherValue || value == "*" || otherValue == "*" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resourcesOverlap matches two resources of webhook rules: "*" matches all
+// the resources, "pods/*" all the subresources of pods, and "*/*" all the
+// resources and their subresources.
+func resourcesOverlap(resource, otherResource string) bool {
+	name, subresource, _ := strings.Cut(resource, "/")
+	otherName, otherSubresource, _ := strings.Cut(otherResource, "/")
+
+	if name != otherName && name != "*" && otherName != "*" {
+		return false
+	}
+
+	switch {
+	case subresource == otherSubresource:
+		return true
+	case subresource == "*":
+		return otherSubresource != "" || name == "*"
+	case otherSubresource == "*":
+		return subresource != "" || otherName == "*"
+	default:
+		return false
+	}
+}
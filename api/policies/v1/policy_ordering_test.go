@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateMutatingOrderingFields(t *testing.T) {
+	reinvocationPolicy := admissionregistrationv1.IfNeededReinvocationPolicy
+
+	mutatingPolicy := NewClusterAdmissionPolicyFactory().WithMutating(true).Build()
+	mutatingPolicy.Spec.ReinvocationPolicy = &reinvocationPolicy
+	mutatingPolicy.Spec.Priority = ptr.To(int32(10))
+	assert.Empty(t, validateMutatingOrderingFields(mutatingPolicy))
+
+	validatingPolicy := NewClusterAdmissionPolicyFactory().WithMutating(false).Build()
+	validatingPolicy.Spec.ReinvocationPolicy = &reinvocationPolicy
+	validatingPolicy.Spec.Priority = ptr.To(int32(10))
+	assert.Len(t, validateMutatingOrderingFields(validatingPolicy), 2)
+}
+
+func TestResourcesOverlap(t *testing.T) {
+	tests := []struct {
+		resource      string
+		otherResource string
+		expected      bool
+	}{
+		{"pods", "pods", true},
+		{"pods", "deployments", false},
+		{"*", "pods", true},
+		{"*", "pods/exec", false},
+		{"*/*", "pods", true},
+		{"*/*", "pods/exec", true},
+		{"pods/*", "pods/exec", true},
+		{"pods/*", "pods", false},
+		{"pods/*", "deployments/scale", false},
+		{"*/scale", "deployments/scale", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.resource+" "+test.otherResource, func(t *testing.T) {
+			assert.Equal(t, test.expected, resourcesOverlap(test.resource, test.otherResource))
+			assert.Equal(t, test.expected, resourcesOverlap(test.otherResource, test.resource))
+		})
+	}
+}
+
+func TestMutatingPolicyOrderingWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	podsRules := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"pods"},
+			},
+		},
+	}
+	deploymentsRules := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"apps"},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"deployments"},
+			},
+		},
+	}
+
+	unorderedPolicy := NewClusterAdmissionPolicyFactory().WithName("unordered").WithMutating(true).WithRules(podsRules).Build()
+	prioritizedPolicy := NewClusterAdmissionPolicyFactory().WithName("prioritized").WithMutating(true).WithRules(podsRules).Build()
+	prioritizedPolicy.Spec.Priority = ptr.To(int32(10))
+	deploymentsPolicy := NewClusterAdmissionPolicyFactory().WithName("deployments").WithMutating(true).WithRules(deploymentsRules).Build()
+	otherNamespacePolicy := NewAdmissionPolicyFactory().WithName("other-namespace").WithNamespace("other").WithMutating(true).WithRules(podsRules).Build()
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(unorderedPolicy, prioritizedPolicy, deploymentsPolicy, otherNamespacePolicy).
+		Build()
+
+	tests := []struct {
+		name             string
+		policy           Policy
+		expectedWarnings int
+	}{
+		{
+			"policy without priority",
+			NewAdmissionPolicyFactory().WithName("policy").WithNamespace("default").WithMutating(true).WithRules(podsRules).Build(),
+			1,
+		},
+		{
+			"policy with the priority of an overlapping policy",
+			func() Policy {
+				policy := NewAdmissionPolicyFactory().WithName("policy").WithNamespace("default").WithMutating(true).WithRules(podsRules).Build()
+				policy.Spec.Priority = ptr.To(int32(10))
+				return policy
+			}(),
+			1,
+		},
+		{
+			"policy with a different priority",
+			func() Policy {
+				policy := NewAdmissionPolicyFactory().WithName("policy").WithNamespace("default").WithMutating(true).WithRules(podsRules).Build()
+				policy.Spec.Priority = ptr.To(int32(20))
+				return policy
+			}(),
+			0,
+		},
+		{
+			"validating policy",
+			NewAdmissionPolicyFactory().WithName("policy").WithNamespace("default").WithMutating(false).WithRules(podsRules).Build(),
+			0,
+		},
+		{
+			"cluster-wide policy overlapping with the namespaced policies",
+			unorderedPolicy,
+			1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warnings := mutatingPolicyOrderingWarnings(context.Background(), k8sClient, test.policy, logr.Discard())
+			assert.Len(t, warnings, test.expectedWarnings)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/go-logr/logr"
+)
+
+// ValidateServedResources checks that the resources targeted by the rules of
+// the policy, and the kinds of its context aware resources, are served by the
+// cluster. A typo in the rules or in the context aware resources is otherwise
+// accepted, and silently never matches.
+// The errors of the RESTMapper other than the missing matches are returned,
+// because the check cannot be performed.
+func ValidateServedResources(policy Policy, restMapper meta.RESTMapper) (field.ErrorList, error) {
+	var allErrors field.ErrorList
+
+	rulesErrors, err := validateServedRules(policy, restMapper)
+	if err != nil {
+		return nil, err
+	}
+	allErrors = append(allErrors, rulesErrors...)
+
+	contextAwareResourcesErrors, err := validateServedContextAwareResourcesField(policy, restMapper)
+	if err != nil {
+		return nil, err
+	}
+	allErrors = append(allErrors, contextAwareResourcesErrors...)
+
+	return allErrors, nil
+}
+
+// servedResourcesWarnings returns the resources of the policy not served by
+// the cluster as admission warnings or, in strict mode, as validation errors.
+func servedResourcesWarnings(policy Policy, restMapper meta.RESTMapper, strict bool, logger logr.Logger) (admission.Warnings, field.ErrorList) {
+	if restMapper == nil {
+		return nil, nil
+	}
+
+	allErrors, err := ValidateServedResources(policy, restMapper)
+	if err != nil {
+		logger.Error(err, "Cannot check the resources served by the cluster", "name", policy.GetName())
+		return nil, nil
+	}
+	if strict {
+		return nil, allErrors
+	}
+
+	var warnings admission.Warnings
+	for _, err := range allErrors {
+		warnings = append(warnings, err.Error())
+	}
+
+	return warnings, nil
+}
+
+// strictServedResourcesOnUpdate tells whether the resources not served by the
+// cluster reject the update of the policy. They do only when the update
+// changes the rules or the context aware resources: the policies whose
+// resources have been removed from the cluster after their creation must
+// remain updatable, in particular to remove their finalizers on deletion.
+func strictServedResourcesOnUpdate(strict bool, oldPolicy, newPolicy Policy) bool {
+	if !strict || newPolicy.GetDeletionTimestamp() != nil {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(oldPolicy.GetRules(), newPolicy.GetRules()) ||
+		!equality.Semantic.DeepEqual(servedContextAwareResources(oldPolicy), servedContextAwareResources(newPolicy))
+}
+
+// servedContextAwareResources returns the context aware resources of the
+// policy, or of the members of the policy group, indexed by member name.
+func servedContextAwareResources(policy Policy) map[string][]ContextAwareResource {
+	policyGroup, ok := policy.(PolicyGroup)
+	if !ok {
+		return map[string][]ContextAwareResource{"": policy.GetContextAwareResources()}
+	}
+
+	resources := map[string][]ContextAwareResource{}
+	for name, member := range policyGroup.GetPolicyGroupMembersWithContext() {
+		resources[name] = member.ContextAwareResources
+	}
+
+	return resources
+}
+
+// validateServedRules checks that each API group, version and resource of the
+// rules is served by the cluster along with some of the other values of the
+// rule: the rules match the combinations of their API groups, versions and
+// resources, not all of them have to be served. When none of them is served,
+// the whole rule is reported. The rules using wildcards are not checked.
+func validateServedRules(policy Policy, restMapper meta.RESTMapper) (field.ErrorList, error) {
+	var allErrors field.ErrorList
+	rulesField := field.NewPath("spec", "rules")
+
+	for i, rule := range policy.GetRules() {
+		if slices.Contains(rule.Rule.APIGroups, "*") || slices.Contains(rule.Rule.APIVersions, "*") ||
+			slices.ContainsFunc(rule.Rule.Resources, func(resource string) bool { return strings.Contains(resource, "*") }) {
+			continue
+		}
+
+		servedGroups := sets.New[string]()
+		servedVersions := sets.New[string]()
+		servedResources := sets.New[string]()
+		for _, group := range rule.Rule.APIGroups {
+			for _, version := range rule.Rule.APIVersions {
+				for _, resource := range rule.Rule.Resources {
+					// The subresources are served along with their resource.
+					resourceName, _, _ := strings.Cut(resource, "/")
+					if resourceName == "" {
+						continue
+					}
+
+					served, err := restMapper.ResourceFor(schema.GroupVersionResource{Group: group, Version: version, Resource: resourceName})
+					if err != nil {
+						if !meta.IsNoMatchError(err) {
+							return nil, err
+						}
+						continue
+					}
+					// The RESTMapper also resolves the singular names, which
+					// are never matched by the webhooks.
+					if served.Resource != resourceName {
+						continue
+					}
+
+					servedGroups.Insert(group)
+					servedVersions.Insert(version)
+					servedResources.Insert(resource)
+				}
+			}
+		}
+
+		ruleField := rulesField.Index(i).Child("rule")
+		if servedResources.Len() == 0 {
+			allErrors = append(allErrors, field.Invalid(ruleField.Child("resources"), rule.Rule.Resources,
+				"none of the resources is served by the cluster in the API groups and versions of the rule"))
+			continue
+		}
+		for j, group := range rule.Rule.APIGroups {
+			if !servedGroups.Has(group) {
+				allErrors = append(allErrors, field.Invalid(ruleField.Child("apiGroups").Index(j), group,
+					"none of the resources of the rule is served by the cluster in this API group"))
+			}
+		}
+		for j, version := range rule.Rule.APIVersions {
+			if !servedVersions.Has(version) {
+				allErrors = append(allErrors, field.Invalid(ruleField.Child("apiVersions").Index(j), version,
+					"none of the resources of the rule is served by the cluster at this version"))
+			}
+		}
+		for j, resource := range rule.Rule.Resources {
+			if !servedResources.Has(resource) {
+				allErrors = append(allErrors, field.Invalid(ruleField.Child("resources").Index(j), resource,
+					"the resource is not served by the cluster in the API groups and versions of the rule"))
+			}
+		}
+	}
+
+	return allErrors, nil
+}
+
+func validateServedContextAwareResourcesField(policy Policy, restMapper meta.RESTMapper) (field.ErrorList, error) {
+	policyGroup, ok := policy.(PolicyGroup)
+	if !ok {
+		return validateServedContextAwareResources(policy.GetContextAwareResources(), restMapper, field.NewPath("spec", "contextAwareResources"))
+	}
+
+	var allErrors field.ErrorList
+	for name, member := range policyGroup.GetPolicyGroupMembersWithContext() {
+		memberErrors, err := validateServedContextAwareResources(
+			member.ContextAwareResources, restMapper, field.NewPath("spec", "policies").Key(name).Child("contextAwareResources"))
+		if err != nil {
+			return nil, err
+		}
+		allErrors = append(allErrors, memberErrors...)
+	}
+
+	return allErrors, nil
+}
+
+func validateServedContextAwareResources(resources []ContextAwareResource, restMapper meta.RESTMapper, fldPath *field.Path) (field.ErrorList, error) {
+	var allErrors field.ErrorList
+
+	for i, resource := range resources {
+		groupVersion, err := schema.ParseGroupVersion(resource.APIVersion)
+		if err != nil {
+			// The apiVersion is validated along with the other fields of
+			// the context aware resources.
+			continue
+		}
+
+		if _, err := restMapper.RESTMapping(groupVersion.WithKind(resource.Kind).GroupKind(), groupVersion.Version); err != nil {
+			if !meta.IsNoMatchError(err) {
+				return nil, err
+			}
+			allErrors = append(allErrors, field.Invalid(fldPath.Index(i).Child("kind"),
+				resource.APIVersion+"/"+resource.Kind, "the kind is not served by the cluster"))
+		}
+	}
+
+	return allErrors, nil
+}
@@ -0,0 +1,230 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var servedRules = []admissionregistrationv1.RuleWithOperations{{
+	Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	Rule: admissionregistrationv1.Rule{
+		APIGroups:   []string{""},
+		APIVersions: []string{"v1"},
+		Resources:   []string{"pods"},
+	},
+}}
+
+func newServedResourcesRESTMapper() meta.RESTMapper {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}, {Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	return restMapper
+}
+
+func TestValidateServedResources(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          []admissionregistrationv1.RuleWithOperations
+		resources      []ContextAwareResource
+		expectedErrors []string
+	}{
+		{
+			"served resources",
+			[]admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"", "apps"},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods", "pods/exec", "deployments"},
+				},
+			}},
+			[]ContextAwareResource{{APIVersion: "apps/v1", Kind: "Deployment"}},
+			nil,
+		},
+		{
+			"wildcards",
+			[]admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"*"},
+					APIVersions: []string{"*"},
+					Resources:   []string{"*"},
+				},
+			}},
+			nil,
+			nil,
+		},
+		{
+			"misspelled resources",
+			[]admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"apps"},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"deployments", "deployment", "Deployments"},
+				},
+			}},
+			[]ContextAwareResource{{APIVersion: "v1", Kind: "Configmap"}},
+			[]string{
+				"spec.rules[0].rule.resources[1]: Invalid value: \"deployment\": the resource is not served by the cluster in the API groups and versions of the rule",
+				"spec.rules[0].rule.resources[2]: Invalid value: \"Deployments\": the resource is not served by the cluster in the API groups and versions of the rule",
+				"spec.contextAwareResources[0].kind: Invalid value: \"v1/Configmap\": the kind is not served by the cluster",
+			},
+		},
+		{
+			"rule not served",
+			[]admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"apps"},
+					APIVersions: []string{"v1beta9"},
+					Resources:   []string{"deployments"},
+				},
+			}},
+			nil,
+			[]string{
+				"spec.rules[0].rule.resources: Invalid value: []string{\"deployments\"}: none of the resources is served by the cluster in the API groups and versions of the rule",
+			},
+		},
+		{
+			"version not served",
+			[]admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"apps"},
+					APIVersions: []string{"v1", "v1beta9"},
+					Resources:   []string{"deployments"},
+				},
+			}},
+			nil,
+			[]string{
+				"spec.rules[0].rule.apiVersions[1]: Invalid value: \"v1beta9\": none of the resources of the rule is served by the cluster at this version",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewClusterAdmissionPolicyFactory().
+				WithRules(test.rules).
+				WithContextAwareResources(test.resources).
+				Build()
+
+			allErrors, err := ValidateServedResources(policy, newServedResourcesRESTMapper())
+			require.NoError(t, err)
+			require.Len(t, allErrors, len(test.expectedErrors))
+			for i, expectedError := range test.expectedErrors {
+				require.ErrorContains(t, allErrors[i], expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateServedResourcesOfPolicyGroupMembers(t *testing.T) {
+	policyGroup := NewClusterAdmissionPolicyGroupFactory().
+		WithRules(servedRules).
+		WithMembers(PolicyGroupMembersWithContext{
+			"pod_privileged": {
+				PolicyGroupMember: PolicyGroupMember{
+					Module: "registry://ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+				},
+				ContextAwareResources: []ContextAwareResource{{APIVersion: "example.com/v1", Kind: "Widget"}},
+			},
+		}).
+		Build()
+
+	allErrors, err := ValidateServedResources(policyGroup, newServedResourcesRESTMapper())
+	require.NoError(t, err)
+	require.Len(t, allErrors, 1)
+	require.Equal(t, "spec.policies[pod_privileged].contextAwareResources[0].kind", allErrors[0].Field)
+}
+
+func TestServedResourcesWarnings(t *testing.T) {
+	policy := NewClusterAdmissionPolicyFactory().
+		WithRules(servedRules).
+		WithContextAwareResources([]ContextAwareResource{{APIVersion: "v1", Kind: "Configmap"}}).
+		Build()
+
+	warnings, allErrors := servedResourcesWarnings(policy, newServedResourcesRESTMapper(), false, logr.Discard())
+	require.Empty(t, allErrors)
+	require.Equal(t, []string{"spec.contextAwareResources[0].kind: Invalid value: \"v1/Configmap\": the kind is not served by the cluster"}, []string(warnings))
+
+	warnings, allErrors = servedResourcesWarnings(policy, newServedResourcesRESTMapper(), true, logr.Discard())
+	require.Empty(t, warnings)
+	require.Len(t, allErrors, 1)
+
+	warnings, allErrors = servedResourcesWarnings(policy, nil, true, logr.Discard())
+	require.Empty(t, warnings)
+	require.Empty(t, allErrors)
+}
+
+func TestStrictServedResourcesOnUpdate(t *testing.T) {
+	oldPolicy := NewClusterAdmissionPolicyFactory().
+		WithContextAwareResources([]ContextAwareResource{{APIVersion: "example.com/v1", Kind: "Widget"}}).
+		Build()
+
+	unchangedPolicy := oldPolicy.DeepCopy()
+	unchangedPolicy.Finalizers = nil
+	require.False(t, strictServedResourcesOnUpdate(true, oldPolicy, unchangedPolicy))
+
+	deletedPolicy := oldPolicy.DeepCopy()
+	deletedPolicy.Spec.ContextAwareResources = append(deletedPolicy.Spec.ContextAwareResources, ContextAwareResource{APIVersion: "v1", Kind: "Pod"})
+	deletedPolicy.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	require.False(t, strictServedResourcesOnUpdate(true, oldPolicy, deletedPolicy))
+
+	changedPolicy := oldPolicy.DeepCopy()
+	changedPolicy.Spec.ContextAwareResources = append(changedPolicy.Spec.ContextAwareResources, ContextAwareResource{APIVersion: "v1", Kind: "Pod"})
+	require.True(t, strictServedResourcesOnUpdate(true, oldPolicy, changedPolicy))
+	require.False(t, strictServedResourcesOnUpdate(false, oldPolicy, changedPolicy))
+
+	changedRulesPolicy := oldPolicy.DeepCopy()
+	changedRulesPolicy.Spec.Rules = servedRules
+	require.True(t, strictServedResourcesOnUpdate(true, oldPolicy, changedRulesPolicy))
+}
+
+func TestClusterAdmissionPolicyValidateUpdateWithRemovedResourcesInStrictMode(t *testing.T) {
+	validator := clusterAdmissionPolicyValidator{
+		restMapper:            newServedResourcesRESTMapper(),
+		strictServedResources: true,
+		logger:                logr.Discard(),
+	}
+	oldPolicy := NewClusterAdmissionPolicyFactory().
+		WithRules(servedRules).
+		WithContextAwareResources([]ContextAwareResource{{APIVersion: "example.com/v1", Kind: "Widget"}}).
+		Build()
+	newPolicy := oldPolicy.DeepCopy()
+	newPolicy.Finalizers = nil
+
+	warnings, err := validator.ValidateUpdate(context.Background(), oldPolicy, newPolicy)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+
+	newPolicy.Spec.ContextAwareResources = append(newPolicy.Spec.ContextAwareResources, ContextAwareResource{APIVersion: "v1", Kind: "Pod"})
+	_, err = validator.ValidateUpdate(context.Background(), oldPolicy, newPolicy)
+	require.ErrorContains(t, err, "the kind is not served by the cluster")
+}
@@ -21,34 +21,133 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-// +kubebuilder:validation:Enum=protect;monitor
+// +kubebuilder:validation:Enum=protect;warn;monitor
 type PolicyMode string
 
+const (
+	// PolicyModeProtect rejects the requests that are not accepted by the
+	// policy.
+	PolicyModeProtect PolicyMode = "protect"
+	// PolicyModeWarn accepts the requests that are not accepted by the
+	// policy, and returns the rejection messages as admission warnings.
+	PolicyModeWarn PolicyMode = "warn"
+	// PolicyModeMonitor accepts all the requests, the policy evaluations are
+	// only logged and traced.
+	PolicyModeMonitor PolicyMode = "monitor"
+)
+
+// PolicyEnforcement schedules the transitions of a policy from "monitor" to
+// "protect" mode, with an optional "warn" period in between. The time at
+// which the policy switches to "protect" mode is either given by protectAt, or
+// it comes after the monitor and warn periods, counted from the creation of
+// the policy.
+type PolicyEnforcement struct {
+	// MonitorPeriod is how long the policy runs in "monitor" mode after its
+	// creation. Exactly one of monitorPeriod and protectAt must be set.
+	// +optional
+	MonitorPeriod *metav1.Duration `json:"monitorPeriod,omitempty"`
+	// WarnPeriod is how long the policy runs in "warn" mode before being
+	// switched to "protect" mode. When empty, the policy switches from
+	// "monitor" to "protect" mode directly.
+	// +optional
+	WarnPeriod *metav1.Duration `json:"warnPeriod,omitempty"`
+	// ProtectAt is the time at which the policy switches to "protect" mode.
+	// Exactly one of monitorPeriod and protectAt must be set.
+	// +optional
+	ProtectAt *metav1.Time `json:"protectAt,omitempty"`
+}
+
+// PolicyExpirationAction is the action taken on a policy once it expires.
+// +kubebuilder:validation:Enum=suspend;delete
+type PolicyExpirationAction string
+
+const (
+	// PolicyExpirationActionSuspend suspends the expired policy.
+	PolicyExpirationActionSuspend PolicyExpirationAction = "suspend"
+	// PolicyExpirationActionDelete deletes the expired policy.
+	PolicyExpirationActionDelete PolicyExpirationAction = "delete"
+)
+
+// PolicyExpiration makes a policy temporary. The time at which the policy
+// expires is either given by expiresAt, or it comes after the ttl, counted
+// from the creation of the policy.
+type PolicyExpiration struct {
+	// ExpiresAt is the time at which the policy expires.
+	// Exactly one of expiresAt and ttl must be set.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// TTL is how long the policy lives after its creation.
+	// Exactly one of expiresAt and ttl must be set.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Action is the action taken once the policy expires: "suspend" or
+	// "delete". The default is "suspend".
+	// +kubebuilder:default:=suspend
+	// +optional
+	Action PolicyExpirationAction `json:"action,omitempty"`
+}
+
 type PolicySpec struct {
 	// PolicyServer identifies an existing PolicyServer resource.
-	// +kubebuilder:default:=default
+	// When empty, it is defaulted to the PolicyServer named by the
+	// kubewarden.io/default-policy-server annotation of the policy namespace
+	// (namespaced policies only), then to the default PolicyServer configured
+	// on the controller, and finally to "default".
 	// +optional
 	PolicyServer string `json:"policyServer"`
 
 	// Mode defines the execution mode of this policy. Can be set to
-	// either "protect" or "monitor". If it's empty, it is defaulted to
+	// "protect", "warn" or "monitor". If it's empty, it is defaulted to
 	// "protect".
-	// Transitioning this setting from "monitor" to "protect" is
-	// allowed, but is disallowed to transition from "protect" to
-	// "monitor". To perform this transition, the policy should be
-	// recreated in "monitor" mode instead.
+	// In "warn" mode the requests rejected by the policy are accepted,
+	// and the rejection messages are returned as admission warnings.
+	// Transitioning this setting from "monitor" to "warn", and from
+	// "monitor" or "warn" to "protect" is allowed, but it is disallowed
+	// to transition to a less strict mode. To perform such a
+	// transition, the policy should be recreated instead, or downgraded
+	// temporarily by a member of the break-glass group, through the
+	// policies.kubewarden.io/break-glass-reason and
+	// policies.kubewarden.io/break-glass-until annotations.
 	// +kubebuilder:default:=protect
 	// +optional
 	Mode PolicyMode `json:"mode,omitempty"`
 
+	// Suspended switches off the policy while keeping it: its webhook is
+	// removed, hence the policy is not evaluated anymore. Unsuspending the
+	// policy registers its webhook again.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Enforcement schedules the progressive enforcement of the policy: it
+	// runs in "monitor" mode, optionally in "warn" mode, and then it is
+	// switched to "protect" mode. When set, the mode of a new policy is
+	// the one scheduled at its creation: it must be omitted, or match the
+	// scheduled one. As the mode is defaulted to "protect", an explicit
+	// "protect" mode cannot be told apart from an omitted one: it is
+	// replaced by the scheduled mode instead of being rejected.
+	// +optional
+	Enforcement *PolicyEnforcement `json:"enforcement,omitempty"`
+
+	// Expiration makes the policy temporary: once it expires, the policy is
+	// suspended or deleted. A suspended expired policy is suspended again
+	// until its expiration is changed or removed.
+	// +optional
+	Expiration *PolicyExpiration `json:"expiration,omitempty"`
+
 	// Module is the location of the WASM module to be loaded. Can be a
 	// local file (file://), a remote file served by an HTTP server
 	// (http://, https://), or an artifact served by an OCI-compatible
 	// registry (registry://).
 	// If prefix is missing, it will default to registry:// and use that
 	// internally.
-	// +kubebuilder:validation:Required
-	Module string `json:"module"`
+	// Exactly one of module and moduleRef must be set.
+	// +optional
+	Module string `json:"module,omitempty"`
+
+	// ModuleRef is the name of the PolicyModule providing the WASM module to
+	// be loaded. Exactly one of module and moduleRef must be set.
+	// +optional
+	ModuleRef string `json:"moduleRef,omitempty"`
 
 	// Settings is a free-form object that contains the policy configuration
 	// values.
@@ -76,6 +175,24 @@ type PolicySpec struct {
 	// incoming requests or not.
 	Mutating bool `json:"mutating"`
 
+	// ReinvocationPolicy indicates whether the mutating policy should be
+	// called again when the object is modified by the webhooks invoked
+	// after it. Allowed values are "Never" and "IfNeeded". Only allowed on
+	// mutating policies.
+	// The default behaviour is "Never".
+	// +optional
+	ReinvocationPolicy *admissionregistrationv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+
+	// Priority orders the invocation of the mutating policies: the policies
+	// with a priority are invoked before the ones without, in increasing
+	// order of priority. The priority is encoded into the name of the
+	// webhook configuration of the policy, which the API server uses to
+	// order the webhooks. Only allowed on mutating policies.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=999
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
 	// BackgroundAudit indicates whether a policy should be used or skipped when
 	// performing audit checks. If false, the policy cannot produce meaningful
 	// evaluation results during audit checks and will be skipped.
@@ -160,11 +277,26 @@ type PolicyGroupMember struct {
 	// registry (registry://).
 	// If prefix is missing, it will default to registry:// and use that
 	// internally.
-	// +kubebuilder:validation:Required
-	Module string `json:"module"`
+	// Exactly one of module, moduleRef and policyRef must be set.
+	// +optional
+	Module string `json:"module,omitempty"`
+
+	// ModuleRef is the name of the PolicyModule providing the WASM module to
+	// be loaded. Exactly one of module, moduleRef and policyRef must be set.
+	// +optional
+	ModuleRef string `json:"moduleRef,omitempty"`
+
+	// PolicyRef is the name of an existing policy providing the module, the
+	// settings and the context aware resources of the member: a
+	// ClusterAdmissionPolicy for the ClusterAdmissionPolicyGroups, or an
+	// AdmissionPolicy of the same namespace for the AdmissionPolicyGroups.
+	// The policy group is not served while the referenced policy is missing.
+	// Exactly one of module, moduleRef and policyRef must be set.
+	// +optional
+	PolicyRef string `json:"policyRef,omitempty"`
 
 	// Settings is a free-form object that contains the policy configuration
-	// values.
+	// values. It must not be set together with policyRef.
 	// +optional
 	// +nullable
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -184,23 +316,65 @@ type PolicyGroupMemberWithContext struct {
 	ContextAwareResources []ContextAwareResource `json:"contextAwareResources,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=v1;v2
+type GroupExpressionVersion string
+
+const (
+	// GroupExpressionVersionV1 is the environment of the expressions
+	// combining the policy results with the logical operators only.
+	GroupExpressionVersionV1 GroupExpressionVersion = "v1"
+	// GroupExpressionVersionV2 is the environment of the expressions using
+	// the CEL standard library, the request, and the policy results.
+	GroupExpressionVersionV2 GroupExpressionVersion = "v2"
+)
+
 type GroupSpec struct {
 	// PolicyServer identifies an existing PolicyServer resource.
-	// +kubebuilder:default:=default
+	// When empty, it is defaulted to the PolicyServer named by the
+	// kubewarden.io/default-policy-server annotation of the policy namespace
+	// (namespaced policies only), then to the default PolicyServer configured
+	// on the controller, and finally to "default".
 	// +optional
 	PolicyServer string `json:"policyServer"`
 
 	// Mode defines the execution mode of this policy. Can be set to
-	// either "protect" or "monitor". If it's empty, it is defaulted to
+	// "protect", "warn" or "monitor". If it's empty, it is defaulted to
 	// "protect".
-	// Transitioning this setting from "monitor" to "protect" is
-	// allowed, but is disallowed to transition from "protect" to
-	// "monitor". To perform this transition, the policy should be
-	// recreated in "monitor" mode instead.
+	// In "warn" mode the requests rejected by the policy are accepted,
+	// and the rejection messages are returned as admission warnings.
+	// Transitioning this setting from "monitor" to "warn", and from
+	// "monitor" or "warn" to "protect" is allowed, but it is disallowed
+	// to transition to a less strict mode. To perform such a
+	// transition, the policy should be recreated instead, or downgraded
+	// temporarily by a member of the break-glass group, through the
+	// policies.kubewarden.io/break-glass-reason and
+	// policies.kubewarden.io/break-glass-until annotations.
 	// +kubebuilder:default:=protect
 	// +optional
 	Mode PolicyMode `json:"mode,omitempty"`
 
+	// Suspended switches off the policy while keeping it: its webhook is
+	// removed, hence the policy is not evaluated anymore. Unsuspending the
+	// policy registers its webhook again.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Enforcement schedules the progressive enforcement of the policy: it
+	// runs in "monitor" mode, optionally in "warn" mode, and then it is
+	// switched to "protect" mode. When set, the mode of a new policy is
+	// the one scheduled at its creation: it must be omitted, or match the
+	// scheduled one. As the mode is defaulted to "protect", an explicit
+	// "protect" mode cannot be told apart from an omitted one: it is
+	// replaced by the scheduled mode instead of being rejected.
+	// +optional
+	Enforcement *PolicyEnforcement `json:"enforcement,omitempty"`
+
+	// Expiration makes the policy temporary: once it expires, the policy is
+	// suspended or deleted. A suspended expired policy is suspended again
+	// until its expiration is changed or removed.
+	// +optional
+	Expiration *PolicyExpiration `json:"expiration,omitempty"`
+
 	// Rules describes what operations on what resources/subresources the webhook cares about.
 	// The webhook cares about an operation if it matches _any_ Rule.
 	Rules []admissionregistrationv1.RuleWithOperations `json:"rules"`
@@ -303,9 +477,28 @@ type GroupSpec struct {
 	// +kubebuilder:validation:Required
 	Expression string `json:"expression"`
 
+	// ExpressionVersion is the version of the environment the expression is
+	// evaluated in. In "v1", the policies are functions returning whether
+	// they accept the request, which can only be combined with the ==, !=,
+	// &&, || and ! operators. "v2" extends "v1" with the CEL standard
+	// library, including the ternary operator and the list macros and size
+	// function, with the request variable holding the operation, namespace
+	// and userInfo of the request, and with a variable for each policy
+	// holding its result as an object with the allowed and message fields.
+	// +kubebuilder:default:=v1
+	// +optional
+	ExpressionVersion GroupExpressionVersion `json:"expressionVersion,omitempty"`
+
 	// Message is  used to specify the message that will be returned when
 	// the policy group is rejected. The specific policy results will be
 	// returned in the warning field of the response.
+	// The message can be a Go template, rendered with the name, namespace
+	// and operation of the request, and the rejections of the policies in
+	// the group, as in:
+	// "{{ .operation }} of {{ .namespace }}/{{ .name }} rejected by
+	// {{ range .rejections }}{{ .policy }}: {{ .message }}. {{ end }}"
+	// Only the fields, the if and range actions, and the len function are
+	// supported.
 	// +kubebuilder:validation:Required
 	Message string `json:"message"`
 }
@@ -316,6 +509,8 @@ type PolicyGroupSpec struct {
 	// Policies is a list of policies that are part of the group that will
 	// be available to be called in the evaluation expression field.
 	// Each policy in the group should be a Kubewarden policy.
+	// The context aware resources of the members are restricted to the
+	// namespace of the group.
 	// +kubebuilder:validation:Required
-	Policies PolicyGroupMembers `json:"policies"`
+	Policies PolicyGroupMembersWithContext `json:"policies"`
 }
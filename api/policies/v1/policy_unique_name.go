@@ -0,0 +1,87 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+const (
+	admissionPolicyUniqueNamePrefix             = "namespaced"
+	admissionPolicyGroupUniqueNamePrefix        = "namespaced-group"
+	clusterAdmissionPolicyUniqueNamePrefix      = "clusterwide"
+	clusterAdmissionPolicyGroupUniqueNamePrefix = "clusterwide-group"
+
+	// hashedUniqueNameMarker is appended to the prefix of the unique names
+	// that had to be shortened, so that they can never match the unique name
+	// of another policy.
+	hashedUniqueNameMarker = "-h"
+	uniqueNameHashLength   = 16
+
+	// MaxUniqueNameLength is the maximum length of a policy unique name. It
+	// keeps the webhook name, which is the unique name followed by
+	// constants.WebhookNameSuffix, within the length of a DNS subdomain.
+	MaxUniqueNameLength = validation.DNS1123SubdomainMaxLength - len(constants.WebhookNameSuffix)
+)
+
+// uniqueName builds the unique name of a policy, which is used to name its
+// webhook configuration and its entry in the Policy Server configuration.
+//
+// This is the second version of the naming scheme: the prefix, the namespace
+// and the name are joined with dots. Namespaces and prefixes cannot contain
+// dots, hence two different policies can never share the same unique name.
+// The names exceeding MaxUniqueNameLength are truncated, and suffixed with a
+// hash of the full name.
+func uniqueName(prefix, namespace, name string) string {
+	identifier := name
+	if namespace != "" {
+		identifier = namespace + "." + name
+	}
+
+	fullName := prefix + "." + identifier
+	if len(fullName) <= MaxUniqueNameLength {
+		return fullName
+	}
+
+	sum := sha256.Sum256([]byte(fullName))
+	hash := hex.EncodeToString(sum[:])[:uniqueNameHashLength]
+	hashedPrefix := prefix + hashedUniqueNameMarker + "."
+
+	truncated := strings.TrimRight(identifier[:MaxUniqueNameLength-len(hashedPrefix)-len(hash)-1], ".-")
+	if truncated == "" {
+		return hashedPrefix + hash
+	}
+
+	return hashedPrefix + truncated + "." + hash
+}
+
+// legacyUniqueName builds the unique name of a policy with the first version
+// of the naming scheme, which is kept to migrate the existing webhook
+// configurations.
+func legacyUniqueName(prefix, namespace, name string) string {
+	if namespace != "" {
+		return prefix + "-" + namespace + "-" + name
+	}
+
+	return prefix + "-" + name
+}
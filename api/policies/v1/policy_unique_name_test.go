@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+func TestGetUniqueName(t *testing.T) {
+	tests := []struct {
+		name               string
+		policy             Policy
+		expectedUniqueName string
+		expectedLegacyName string
+	}{
+		{
+			"AdmissionPolicy",
+			&AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "team-a"}},
+			"namespaced.team-a.policy",
+			"namespaced-team-a-policy",
+		},
+		{
+			"AdmissionPolicyGroup",
+			&AdmissionPolicyGroup{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "team-a"}},
+			"namespaced-group.team-a.policy",
+			"namespaced-group-team-a-policy",
+		},
+		{
+			"ClusterAdmissionPolicy",
+			&ClusterAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+			"clusterwide.policy",
+			"clusterwide-policy",
+		},
+		{
+			"ClusterAdmissionPolicyGroup",
+			&ClusterAdmissionPolicyGroup{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+			"clusterwide-group.policy",
+			"clusterwide-group-policy",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedUniqueName, test.policy.GetUniqueName())
+			assert.Equal(t, test.expectedLegacyName, test.policy.GetLegacyUniqueName())
+		})
+	}
+}
+
+func TestGetUniqueNameIsCollisionFree(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		other  Policy
+	}{
+		{
+			"AdmissionPolicies with the namespace and name split differently",
+			&AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "x", Namespace: "team-a"}},
+			&AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "a-x", Namespace: "team"}},
+		},
+		{
+			"AdmissionPolicy and AdmissionPolicyGroup",
+			&AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "x", Namespace: "default"}},
+			&AdmissionPolicyGroup{ObjectMeta: metav1.ObjectMeta{Name: "default-x", Namespace: "group"}},
+		},
+		{
+			"ClusterAdmissionPolicy and ClusterAdmissionPolicyGroup",
+			&ClusterAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "group-x"}},
+			&ClusterAdmissionPolicyGroup{ObjectMeta: metav1.ObjectMeta{Name: "x"}},
+		},
+		{
+			"long AdmissionPolicies sharing the same prefix",
+			&AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 253), Namespace: "default"}},
+			&AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 252) + "b", Namespace: "default"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.NotEqual(t, test.policy.GetUniqueName(), test.other.GetUniqueName())
+		})
+	}
+}
+
+func TestGetUniqueNameIsLengthSafe(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+	}{
+		{
+			"AdmissionPolicy with a long namespace and name",
+			&AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 253), Namespace: strings.Repeat("n", 63)}},
+		},
+		{
+			"AdmissionPolicyGroup with a long name containing dots",
+			&AdmissionPolicyGroup{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a.", 126) + "a", Namespace: "default"}},
+		},
+		{
+			"ClusterAdmissionPolicy with a long name",
+			&ClusterAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 253)}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uniqueName := test.policy.GetUniqueName()
+
+			require.LessOrEqual(t, len(uniqueName), MaxUniqueNameLength)
+			require.Empty(t, validation.IsDNS1123Subdomain(uniqueName))
+			require.Empty(t, validation.IsDNS1123Subdomain(uniqueName+constants.WebhookNameSuffix))
+			assert.Equal(t, uniqueName, test.policy.GetUniqueName(), "the unique name must be stable")
+		})
+	}
+}
@@ -19,9 +19,12 @@ package v1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -29,6 +32,7 @@ import (
 	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
 	"k8s.io/apiserver/pkg/cel"
 	"k8s.io/apiserver/pkg/cel/environment"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // nonStrictStatelessCELCompiler is a cel Compiler that does not enforce strict cost enforcement.
@@ -40,66 +44,59 @@ var (
 
 const maxMatchConditionsCount = 64
 
-type sensitiveResource struct {
-	APIGroup string
-	Resource string
-}
-
-func (sr sensitiveResource) String() string {
-	return fmt.Sprintf("APIGroup: %s, Resource: %s", sr.APIGroup, sr.Resource)
-}
-
-func (sr sensitiveResource) MatchesRules(apiGroups []string, resource []string) bool {
-	apiGroupMatches := false
-	for _, apiGroup := range apiGroups {
-		if apiGroup == sr.APIGroup || apiGroup == "*" {
-			apiGroupMatches = true
-			break
-		}
-	}
-
-	resourceMatches := false
-	for _, res := range resource {
-		if res == sr.Resource || res == "*" || res == "*/*" || strings.HasPrefix(res, sr.Resource+"/") {
-			resourceMatches = true
-			break
-		}
-	}
-
-	return apiGroupMatches && resourceMatches
-}
-
-func defaultSensitiveResources() []sensitiveResource {
-	return []sensitiveResource{
-		{APIGroup: "wgpolicyk8s.io", Resource: "policyreports"},
-	}
-}
-
-func validatePolicyCreate(policy Policy) field.ErrorList {
+func validatePolicyCreate(policy Policy, sensitiveResources SensitiveResources, restMapper meta.RESTMapper) field.ErrorList {
 	var allErrors field.ErrorList
 
-	allErrors = append(allErrors, validateRulesField(policy)...)
+	allErrors = append(allErrors, validateRulesField(policy, sensitiveResources)...)
+	allErrors = append(allErrors, validateModuleFields(policy)...)
+	allErrors = append(allErrors, validateContextAwareResourcesField(policy, restMapper)...)
 	allErrors = append(allErrors, validateMatchConditions(policy.GetMatchConditions(), field.NewPath("spec").Child("matchConditions"))...)
+	allErrors = append(allErrors, validateEnforcementField(policy)...)
+	allErrors = append(allErrors, validateExpirationField(policy)...)
+	allErrors = append(allErrors, validateMutatingOrderingFields(policy)...)
+	if err := validateExpiresAtInTheFuture(policy, time.Now()); err != nil {
+		allErrors = append(allErrors, err)
+	}
+	if err := validateEnforcementModeAtCreation(policy, time.Now()); err != nil {
+		allErrors = append(allErrors, err)
+	}
 	return allErrors
 }
 
-func validatePolicyUpdate(oldPolicy, newPolicy Policy) field.ErrorList {
+func validatePolicyUpdate(oldPolicy, newPolicy Policy, sensitiveResources SensitiveResources, restMapper meta.RESTMapper, authorization breakGlassAuthorization) field.ErrorList {
 	var allErrors field.ErrorList
 
-	allErrors = append(allErrors, validateRulesField(newPolicy)...)
+	allErrors = append(allErrors, validateRulesField(newPolicy, sensitiveResourcesOnUpdate(oldPolicy, newPolicy, sensitiveResources))...)
+	allErrors = append(allErrors, validateModuleFields(newPolicy)...)
+	allErrors = append(allErrors, validateContextAwareResourcesField(newPolicy, restMapper)...)
 	allErrors = append(allErrors, validateMatchConditions(newPolicy.GetMatchConditions(), field.NewPath("spec").Child("matchConditions"))...)
+	allErrors = append(allErrors, validateEnforcementField(newPolicy)...)
+	allErrors = append(allErrors, validateExpirationField(newPolicy)...)
+	allErrors = append(allErrors, validateMutatingOrderingFields(newPolicy)...)
 	if err := validatePolicyServerField(oldPolicy, newPolicy); err != nil {
 		allErrors = append(allErrors, err)
 	}
-	if err := validatePolicyModeField(oldPolicy, newPolicy); err != nil {
-		allErrors = append(allErrors, err)
-	}
+	allErrors = append(allErrors, validatePolicyModeUpdate(oldPolicy, newPolicy, authorization)...)
 
 	return allErrors
 }
 
-// Validates the spec.Rules field for non-empty, webhook-valid rules.
-func validateRulesField(policy Policy) field.ErrorList {
+// sensitiveResourcesOnUpdate returns the sensitive resources enforced on the
+// update of the policy. They are enforced only when the update changes the
+// rules: the policies created before the sensitive resources were widened
+// must remain updatable, in particular to remove their finalizers on
+// deletion.
+func sensitiveResourcesOnUpdate(oldPolicy, newPolicy Policy, sensitiveResources SensitiveResources) SensitiveResources {
+	if newPolicy.GetDeletionTimestamp() != nil || equality.Semantic.DeepEqual(oldPolicy.GetRules(), newPolicy.GetRules()) {
+		return SensitiveResources{}
+	}
+
+	return sensitiveResources
+}
+
+// Validates the spec.Rules field for non-empty, webhook-valid rules, which do
+// not target any of the sensitive resources.
+func validateRulesField(policy Policy, sensitiveResources SensitiveResources) field.ErrorList {
 	var allErrors field.ErrorList
 	rulesField := field.NewPath("spec", "rules")
 
@@ -125,8 +122,14 @@ func validateRulesField(policy Policy) field.ErrorList {
 			allErrors = append(allErrors, checkRulesArrayForEmptyString(rule.Rule.Resources, rulesField.Child("rule.resources"))...)
 
 			if isAdmissionPolicy || isAdmissionPolicyGroup {
-				allErrors = append(allErrors, checkRulesArrayForWildcardUsage(rule.Rule.APIVersions, rule.Rule.Resources, rulesField)...)
-				allErrors = append(allErrors, checkRulesArrayForSensitiveResourcesBeingTargeted(rule.Rule.APIVersions, rule.Rule.Resources, rulesField)...)
+				allErrors = append(allErrors, checkRulesArrayForWildcardUsage(rule.Rule.APIGroups, rule.Rule.Resources, rulesField)...)
+				allErrors = append(allErrors, checkRulesArrayForSensitiveResourcesBeingTargeted(
+					policy, sensitiveResources.Namespaced, rule.Rule.APIGroups, rule.Rule.Resources, rulesField,
+					"AdmissionPolicy or AdmissionPolicyGroup")...)
+			} else {
+				allErrors = append(allErrors, checkRulesArrayForSensitiveResourcesBeingTargeted(
+					policy, sensitiveResources.Cluster, rule.Rule.APIGroups, rule.Rule.Resources, rulesField,
+					"ClusterAdmissionPolicy or ClusterAdmissionPolicyGroup")...)
 			}
 		}
 	}
@@ -199,14 +202,19 @@ func checkRulesArrayForWildcardUsage(rulesAPIGroups []string, rulesResources []s
 
 // checkRulesArrayForSensitiveResourcesBeingTargeted checks if any of the sensitive resources are being targeted by the
 // rule.
-func checkRulesArrayForSensitiveResourcesBeingTargeted(rulesAPIGroups []string, rulesResources []string, rulesField *field.Path) field.ErrorList {
+func checkRulesArrayForSensitiveResourcesBeingTargeted(
+	policy Policy,
+	sensitiveResources []SensitiveResource,
+	rulesAPIGroups []string,
+	rulesResources []string,
+	rulesField *field.Path,
+	policyKinds string,
+) field.ErrorList {
 	var allErrors field.ErrorList
 
-	sensitiveResources := defaultSensitiveResources()
-
 	for _, sensitiveResource := range sensitiveResources {
-		if sensitiveResource.MatchesRules(rulesAPIGroups, rulesResources) {
-			allErrors = append(allErrors, field.Forbidden(rulesField, fmt.Sprintf("{%s} resources cannot be targeted by AdmissionPolicy or AdmissionPolicyGroup", sensitiveResource)))
+		if sensitiveResource.MatchesRules(rulesAPIGroups, rulesResources) && sensitiveResource.MatchesNamespace(policy) {
+			allErrors = append(allErrors, field.Forbidden(rulesField, fmt.Sprintf("{%s} resources cannot be targeted by %s", sensitiveResource, policyKinds)))
 		}
 	}
 
@@ -222,13 +230,150 @@ func validatePolicyServerField(oldPolicy, newPolicy Policy) *field.Error {
 }
 
 func validatePolicyModeField(oldPolicy, newPolicy Policy) *field.Error {
-	if oldPolicy.GetPolicyMode() == "protect" && newPolicy.GetPolicyMode() == "monitor" {
-		return field.Forbidden(field.NewPath("spec").Child("mode"), "field cannot transition from protect to monitor. Recreate instead.")
+	oldMode, newMode := oldPolicy.GetPolicyMode(), newPolicy.GetPolicyMode()
+	if oldMode.IsStricterThan(newMode) {
+		return field.Forbidden(field.NewPath("spec").Child("mode"), fmt.Sprintf("field cannot transition from %s to %s. Recreate instead.", oldMode, newMode))
+	}
+
+	return nil
+}
+
+// validateEnforcementField validates the schedule of the policy enforcement:
+// the time to switch to protect mode is given either by a monitor period or
+// by a date, and the periods must be positive.
+func validateEnforcementField(policy Policy) field.ErrorList {
+	var allErrors field.ErrorList
+
+	enforcement := policy.GetEnforcement()
+	if enforcement == nil {
+		return allErrors
+	}
+
+	enforcementPath := field.NewPath("spec").Child("enforcement")
+	switch {
+	case enforcement.MonitorPeriod == nil && enforcement.ProtectAt == nil:
+		allErrors = append(allErrors, field.Required(enforcementPath, "one of monitorPeriod or protectAt must be set"))
+	case enforcement.MonitorPeriod != nil && enforcement.ProtectAt != nil:
+		allErrors = append(allErrors, field.Forbidden(enforcementPath.Child("protectAt"), "monitorPeriod and protectAt are mutually exclusive"))
+	}
+
+	if enforcement.MonitorPeriod != nil && enforcement.MonitorPeriod.Duration <= 0 {
+		allErrors = append(allErrors, field.Invalid(enforcementPath.Child("monitorPeriod"), enforcement.MonitorPeriod.Duration.String(), "must be positive"))
+	}
+	if enforcement.WarnPeriod != nil && enforcement.WarnPeriod.Duration <= 0 {
+		allErrors = append(allErrors, field.Invalid(enforcementPath.Child("warnPeriod"), enforcement.WarnPeriod.Duration.String(), "must be positive"))
+	}
+
+	return allErrors
+}
+
+// validateExpirationField validates the expiration of the policy: it is
+// given either by a date or by a ttl, which must be positive.
+func validateExpirationField(policy Policy) field.ErrorList {
+	var allErrors field.ErrorList
+
+	expiration := policy.GetExpiration()
+	if expiration == nil {
+		return allErrors
+	}
+
+	expirationPath := field.NewPath("spec").Child("expiration")
+	switch {
+	case expiration.ExpiresAt == nil && expiration.TTL == nil:
+		allErrors = append(allErrors, field.Required(expirationPath, "one of expiresAt or ttl must be set"))
+	case expiration.ExpiresAt != nil && expiration.TTL != nil:
+		allErrors = append(allErrors, field.Forbidden(expirationPath.Child("ttl"), "expiresAt and ttl are mutually exclusive"))
+	}
+
+	if expiration.TTL != nil && expiration.TTL.Duration <= 0 {
+		allErrors = append(allErrors, field.Invalid(expirationPath.Child("ttl"), expiration.TTL.Duration.String(), "must be positive"))
+	}
+
+	return allErrors
+}
+
+// validateExpiresAtInTheFuture rejects the creation of a policy already
+// expired.
+func validateExpiresAtInTheFuture(policy Policy, now time.Time) *field.Error {
+	expiration := policy.GetExpiration()
+	if expiration == nil || expiration.ExpiresAt == nil || expiration.ExpiresAt.After(now) {
+		return nil
+	}
+
+	return field.Invalid(field.NewPath("spec").Child("expiration").Child("expiresAt"), expiration.ExpiresAt.String(), "must be in the future")
+}
+
+// validateEnforcementModeAtCreation rejects the creation of a policy whose
+// mode conflicts with the mode scheduled by its enforcement at creation. The
+// mode is set by the defaulting when it is omitted or "protect", so only the
+// "monitor" and "warn" modes can conflict here.
+func validateEnforcementModeAtCreation(policy Policy, now time.Time) *field.Error {
+	enforcement := policy.GetEnforcement()
+	if enforcement == nil {
+		return nil
+	}
+
+	if scheduledMode := enforcement.ScheduledMode(now, now); policy.GetPolicyMode() != scheduledMode {
+		return field.Invalid(field.NewPath("spec").Child("mode"), policy.GetPolicyMode(),
+			fmt.Sprintf("conflicts with the mode %s scheduled by the enforcement at creation, omit it instead", scheduledMode))
 	}
 
 	return nil
 }
 
+// validateModuleFields checks that exactly one of the module and moduleRef
+// fields is set. The members of the policy groups are validated on their own.
+func validateModuleFields(policy Policy) field.ErrorList {
+	if _, ok := policy.(PolicyGroup); ok {
+		return nil
+	}
+
+	return validateModuleReference(policy.GetModule(), policy.GetModuleRef(), field.NewPath("spec"))
+}
+
+func validateModuleReference(module, moduleRef string, fldPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	switch {
+	case module == "" && moduleRef == "":
+		allErrors = append(allErrors, field.Required(fldPath.Child("module"), "one of module and moduleRef must be set"))
+	case module != "" && moduleRef != "":
+		allErrors = append(allErrors, field.Forbidden(fldPath.Child("moduleRef"), "module and moduleRef are mutually exclusive"))
+	}
+
+	return allErrors
+}
+
+// validateMutatingOrderingFields checks that the reinvocationPolicy and
+// priority fields are only set on mutating policies.
+func validateMutatingOrderingFields(policy Policy) field.ErrorList {
+	var allErrors field.ErrorList
+
+	if policy.IsMutating() {
+		return allErrors
+	}
+	if policy.GetReinvocationPolicy() != nil {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("reinvocationPolicy"), "only allowed on mutating policies"))
+	}
+	if policy.GetPriority() != nil {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("priority"), "only allowed on mutating policies"))
+	}
+
+	return allErrors
+}
+
+// validateBackendField checks that the ValidatingAdmissionPolicy backend is
+// only requested by validating policies.
+func validateBackendField(policy *ClusterAdmissionPolicy) field.ErrorList {
+	var allErrors field.ErrorList
+
+	if policy.GetBackend() == PolicyBackendValidatingAdmissionPolicy && policy.IsMutating() {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("backend"), "mutating policies can only use the webhook backend"))
+	}
+
+	return allErrors
+}
+
 // prepareInvalidAPIError is a shorthand for generating an invalid apierrors.StatusError with data from a policy.
 func prepareInvalidAPIError(policy Policy, errorList field.ErrorList) *apierrors.StatusError {
 	return apierrors.NewInvalid(
@@ -305,3 +450,96 @@ func validateMatchConditionsExpression(expressionStr string, fldPath *field.Path
 	}
 	return allErrors
 }
+
+// policyTimeoutSecondsWarningThreshold is the timeout above which a warning is
+// emitted: the API server does not wait for the webhooks more than 30 seconds.
+const policyTimeoutSecondsWarningThreshold = 25
+
+// PolicyWarningsSettings holds the settings of the controller the warnings
+// about the policy configuration depend on.
+// +kubebuilder:object:generate:=false
+type PolicyWarningsSettings struct {
+	// DeploymentsNamespace is the namespace of the Kubewarden deployments.
+	DeploymentsNamespace string
+	// FeatureGateAdmissionWebhookMatchConditions tells whether the
+	// matchConditions of the webhooks are supported by the cluster.
+	FeatureGateAdmissionWebhookMatchConditions bool
+}
+
+// policyWarningCheck returns a warning about a risky, but legal,
+// configuration of the policy, or an empty string.
+type policyWarningCheck func(policy Policy, settings *PolicyWarningsSettings) string
+
+//nolint:gochecknoglobals // the list of checks is not meant to be changed
+var policyWarningChecks = []policyWarningCheck{
+	warnIgnoreFailurePolicyInProtectMode,
+	warnTimeoutSecondsNearLimit,
+	warnMutatingSideEffects,
+	warnRulesMatchingDeploymentsNamespace,
+	warnDroppedMatchConditions,
+}
+
+// policyWarnings returns the warnings about the risky, but legal,
+// configuration of the policy. When the settings are nil, the checks
+// depending on them are skipped.
+func policyWarnings(policy Policy, settings *PolicyWarningsSettings) admission.Warnings {
+	var warnings admission.Warnings
+	for _, check := range policyWarningChecks {
+		if warning := check(policy, settings); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings
+}
+
+func warnIgnoreFailurePolicyInProtectMode(policy Policy, _ *PolicyWarningsSettings) string {
+	failurePolicy := policy.GetFailurePolicy()
+	if failurePolicy == nil || *failurePolicy != admissionregistrationv1.Ignore || policy.GetPolicyMode() != PolicyModeProtect {
+		return ""
+	}
+
+	return "spec.failurePolicy: the policy is in protect mode, but the requests are accepted when its Policy Server cannot be reached"
+}
+
+func warnTimeoutSecondsNearLimit(policy Policy, _ *PolicyWarningsSettings) string {
+	timeoutSeconds := policy.GetTimeoutSeconds()
+	if timeoutSeconds == nil || *timeoutSeconds <= policyTimeoutSecondsWarningThreshold {
+		return ""
+	}
+
+	return fmt.Sprintf("spec.timeoutSeconds: the timeout of %d seconds is close to the 30 seconds the API server waits for all the webhooks of a request", *timeoutSeconds)
+}
+
+func warnMutatingSideEffects(policy Policy, _ *PolicyWarningsSettings) string {
+	sideEffects := policy.GetSideEffects()
+	if !policy.IsMutating() || sideEffects == nil || *sideEffects == admissionregistrationv1.SideEffectClassNone {
+		return ""
+	}
+
+	return fmt.Sprintf("spec.sideEffects: the mutating policy declares side effects %q: "+
+		"the mutating webhooks can be reinvoked, and their side effects can happen more than once", *sideEffects)
+}
+
+// warnRulesMatchingDeploymentsNamespace warns about the namespaced policies
+// of the deployments namespace: their rules always target namespaced
+// resources, hence the requests of the Kubewarden deployments are evaluated by
+// the policy. The cluster-wide policies always exclude the deployments
+// namespace.
+func warnRulesMatchingDeploymentsNamespace(policy Policy, settings *PolicyWarningsSettings) string {
+	if settings == nil || settings.DeploymentsNamespace == "" || policy.GetNamespace() != settings.DeploymentsNamespace {
+		return ""
+	}
+
+	return fmt.Sprintf("spec.rules: the rules match the namespaced resources of the %q namespace, "+
+		"where Kubewarden is deployed: a faulty policy can prevent the Policy Servers from being updated", settings.DeploymentsNamespace)
+}
+
+func warnDroppedMatchConditions(policy Policy, settings *PolicyWarningsSettings) string {
+	if settings == nil || settings.FeatureGateAdmissionWebhookMatchConditions || len(policy.GetMatchConditions()) == 0 {
+		return ""
+	}
+
+	return "spec.matchConditions: the AdmissionWebhookMatchConditions feature gate is disabled in the cluster: " +
+		"the match conditions are dropped, and the policy is evaluated on all the requests matching its rules"
+}
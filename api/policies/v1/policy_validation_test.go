@@ -21,10 +21,11 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 )
 
 func TestSensitiveResourceMatchRule(t *testing.T) {
-	sr := sensitiveResource{
+	sr := SensitiveResource{
 		APIGroup: "apps",
 		Resource: "deployments",
 	}
@@ -360,11 +361,94 @@ func TestValidateRulesField(t *testing.T) {
 				"spec.rules: Forbidden: {APIGroup: wgpolicyk8s.io, Resource: policyreports} resources cannot be targeted by AdmissionPolicy or AdmissionPolicyGroup",
 			},
 		},
+		{
+			"targeting a PolicyReport with an explicit APIVersion. But an AdmissionPolicy",
+			NewAdmissionPolicyFactory().
+				WithRules([]admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"wgpolicyk8s.io"},
+							APIVersions: []string{"v1alpha2"},
+							Resources:   []string{"policyreports"},
+						},
+					},
+				}).Build(),
+			[]string{
+				"spec.rules: Forbidden: {APIGroup: wgpolicyk8s.io, Resource: policyreports} resources cannot be targeted by AdmissionPolicy or AdmissionPolicyGroup",
+			},
+		},
+		{
+			"with a wildcard APIVersion and resources, but an explicit APIGroup. And an AdmissionPolicy",
+			NewAdmissionPolicyFactory().
+				WithRules([]admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"apps"},
+							APIVersions: []string{"*"},
+							Resources:   []string{"*"},
+						},
+					},
+				}).Build(),
+			nil,
+		},
+		{
+			"targeting Kubewarden resources. But an AdmissionPolicy",
+			NewAdmissionPolicyFactory().
+				WithRules([]admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"policies.kubewarden.io"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"admissionpolicies"},
+						},
+					},
+				}).Build(),
+			[]string{
+				"spec.rules: Forbidden: {APIGroup: policies.kubewarden.io, Resource: *} resources cannot be targeted by AdmissionPolicy or AdmissionPolicyGroup",
+			},
+		},
+		{
+			"targeting Secrets. But an AdmissionPolicy in the kube-system Namespace",
+			NewAdmissionPolicyFactory().
+				WithNamespace("kube-system").
+				WithRules([]admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"secrets"},
+						},
+					},
+				}).Build(),
+			[]string{
+				"spec.rules: Forbidden: {APIGroup: , Resource: secrets, Namespace: kube-system} resources cannot be targeted by AdmissionPolicy or AdmissionPolicyGroup",
+			},
+		},
+		{
+			"targeting Secrets. But an AdmissionPolicy in another Namespace",
+			NewAdmissionPolicyFactory().
+				WithNamespace("team-a").
+				WithRules([]admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"secrets"},
+						},
+					},
+				}).Build(),
+			nil,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			allErrors := validateRulesField(test.policy)
+			allErrors := validateRulesField(test.policy, DefaultSensitiveResources())
 
 			if len(test.expectedErrorMessages) != 0 {
 				err := prepareInvalidAPIError(test.policy, allErrors)
@@ -582,6 +666,70 @@ func TestValidatePolicyModeField(t *testing.T) {
 				Build(),
 			"spec.mode: Forbidden: field cannot transition from protect to monitor. Recreate instead.",
 		},
+		{
+			"policy mode changed from monitor to warn",
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("monitor").
+				Build(),
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("warn").
+				Build(),
+			"",
+		},
+		{
+			"policy mode changed from warn to protect",
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("warn").
+				Build(),
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("protect").
+				Build(),
+			"",
+		},
+		{
+			"policy mode changed from protect to warn",
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("protect").
+				Build(),
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("warn").
+				Build(),
+			"spec.mode: Forbidden: field cannot transition from protect to warn. Recreate instead.",
+		},
+		{
+			"policy mode changed from warn to monitor",
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("warn").
+				Build(),
+			NewClusterAdmissionPolicyFactory().
+				WithRules(defaultRules).
+				WithMatchConditions(nil).
+				WithPolicyServer("default").
+				WithMode("monitor").
+				Build(),
+			"spec.mode: Forbidden: field cannot transition from warn to monitor. Recreate instead.",
+		},
 	}
 
 	for _, test := range tests {
@@ -596,3 +744,109 @@ func TestValidatePolicyModeField(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyWarnings(t *testing.T) {
+	settings := &PolicyWarningsSettings{
+		DeploymentsNamespace:                       "kubewarden",
+		FeatureGateAdmissionWebhookMatchConditions: true,
+	}
+
+	tests := []struct {
+		name             string
+		policy           func() Policy
+		settings         *PolicyWarningsSettings
+		expectedWarnings []string
+	}{
+		{
+			"no warnings",
+			func() Policy { return NewClusterAdmissionPolicyFactory().Build() },
+			settings,
+			nil,
+		},
+		{
+			"ignore failure policy in protect mode",
+			func() Policy {
+				policy := NewClusterAdmissionPolicyFactory().WithMode(PolicyModeProtect).Build()
+				policy.Spec.FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+				return policy
+			},
+			settings,
+			[]string{"spec.failurePolicy: the policy is in protect mode"},
+		},
+		{
+			"ignore failure policy in monitor mode",
+			func() Policy {
+				policy := NewClusterAdmissionPolicyFactory().WithMode(PolicyModeMonitor).Build()
+				policy.Spec.FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+				return policy
+			},
+			settings,
+			nil,
+		},
+		{
+			"timeout near the limit",
+			func() Policy {
+				policy := NewAdmissionPolicyFactory().Build()
+				policy.Spec.TimeoutSeconds = ptr.To[int32](28)
+				return policy
+			},
+			settings,
+			[]string{"spec.timeoutSeconds: the timeout of 28 seconds"},
+		},
+		{
+			"mutating policy with side effects",
+			func() Policy {
+				policy := NewClusterAdmissionPolicyFactory().WithMutating(true).Build()
+				policy.Spec.SideEffects = ptr.To(admissionregistrationv1.SideEffectClassNoneOnDryRun)
+				return policy
+			},
+			settings,
+			[]string{"spec.sideEffects: the mutating policy declares side effects \"NoneOnDryRun\""},
+		},
+		{
+			"validating policy with side effects",
+			func() Policy {
+				policy := NewClusterAdmissionPolicyFactory().Build()
+				policy.Spec.SideEffects = ptr.To(admissionregistrationv1.SideEffectClassNoneOnDryRun)
+				return policy
+			},
+			settings,
+			nil,
+		},
+		{
+			"namespaced policy of the deployments namespace",
+			func() Policy { return NewAdmissionPolicyGroupFactory().WithNamespace("kubewarden").Build() },
+			settings,
+			[]string{"spec.rules: the rules match the namespaced resources of the \"kubewarden\" namespace"},
+		},
+		{
+			"namespaced policy of another namespace",
+			func() Policy { return NewAdmissionPolicyFactory().WithNamespace("default").Build() },
+			settings,
+			nil,
+		},
+		{
+			"match conditions dropped",
+			func() Policy { return NewClusterAdmissionPolicyFactory().Build() },
+			&PolicyWarningsSettings{DeploymentsNamespace: "kubewarden"},
+			[]string{"spec.matchConditions: the AdmissionWebhookMatchConditions feature gate is disabled"},
+		},
+		{
+			"unknown settings",
+			func() Policy { return NewAdmissionPolicyFactory().WithNamespace("kubewarden").Build() },
+			nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warnings := policyWarnings(test.policy(), test.settings)
+
+			require.Len(t, warnings, len(test.expectedWarnings))
+			for i, expectedWarning := range test.expectedWarnings {
+				require.Contains(t, warnings[i], expectedWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	exceptionMatchConditionNamePrefix = "policies.kubewarden.io/exception-"
+	exceptionMatchConditionHashLength = 16
+
+	// exceptionObjectExpression is the object of the request, which is only
+	// available in oldObject for the DELETE operations.
+	exceptionObjectExpression = "(object != null ? object : oldObject)"
+)
+
+// ExceptionTargets returns true when the exception applies to the given
+// policy.
+func ExceptionTargets(exception Exception, policy Policy) bool {
+	policyKind := PolicyKind(policy)
+
+	for _, target := range exception.GetExceptionSpec().Policies {
+		if target.Kind != policyKind || target.Name != policy.GetName() {
+			continue
+		}
+		if exceptionTargetNamespace(exception, target) == policy.GetNamespace() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExceptionTargetKeys returns the keys of the policies of the given kind
+// targeted by the exception.
+func ExceptionTargetKeys(exception Exception, policyKind string) []client.ObjectKey {
+	keys := []client.ObjectKey{}
+	for _, target := range exception.GetExceptionSpec().Policies {
+		if target.Kind == policyKind {
+			keys = append(keys, client.ObjectKey{Namespace: exceptionTargetNamespace(exception, target), Name: target.Name})
+		}
+	}
+
+	return keys
+}
+
+// exceptionTargetNamespace returns the namespace of the policy targeted by the
+// exception. The namespaced policies targeted by a PolicyException are in the
+// namespace of the exception when not set.
+func exceptionTargetNamespace(exception Exception, target PolicyExceptionTarget) string {
+	if target.Namespace == "" && isNamespacedPolicyKind(target.Kind) {
+		return exception.GetNamespace()
+	}
+
+	return target.Namespace
+}
+
+func isNamespacedPolicyKind(kind string) bool {
+	return kind == AdmissionPolicyKind || kind == AdmissionPolicyGroupKind
+}
+
+// ExceptionMatchCondition compiles the exception into a webhook match
+// condition, which evaluates to false for the exempted requests so that they
+// are not sent to the Policy Server.
+func ExceptionMatchCondition(exception Exception) admissionregistrationv1.MatchCondition {
+	spec := exception.GetExceptionSpec()
+	clauses := []string{}
+
+	if exception.GetNamespace() != "" {
+		clauses = append(clauses, "request.namespace == "+strconv.Quote(exception.GetNamespace()))
+	}
+
+	if len(spec.Namespaces) > 0 || spec.NamespaceSelector != nil {
+		namespaces := slices.Concat(spec.Namespaces, exception.GetExceptionStatus().MatchedNamespaces)
+		slices.Sort(namespaces)
+		clauses = append(clauses, "request.namespace in "+celStringList(slices.Compact(namespaces)))
+	}
+
+	if spec.ObjectSelector != nil {
+		labels := fmt.Sprintf("(has(%[1]s.metadata.labels) ? %[1]s.metadata.labels : {})", exceptionObjectExpression)
+		clauses = append(clauses, labelSelectorExpressions(spec.ObjectSelector, labels)...)
+	}
+
+	if len(spec.Names) > 0 {
+		clauses = append(clauses, "request.name in "+celStringList(spec.Names))
+	}
+
+	// The user expressions are closed on a new line, so that a trailing
+	// comment does not swallow the parentheses.
+	for _, matchCondition := range spec.MatchConditions {
+		clauses = append(clauses, "("+strings.TrimSpace(matchCondition.Expression)+"\n)")
+	}
+
+	expression := "true"
+	if len(clauses) > 0 {
+		expression = strings.Join(clauses, " && ")
+	}
+
+	return admissionregistrationv1.MatchCondition{
+		Name:       exceptionMatchConditionName(exception),
+		Expression: "!(" + expression + ")",
+	}
+}
+
+// exceptionMatchConditionName returns a name that is unique to the exception
+// and valid as a match condition name.
+func exceptionMatchConditionName(exception Exception) string {
+	sum := sha256.Sum256([]byte(exception.GetKind() + "/" + exception.GetNamespace() + "/" + exception.GetName()))
+
+	return exceptionMatchConditionNamePrefix + hex.EncodeToString(sum[:])[:exceptionMatchConditionHashLength]
+}
+
+// labelSelectorExpressions returns the CEL expressions matching the given
+// label selector against the labels map expression.
+func labelSelectorExpressions(selector *metav1.LabelSelector, labels string) []string {
+	expressions := []string{}
+
+	for _, key := range slices.Sorted(maps.Keys(selector.MatchLabels)) {
+		quotedKey := strconv.Quote(key)
+		expressions = append(expressions,
+			fmt.Sprintf("(%[1]s in %[2]s && %[2]s[%[1]s] == %[3]s)", quotedKey, labels, strconv.Quote(selector.MatchLabels[key])))
+	}
+
+	for _, requirement := range selector.MatchExpressions {
+		quotedKey := strconv.Quote(requirement.Key)
+		switch requirement.Operator {
+		case metav1.LabelSelectorOpIn:
+			expressions = append(expressions,
+				fmt.Sprintf("(%[1]s in %[2]s && %[2]s[%[1]s] in %[3]s)", quotedKey, labels, celStringList(requirement.Values)))
+		case metav1.LabelSelectorOpNotIn:
+			expressions = append(expressions,
+				fmt.Sprintf("!(%[1]s in %[2]s && %[2]s[%[1]s] in %[3]s)", quotedKey, labels, celStringList(requirement.Values)))
+		case metav1.LabelSelectorOpExists:
+			expressions = append(expressions, fmt.Sprintf("(%s in %s)", quotedKey, labels))
+		case metav1.LabelSelectorOpDoesNotExist:
+			expressions = append(expressions, fmt.Sprintf("!(%s in %s)", quotedKey, labels))
+		}
+	}
+
+	return expressions
+}
+
+func celStringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
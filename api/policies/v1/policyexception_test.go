@@ -0,0 +1,283 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestExceptionTargets(t *testing.T) {
+	clusterPolicy := &ClusterAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}}
+	namespacedPolicy := &AdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "team-a"}}
+
+	tests := []struct {
+		name      string
+		exception Exception
+		policy    Policy
+		expected  bool
+	}{
+		{
+			"PolicyException targeting a ClusterAdmissionPolicy",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+				Spec:       PolicyExceptionSpec{Policies: []PolicyExceptionTarget{{Kind: ClusterAdmissionPolicyKind, Name: "policy"}}},
+			},
+			clusterPolicy,
+			true,
+		},
+		{
+			"PolicyException targeting an AdmissionPolicy of its namespace",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+				Spec:       PolicyExceptionSpec{Policies: []PolicyExceptionTarget{{Kind: AdmissionPolicyKind, Name: "policy"}}},
+			},
+			namespacedPolicy,
+			true,
+		},
+		{
+			"PolicyException of another namespace",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-b"},
+				Spec:       PolicyExceptionSpec{Policies: []PolicyExceptionTarget{{Kind: AdmissionPolicyKind, Name: "policy"}}},
+			},
+			namespacedPolicy,
+			false,
+		},
+		{
+			"ClusterPolicyException targeting an AdmissionPolicy",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec:       PolicyExceptionSpec{Policies: []PolicyExceptionTarget{{Kind: AdmissionPolicyKind, Name: "policy", Namespace: "team-a"}}},
+			},
+			namespacedPolicy,
+			true,
+		},
+		{
+			"ClusterPolicyException targeting another kind",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec:       PolicyExceptionSpec{Policies: []PolicyExceptionTarget{{Kind: ClusterAdmissionPolicyGroupKind, Name: "policy"}}},
+			},
+			clusterPolicy,
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ExceptionTargets(test.exception, test.policy))
+		})
+	}
+}
+
+func TestExceptionMatchCondition(t *testing.T) {
+	tests := []struct {
+		name               string
+		exception          Exception
+		expectedExpression string
+	}{
+		{
+			"PolicyException exempting its namespace",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+			},
+			`!(request.namespace == "team-a")`,
+		},
+		{
+			"ClusterPolicyException exempting namespaces by name and labels",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec: PolicyExceptionSpec{
+					Namespaces:        []string{"team-b", "team-a"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"exempt": "true"}},
+				},
+				Status: PolicyExceptionStatus{MatchedNamespaces: []string{"team-a", "team-c"}},
+			},
+			`!(request.namespace in ["team-a", "team-b", "team-c"])`,
+		},
+		{
+			"ClusterPolicyException exempting objects",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec: PolicyExceptionSpec{
+					ObjectSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "legacy"},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"frontend"}},
+							{Key: "debug", Operator: metav1.LabelSelectorOpExists},
+						},
+					},
+					Names: []string{"legacy-app"},
+					MatchConditions: []admissionregistrationv1.MatchCondition{
+						{Name: "create", Expression: "request.operation == 'CREATE'"},
+					},
+				},
+			},
+			`!(("app" in (has((object != null ? object : oldObject).metadata.labels) ? (object != null ? object : oldObject).metadata.labels : {}) && ` +
+				`(has((object != null ? object : oldObject).metadata.labels) ? (object != null ? object : oldObject).metadata.labels : {})["app"] == "legacy") && ` +
+				`!("tier" in (has((object != null ? object : oldObject).metadata.labels) ? (object != null ? object : oldObject).metadata.labels : {}) && ` +
+				`(has((object != null ? object : oldObject).metadata.labels) ? (object != null ? object : oldObject).metadata.labels : {})["tier"] in ["frontend"]) && ` +
+				`("debug" in (has((object != null ? object : oldObject).metadata.labels) ? (object != null ? object : oldObject).metadata.labels : {})) && ` +
+				"request.name in [\"legacy-app\"] && (request.operation == 'CREATE'\n))",
+		},
+		{
+			"PolicyException with a match condition ending with a comment",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+				Spec: PolicyExceptionSpec{
+					MatchConditions: []admissionregistrationv1.MatchCondition{
+						{Name: "create", Expression: "request.operation == 'CREATE' // only the creations\n"},
+						{Name: "dry-run", Expression: "request.dryRun // and the dry runs"},
+					},
+				},
+			},
+			"!(request.namespace == \"team-a\" && (request.operation == 'CREATE' // only the creations\n) && (request.dryRun // and the dry runs\n))",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchCondition := ExceptionMatchCondition(test.exception)
+
+			assert.Equal(t, test.expectedExpression, matchCondition.Expression)
+			require.Empty(t, validation.IsQualifiedName(matchCondition.Name))
+			require.Empty(t, validateMatchConditionsExpression(matchCondition.Expression, field.NewPath("expression")))
+		})
+	}
+}
+
+func TestExceptionMatchConditionNameIsUnique(t *testing.T) {
+	policyException := &PolicyException{ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "default"}}
+	clusterPolicyException := &ClusterPolicyException{ObjectMeta: metav1.ObjectMeta{Name: "exception"}}
+
+	assert.NotEqual(t, ExceptionMatchCondition(policyException).Name, ExceptionMatchCondition(clusterPolicyException).Name)
+}
+
+func TestValidateException(t *testing.T) {
+	now := time.Now()
+	clusterPolicyTarget := []PolicyExceptionTarget{{Kind: ClusterAdmissionPolicyKind, Name: "policy"}}
+
+	tests := []struct {
+		name           string
+		exception      Exception
+		expectedErrors int
+	}{
+		{
+			"valid PolicyException",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+				Spec: PolicyExceptionSpec{
+					Policies:  []PolicyExceptionTarget{{Kind: ClusterAdmissionPolicyKind, Name: "policy"}, {Kind: AdmissionPolicyKind, Name: "policy"}},
+					ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)},
+				},
+			},
+			0,
+		},
+		{
+			"PolicyException targeting the policies of another namespace",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+				Spec:       PolicyExceptionSpec{Policies: []PolicyExceptionTarget{{Kind: AdmissionPolicyKind, Name: "policy", Namespace: "team-b"}}},
+			},
+			1,
+		},
+		{
+			"PolicyException exempting other namespaces",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+				Spec: PolicyExceptionSpec{
+					Policies:          clusterPolicyTarget,
+					Namespaces:        []string{"team-b"},
+					NamespaceSelector: &metav1.LabelSelector{},
+				},
+			},
+			2,
+		},
+		{
+			"expired PolicyException",
+			&PolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "team-a"},
+				Spec: PolicyExceptionSpec{
+					Policies:  clusterPolicyTarget,
+					ExpiresAt: &metav1.Time{Time: now.Add(-time.Hour)},
+				},
+			},
+			1,
+		},
+		{
+			"valid ClusterPolicyException",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec: PolicyExceptionSpec{
+					Policies:   []PolicyExceptionTarget{{Kind: AdmissionPolicyGroupKind, Name: "policy", Namespace: "team-a"}},
+					Namespaces: []string{"team-a"},
+				},
+			},
+			0,
+		},
+		{
+			"ClusterPolicyException without selectors",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec:       PolicyExceptionSpec{Policies: clusterPolicyTarget},
+			},
+			1,
+		},
+		{
+			"ClusterPolicyException with invalid targets",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec: PolicyExceptionSpec{
+					Policies: []PolicyExceptionTarget{
+						{Kind: AdmissionPolicyKind, Name: "policy"},
+						{Kind: ClusterAdmissionPolicyKind, Name: "policy", Namespace: "team-a"},
+					},
+					Names: []string{"app"},
+				},
+			},
+			2,
+		},
+		{
+			"ClusterPolicyException with invalid selectors",
+			&ClusterPolicyException{
+				ObjectMeta: metav1.ObjectMeta{Name: "exception"},
+				Spec: PolicyExceptionSpec{
+					Policies:   clusterPolicyTarget,
+					Namespaces: []string{"Invalid_Namespace"},
+					ObjectSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpIn}},
+					},
+					MatchConditions: []admissionregistrationv1.MatchCondition{{Name: "invalid", Expression: "request.foo =="}},
+				},
+			},
+			3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Len(t, validateExceptionCreate(test.exception, now), test.expectedErrors)
+		})
+	}
+}
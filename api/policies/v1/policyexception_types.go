@@ -0,0 +1,206 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	AdmissionPolicyKind             = "AdmissionPolicy"
+	AdmissionPolicyGroupKind        = "AdmissionPolicyGroup"
+	ClusterAdmissionPolicyKind      = "ClusterAdmissionPolicy"
+	ClusterAdmissionPolicyGroupKind = "ClusterAdmissionPolicyGroup"
+	PolicyExceptionKind             = "PolicyException"
+	ClusterPolicyExceptionKind      = "ClusterPolicyException"
+)
+
+// PolicyExceptionTarget identifies a policy the exception applies to.
+type PolicyExceptionTarget struct {
+	// Kind of the policy.
+	// +kubebuilder:validation:Enum=AdmissionPolicy;AdmissionPolicyGroup;ClusterAdmissionPolicy;ClusterAdmissionPolicyGroup
+	Kind string `json:"kind"`
+
+	// Name of the policy.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the AdmissionPolicy or AdmissionPolicyGroup. It must be
+	// empty for the cluster-wide policies. A PolicyException can only target
+	// the namespaced policies of its own namespace, and defaults it.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PolicyExceptionSpec defines the requests exempted from the target policies.
+// A request is exempted when it matches all the selectors that are set. A
+// PolicyException only exempts the requests of its own namespace.
+type PolicyExceptionSpec struct {
+	// Policies the exception applies to.
+	// +kubebuilder:validation:MinItems=1
+	Policies []PolicyExceptionTarget `json:"policies"`
+
+	// Namespaces exempted by name. Only allowed for the
+	// ClusterPolicyExceptions.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector exempts the namespaces matching the selector. A
+	// namespace is exempted when it is listed in namespaces or when it
+	// matches the selector. Only allowed for the ClusterPolicyExceptions.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ObjectSelector exempts the objects having matching labels.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+
+	// Names of the exempted objects.
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// MatchConditions are CEL expressions, with the same variables as the
+	// matchConditions of the policies. A request is exempted only when all
+	// the expressions evaluate to true.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=name
+	MatchConditions []admissionregistrationv1.MatchCondition `json:"matchConditions,omitempty"`
+
+	// ExpiresAt is the time after which the exception no longer applies. The
+	// expired exceptions are deleted.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// PolicyExceptionStatus defines the observed state of PolicyException and
+// ClusterPolicyException.
+type PolicyExceptionStatus struct {
+	// MatchedNamespaces are the namespaces matching the namespaceSelector.
+	// +optional
+	MatchedNamespaces []string `json:"matchedNamespaces,omitempty"`
+}
+
+// PolicyException exempts the requests of its namespace from some policies
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=pex
+// +kubebuilder:printcolumn:name="Expires At",type=string,JSONPath=`.spec.expiresAt`,description="Time after which the exception no longer applies"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PolicyException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicyExceptionSpec   `json:"spec,omitempty"`
+	Status PolicyExceptionStatus `json:"status,omitempty"`
+}
+
+// PolicyExceptionList contains a list of PolicyException
+// +kubebuilder:object:root=true
+type PolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyException `json:"items"`
+}
+
+// ClusterPolicyException exempts the requests of the whole cluster from some
+// policies
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cpex
+// +kubebuilder:printcolumn:name="Expires At",type=string,JSONPath=`.spec.expiresAt`,description="Time after which the exception no longer applies"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterPolicyException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicyExceptionSpec   `json:"spec,omitempty"`
+	Status PolicyExceptionStatus `json:"status,omitempty"`
+}
+
+// ClusterPolicyExceptionList contains a list of ClusterPolicyException
+// +kubebuilder:object:root=true
+type ClusterPolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPolicyException `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicyException{}, &PolicyExceptionList{})
+	SchemeBuilder.Register(&ClusterPolicyException{}, &ClusterPolicyExceptionList{})
+}
+
+// +kubebuilder:object:generate:=false
+type Exception interface {
+	client.Object
+	GetKind() string
+	GetExceptionSpec() *PolicyExceptionSpec
+	GetExceptionStatus() *PolicyExceptionStatus
+}
+
+func (r *PolicyException) GetKind() string {
+	return PolicyExceptionKind
+}
+
+func (r *PolicyException) GetExceptionSpec() *PolicyExceptionSpec {
+	return &r.Spec
+}
+
+func (r *PolicyException) GetExceptionStatus() *PolicyExceptionStatus {
+	return &r.Status
+}
+
+func (r *ClusterPolicyException) GetKind() string {
+	return ClusterPolicyExceptionKind
+}
+
+func (r *ClusterPolicyException) GetExceptionSpec() *PolicyExceptionSpec {
+	return &r.Spec
+}
+
+func (r *ClusterPolicyException) GetExceptionStatus() *PolicyExceptionStatus {
+	return &r.Status
+}
+
+// IsExpired returns true when the exception no longer applies at the given
+// time.
+func (s *PolicyExceptionSpec) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(s.ExpiresAt.Time)
+}
+
+// PolicyKind returns the kind of the given policy.
+func PolicyKind(policy Policy) string {
+	switch policy.(type) {
+	case *AdmissionPolicy:
+		return AdmissionPolicyKind
+	case *AdmissionPolicyGroup:
+		return AdmissionPolicyGroupKind
+	case *ClusterAdmissionPolicy:
+		return ClusterAdmissionPolicyKind
+	case *ClusterAdmissionPolicyGroup:
+		return ClusterAdmissionPolicyGroupKind
+	default:
+		return ""
+	}
+}
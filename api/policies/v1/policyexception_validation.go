@@ -0,0 +1,129 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validateExceptionCreate(exception Exception, now time.Time) field.ErrorList {
+	var allErrors field.ErrorList
+
+	allErrors = append(allErrors, validateExceptionSpec(exception)...)
+	if exception.GetExceptionSpec().IsExpired(now) {
+		allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("expiresAt"), exception.GetExceptionSpec().ExpiresAt, "must be in the future"))
+	}
+
+	return allErrors
+}
+
+func validateExceptionUpdate(exception Exception) field.ErrorList {
+	return validateExceptionSpec(exception)
+}
+
+func validateExceptionSpec(exception Exception) field.ErrorList {
+	var allErrors field.ErrorList
+	spec := exception.GetExceptionSpec()
+	specPath := field.NewPath("spec")
+	isClusterException := exception.GetNamespace() == ""
+
+	allErrors = append(allErrors, validateExceptionTargets(exception, specPath.Child("policies"))...)
+
+	if !isClusterException {
+		if len(spec.Namespaces) > 0 {
+			allErrors = append(allErrors, field.Forbidden(specPath.Child("namespaces"), "a PolicyException only applies to its own namespace"))
+		}
+		if spec.NamespaceSelector != nil {
+			allErrors = append(allErrors, field.Forbidden(specPath.Child("namespaceSelector"), "a PolicyException only applies to its own namespace"))
+		}
+	} else if len(spec.Namespaces) == 0 && spec.NamespaceSelector == nil && spec.ObjectSelector == nil &&
+		len(spec.Names) == 0 && len(spec.MatchConditions) == 0 {
+		allErrors = append(allErrors, field.Required(specPath,
+			"a ClusterPolicyException must set at least one of namespaces, namespaceSelector, objectSelector, names or matchConditions"))
+	}
+
+	for i, namespace := range spec.Namespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			allErrors = append(allErrors, field.Invalid(specPath.Child("namespaces").Index(i), namespace, msg))
+		}
+	}
+
+	labelSelectorValidationOptions := metav1validation.LabelSelectorValidationOptions{}
+	if spec.NamespaceSelector != nil {
+		allErrors = append(allErrors, metav1validation.ValidateLabelSelector(spec.NamespaceSelector, labelSelectorValidationOptions, specPath.Child("namespaceSelector"))...)
+	}
+	if spec.ObjectSelector != nil {
+		allErrors = append(allErrors, metav1validation.ValidateLabelSelector(spec.ObjectSelector, labelSelectorValidationOptions, specPath.Child("objectSelector"))...)
+	}
+
+	for i, name := range spec.Names {
+		if name == "" {
+			allErrors = append(allErrors, field.Required(specPath.Child("names").Index(i), "the name cannot be empty"))
+		}
+	}
+
+	matchConditionsErrors := validateMatchConditions(spec.MatchConditions, specPath.Child("matchConditions"))
+	allErrors = append(allErrors, matchConditionsErrors...)
+	// The match conditions are combined into the match condition of the
+	// webhooks of the exempted policies, which must compile as well.
+	if len(matchConditionsErrors) == 0 && len(spec.MatchConditions) != 0 {
+		allErrors = append(allErrors, validateMatchConditionsExpression(ExceptionMatchCondition(exception).Expression, specPath.Child("matchConditions"))...)
+	}
+
+	return allErrors
+}
+
+func validateExceptionTargets(exception Exception, policiesPath *field.Path) field.ErrorList {
+	var allErrors field.ErrorList
+
+	if len(exception.GetExceptionSpec().Policies) == 0 {
+		allErrors = append(allErrors, field.Required(policiesPath, "at least one policy must be targeted"))
+	}
+
+	for i, target := range exception.GetExceptionSpec().Policies {
+		namespacePath := policiesPath.Index(i).Child("namespace")
+
+		switch {
+		case !isNamespacedPolicyKind(target.Kind):
+			if target.Namespace != "" {
+				allErrors = append(allErrors, field.Forbidden(namespacePath, "cluster-wide policies have no namespace"))
+			}
+		case exception.GetNamespace() != "":
+			if target.Namespace != "" && target.Namespace != exception.GetNamespace() {
+				allErrors = append(allErrors, field.Forbidden(namespacePath, "a PolicyException can only target the policies of its own namespace"))
+			}
+		case target.Namespace == "":
+			allErrors = append(allErrors, field.Required(namespacePath, "the namespace of the policy is required"))
+		}
+	}
+
+	return allErrors
+}
+
+// prepareInvalidExceptionAPIError is a shorthand for generating an invalid apierrors.StatusError with data from an exception.
+func prepareInvalidExceptionAPIError(exception Exception, errorList field.ErrorList) *apierrors.StatusError {
+	return apierrors.NewInvalid(
+		GroupVersion.WithKind(exception.GetKind()).GroupKind(),
+		exception.GetName(),
+		errorList,
+	)
+}
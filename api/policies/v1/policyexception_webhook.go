@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/go-logr/logr"
+)
+
+// SetupWebhookWithManager registers the PolicyException webhook with the controller manager.
+func (r *PolicyException) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&exceptionValidator{
+			logger: mgr.GetLogger().WithName("policyexception-webhook"),
+		}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed enrolling webhook with manager: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWebhookWithManager registers the ClusterPolicyException webhook with the controller manager.
+func (r *ClusterPolicyException) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&exceptionValidator{
+			logger: mgr.GetLogger().WithName("clusterpolicyexception-webhook"),
+		}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed enrolling webhook with manager: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-policies-kubewarden-io-v1-policyexception,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kubewarden.io,resources=policyexceptions,verbs=create;update,versions=v1,name=vpolicyexception.kb.io,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/validate-policies-kubewarden-io-v1-clusterpolicyexception,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kubewarden.io,resources=clusterpolicyexceptions,verbs=create;update,versions=v1,name=vclusterpolicyexception.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// exceptionValidator validates PolicyException and ClusterPolicyException objects when they are created, updated, or deleted.
+type exceptionValidator struct {
+	logger logr.Logger
+}
+
+var _ webhook.CustomValidator = &exceptionValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *exceptionValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	exception, ok := obj.(Exception)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyException or ClusterPolicyException object, got %T", obj)
+	}
+
+	v.logger.Info("Validating exception creation", "kind", exception.GetKind(), "name", exception.GetName(), "namespace", exception.GetNamespace())
+
+	allErrors := validateExceptionCreate(exception, time.Now())
+	if len(allErrors) != 0 {
+		return nil, prepareInvalidExceptionAPIError(exception, allErrors)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *exceptionValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	exception, ok := newObj.(Exception)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyException or ClusterPolicyException object, got %T", newObj)
+	}
+
+	v.logger.Info("Validating exception update", "kind", exception.GetKind(), "name", exception.GetName(), "namespace", exception.GetNamespace())
+
+	allErrors := validateExceptionUpdate(exception)
+	if len(allErrors) != 0 {
+		return nil, prepareInvalidExceptionAPIError(exception, allErrors)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *exceptionValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	exception, ok := obj.(Exception)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyException or ClusterPolicyException object, got %T", obj)
+	}
+
+	v.logger.Info("Validating exception delete", "kind", exception.GetKind(), "name", exception.GetName(), "namespace", exception.GetNamespace())
+
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PolicyRefs returns the sorted keys of the policies referenced by the
+// members of the policy group. The policies referenced by the members of an
+// AdmissionPolicyGroup live in the namespace of the group.
+func PolicyRefs(policyGroup PolicyGroup) []client.ObjectKey {
+	policyRefs := []client.ObjectKey{}
+	for _, member := range policyGroup.GetPolicyGroupMembersWithContext() {
+		if member.PolicyRef != "" {
+			policyRefs = append(policyRefs, client.ObjectKey{Namespace: policyGroup.GetNamespace(), Name: member.PolicyRef})
+		}
+	}
+	slices.SortFunc(policyRefs, func(a, b client.ObjectKey) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return slices.Compact(policyRefs)
+}
+
+// GetReferencedPolicy returns the policy referenced by a policy group member:
+// the ClusterAdmissionPolicy when the key has no namespace, the AdmissionPolicy
+// otherwise.
+func GetReferencedPolicy(ctx context.Context, k8sReader client.Reader, key client.ObjectKey) (Policy, error) {
+	var policy Policy = &AdmissionPolicy{}
+	if key.Namespace == "" {
+		policy = &ClusterAdmissionPolicy{}
+	}
+	if err := k8sReader.Get(ctx, key, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ListReferencingPolicyGroups returns the policy groups having members
+// referencing the given policy.
+func ListReferencingPolicyGroups(ctx context.Context, k8sReader client.Reader, policy Policy) ([]PolicyGroup, error) {
+	policyGroups := []PolicyGroup{}
+	switch policy.(type) {
+	case *ClusterAdmissionPolicy:
+		var clusterAdmissionPolicyGroups ClusterAdmissionPolicyGroupList
+		if err := k8sReader.List(ctx, &clusterAdmissionPolicyGroups); err != nil {
+			return nil, errors.Join(errors.New("cannot list ClusterAdmissionPolicyGroups"), err)
+		}
+		for i := range clusterAdmissionPolicyGroups.Items {
+			policyGroups = append(policyGroups, &clusterAdmissionPolicyGroups.Items[i])
+		}
+	case *AdmissionPolicy:
+		var admissionPolicyGroups AdmissionPolicyGroupList
+		if err := k8sReader.List(ctx, &admissionPolicyGroups, client.InNamespace(policy.GetNamespace())); err != nil {
+			return nil, errors.Join(errors.New("cannot list AdmissionPolicyGroups"), err)
+		}
+		for i := range admissionPolicyGroups.Items {
+			policyGroups = append(policyGroups, &admissionPolicyGroups.Items[i])
+		}
+	default:
+		return policyGroups, nil
+	}
+
+	policyKey := client.ObjectKeyFromObject(policy)
+	return slices.DeleteFunc(policyGroups, func(policyGroup PolicyGroup) bool {
+		return !slices.Contains(PolicyRefs(policyGroup), policyKey)
+	}), nil
+}
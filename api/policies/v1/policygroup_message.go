@@ -0,0 +1,228 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"text/template"
+	"text/template/parse"
+)
+
+// groupMessageTemplateSample is the data the group message templates are
+// validated against. The Policy Server renders the templates with the same
+// fields when the group rejects a request: the name, namespace and operation
+// of the request, and the policies which rejected it with their messages.
+//
+//nolint:gochecknoglobals // the sample is never modified
+var groupMessageTemplateSample = map[string]any{
+	"name":      "name",
+	"namespace": "namespace",
+	"operation": "CREATE",
+	"rejections": []map[string]any{
+		{"policy": "policy", "message": "message"},
+	},
+}
+
+// ParseGroupMessageTemplate parses the message of a policy group as a Go
+// template, and checks that it only uses the subset of the Go templates
+// supported by the Policy Server:
+//   - the fields available when the template is rendered, on the cursor or
+//     on the $ and range variables;
+//   - the if and range actions, with their else branch. The range action
+//     iterates over the rejections, and can declare the index and element
+//     variables;
+//   - the len function.
+//
+// Every field of the template is checked, including the ones of the branches
+// which are not taken when rendering the sample.
+func ParseGroupMessageTemplate(message string) (*template.Template, error) {
+	messageTemplate, err := template.New("message").Option("missingkey=error").Parse(message)
+	if err != nil {
+		return nil, errors.Join(errors.New("cannot parse the message template"), err)
+	}
+	if len(messageTemplate.Templates()) > 1 {
+		return nil, errors.New("invalid message template: the define and block actions are not supported")
+	}
+	if messageTemplate.Tree != nil {
+		variables := map[string]any{"$": groupMessageTemplateSample}
+		if err = checkGroupMessageNode(messageTemplate.Tree.Root, groupMessageTemplateSample, variables); err != nil {
+			return nil, errors.Join(errors.New("invalid message template"), err)
+		}
+	}
+	if err = messageTemplate.Execute(io.Discard, groupMessageTemplateSample); err != nil {
+		return nil, errors.Join(errors.New("cannot render the message template"), err)
+	}
+
+	return messageTemplate, nil
+}
+
+// checkGroupMessageNode checks the node, where dot is the value of the cursor
+// and variables are the values of the variables in scope.
+func checkGroupMessageNode(node parse.Node, dot any, variables map[string]any) error {
+	switch node := node.(type) {
+	case *parse.TextNode, *parse.CommentNode:
+		return nil
+	case *parse.ListNode:
+		if node == nil {
+			return nil
+		}
+		for _, child := range node.Nodes {
+			if err := checkGroupMessageNode(child, dot, variables); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.ActionNode:
+		if len(node.Pipe.Decl) != 0 {
+			return errors.New("the variables can only be declared by the range actions")
+		}
+		_, err := checkGroupMessagePipe(node.Pipe, dot, variables)
+		return err
+	case *parse.IfNode:
+		if len(node.Pipe.Decl) != 0 {
+			return errors.New("the variables can only be declared by the range actions")
+		}
+		if _, err := checkGroupMessagePipe(node.Pipe, dot, variables); err != nil {
+			return err
+		}
+		if err := checkGroupMessageNode(node.List, dot, maps.Clone(variables)); err != nil {
+			return err
+		}
+		return checkGroupMessageNode(node.ElseList, dot, maps.Clone(variables))
+	case *parse.RangeNode:
+		return checkGroupMessageRange(node, dot, variables)
+	}
+
+	return fmt.Errorf("the %q action is not supported", node.String())
+}
+
+// checkGroupMessageRange checks the range action. The cursor of its list is
+// the element of the list, while the else list keeps the current cursor.
+func checkGroupMessageRange(node *parse.RangeNode, dot any, variables map[string]any) error {
+	value, err := checkGroupMessagePipe(node.Pipe, dot, variables)
+	if err != nil {
+		return err
+	}
+	element, err := groupMessageElement(value)
+	if err != nil {
+		return err
+	}
+
+	scope := maps.Clone(variables)
+	// The range variables hold the index and the element, or the element only.
+	switch len(node.Pipe.Decl) {
+	case 1:
+		scope[node.Pipe.Decl[0].Ident[0]] = element
+	case 2:
+		scope[node.Pipe.Decl[0].Ident[0]] = 0
+		scope[node.Pipe.Decl[1].Ident[0]] = element
+	}
+	if err = checkGroupMessageNode(node.List, element, scope); err != nil {
+		return err
+	}
+
+	return checkGroupMessageNode(node.ElseList, dot, maps.Clone(variables))
+}
+
+// checkGroupMessagePipe checks the pipeline, made of a single command, and
+// returns its value.
+func checkGroupMessagePipe(pipe *parse.PipeNode, dot any, variables map[string]any) (any, error) {
+	if len(pipe.Cmds) != 1 {
+		return nil, fmt.Errorf("the pipeline %q is not supported", pipe.String())
+	}
+	command := pipe.Cmds[0]
+
+	if identifier, ok := command.Args[0].(*parse.IdentifierNode); ok {
+		if identifier.Ident != "len" || len(command.Args) != 2 {
+			return nil, fmt.Errorf("the function %q is not supported", command.String())
+		}
+		if _, err := checkGroupMessageArg(command.Args[1], dot, variables); err != nil {
+			return nil, err
+		}
+		return 0, nil
+	}
+	if len(command.Args) != 1 {
+		return nil, fmt.Errorf("the command %q is not supported", command.String())
+	}
+
+	return checkGroupMessageArg(command.Args[0], dot, variables)
+}
+
+// checkGroupMessageArg checks the fields used by the argument, and returns
+// its value.
+func checkGroupMessageArg(arg parse.Node, dot any, variables map[string]any) (any, error) {
+	switch arg := arg.(type) {
+	case *parse.DotNode:
+		return dot, nil
+	case *parse.FieldNode:
+		return groupMessageFields(dot, arg.Ident)
+	case *parse.VariableNode:
+		value, found := variables[arg.Ident[0]]
+		if !found {
+			return nil, fmt.Errorf("undefined variable %q", arg.Ident[0])
+		}
+		return groupMessageFields(value, arg.Ident[1:])
+	}
+
+	return nil, fmt.Errorf("the argument %q is not supported", arg.String())
+}
+
+// groupMessageFields returns the value of the chain of fields of value, or an
+// error when one of them is not available when the template is rendered.
+func groupMessageFields(value any, fields []string) (any, error) {
+	for _, name := range fields {
+		current, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("the field %q is not available on a %T value", name, value)
+		}
+		if value, ok = current[name]; !ok {
+			return nil, fmt.Errorf("the field %q is not available", name)
+		}
+	}
+
+	return value, nil
+}
+
+// groupMessageElement returns the value of the elements of a list.
+func groupMessageElement(value any) (any, error) {
+	list, ok := value.([]map[string]any)
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("cannot range over a %T value", value)
+	}
+
+	return list[0], nil
+}
+
+// IsGroupMessageTemplate returns true when the message of a policy group has
+// template actions, hence it must be rendered by the Policy Server. The
+// messages made of text only are returned as they are.
+func IsGroupMessageTemplate(message string) bool {
+	messageTemplate, err := ParseGroupMessageTemplate(message)
+	if err != nil || messageTemplate.Tree == nil {
+		return false
+	}
+	for _, node := range messageTemplate.Tree.Root.Nodes {
+		if node.Type() != parse.NodeText {
+			return true
+		}
+	}
+
+	return false
+}
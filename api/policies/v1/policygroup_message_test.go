@@ -0,0 +1,102 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGroupMessageTemplate(t *testing.T) {
+	tests := []struct {
+		name             string
+		message          string
+		expectedError    bool
+		expectedTemplate bool
+	}{
+		{"plain message", "The group rejected the request", false, false},
+		{
+			"template",
+			"{{ .operation }} of {{ .namespace }}/{{ .name }} rejected by {{ range .rejections }}{{ .policy }}: {{ .message }}. {{ end }}",
+			false,
+			true,
+		},
+		{"template with a syntax error", "rejected by {{ range .rejections }}", true, false},
+		{"template with an unknown field", "rejected by {{ .user }}", true, false},
+		{"template with an unknown rejection field", "{{ range .rejections }}{{ .reason }}{{ end }}", true, false},
+		{"template with an unknown field in a branch not taken", "{{ range .rejections }}{{ if .message }}{{ else }}{{ .reason }}{{ end }}{{ end }}", true, false},
+		{"template with an unknown field in an else branch", "{{ if .rejections }}rejected{{ else }}{{ .reason }}{{ end }}", true, false},
+		{"template with an unknown field of a range variable", "{{ range $rejection := .rejections }}{{ $rejection.reason }}{{ end }}", true, false},
+		{"template with a field of a list", "{{ .rejections.policy }}", true, false},
+		{"template with a template action", `{{ define "rejection" }}{{ .user }}{{ end }}{{ template "rejection" . }}`, true, false},
+		{"template with a define action", `{{ define "rejection" }}{{ .name }}{{ end }}rejected`, true, false},
+		{"template with a block action", `{{ block "rejection" . }}{{ .name }}{{ end }}`, true, false},
+		{"template with a with action", "{{ with .name }}{{ . }}{{ end }}", true, false},
+		{"template with a range over a field", "{{ range .name }}{{ . }}{{ end }}", true, false},
+		{"template with the printf function", `{{ printf "%s" .name }}`, true, false},
+		{"template with the index function", "{{ index .rejections 0 }}", true, false},
+		{"template with the call function", "{{ call .name }}", true, false},
+		{"template with the html function", "{{ html .name }}", true, false},
+		{"template with a pipeline", "{{ .rejections | len }}", true, false},
+		{"template with a variable declaration", "{{ $name := .name }}{{ $name }}", true, false},
+		{"template with a literal", `{{ "rejected" }}`, true, false},
+		{"template with the len function", "rejected by {{ len .rejections }} policies", false, true},
+		{
+			"template with variables",
+			"{{ range $index, $rejection := .rejections }}{{ $index }} {{ $rejection.policy }} of {{ $.name }}{{ end }}",
+			false,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseGroupMessageTemplate(test.message)
+			if test.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedTemplate, IsGroupMessageTemplate(test.message))
+		})
+	}
+}
+
+func TestValidatePolicyGroupMessageField(t *testing.T) {
+	policyGroup := NewClusterAdmissionPolicyGroupFactory().Build()
+	policyGroup.Spec.Message = "rejected by {{ range .rejections }}{{ .policy }} {{ end }}"
+	require.Nil(t, validatePolicyGroupMessageField(policyGroup))
+
+	policyGroup.Spec.Message = "rejected by {{ .rejections.policy }}"
+	err := validatePolicyGroupMessageField(policyGroup)
+	require.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "spec.message: Invalid value"))
+}
+
+func TestValidatePolicyGroupUpdateMessage(t *testing.T) {
+	oldPolicyGroup := NewClusterAdmissionPolicyGroupFactory().WithMode(PolicyModeMonitor).Build()
+	oldPolicyGroup.Spec.Message = "rejected by {{ .user }}"
+	newPolicyGroup := oldPolicyGroup.DeepCopy()
+	newPolicyGroup.Spec.Mode = PolicyModeProtect
+	require.Empty(t, validatePolicyGroupUpdate(oldPolicyGroup, newPolicyGroup, SensitiveResources{}, nil, breakGlassAuthorization{}))
+
+	newPolicyGroup.Spec.Message = "rejected by {{ .group }}"
+	allErrors := validatePolicyGroupUpdate(oldPolicyGroup, newPolicyGroup, SensitiveResources{}, nil, breakGlassAuthorization{})
+	require.Len(t, allErrors, 1)
+	assert.Equal(t, "spec.message", allErrors[0].Field)
+}
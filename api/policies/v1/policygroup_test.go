@@ -0,0 +1,42 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPolicyRefs(t *testing.T) {
+	clusterPolicyGroup := NewClusterAdmissionPolicyGroupFactory().Build()
+	clusterPolicyGroup.Spec.Policies = PolicyGroupMembersWithContext{
+		"privileged":     {PolicyGroupMember: PolicyGroupMember{PolicyRef: "privileged-pods"}},
+		"privileged_too": {PolicyGroupMember: PolicyGroupMember{PolicyRef: "privileged-pods"}},
+		"inline":         {PolicyGroupMember: PolicyGroupMember{Module: "ghcr.io/kubewarden/tests/user-group-psp:v0.4.9"}},
+	}
+	assert.Equal(t, []client.ObjectKey{{Name: "privileged-pods"}}, PolicyRefs(clusterPolicyGroup))
+
+	policyGroup := NewAdmissionPolicyGroupFactory().WithNamespace("team-a").Build()
+	policyGroup.Spec.Policies = PolicyGroupMembersWithContext{
+		"labels":     {PolicyGroupMember: PolicyGroupMember{PolicyRef: "safe-labels"}},
+		"privileged": {PolicyGroupMember: PolicyGroupMember{PolicyRef: "privileged-pods"}},
+	}
+	assert.Equal(t, []client.ObjectKey{
+		{Namespace: "team-a", Name: "privileged-pods"},
+		{Namespace: "team-a", Name: "safe-labels"},
+	}, PolicyRefs(policyGroup))
+}
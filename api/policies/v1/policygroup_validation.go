@@ -9,8 +9,10 @@ import (
 	"github.com/google/cel-go/common/operators"
 	"github.com/google/cel-go/common/stdlib"
 	"github.com/google/cel-go/common/types"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	apiservercel "k8s.io/apiserver/pkg/cel"
 )
 
 // Regex to validate the policy members names.
@@ -29,26 +31,36 @@ var celReservedSymbols = sets.NewString(
 	"var", "void", "while",
 )
 
-func validatePolicyGroupCreate(policyGroup PolicyGroup) field.ErrorList {
+func validatePolicyGroupCreate(policyGroup PolicyGroup, sensitiveResources SensitiveResources, restMapper meta.RESTMapper) field.ErrorList {
 	var allErrors field.ErrorList
 
-	allErrors = append(allErrors, validatePolicyCreate(policyGroup)...)
+	allErrors = append(allErrors, validatePolicyCreate(policyGroup, sensitiveResources, restMapper)...)
 	allErrors = append(allErrors, validatePolicyGroupMembers(policyGroup)...)
 	if err := validatePolicyGroupExpressionField(policyGroup); err != nil {
 		allErrors = append(allErrors, err)
 	}
+	if err := validatePolicyGroupMessageField(policyGroup); err != nil {
+		allErrors = append(allErrors, err)
+	}
 
 	return allErrors
 }
 
-func validatePolicyGroupUpdate(oldPolicyGroup, newPolicyGroup PolicyGroup) field.ErrorList {
+func validatePolicyGroupUpdate(oldPolicyGroup, newPolicyGroup PolicyGroup, sensitiveResources SensitiveResources, restMapper meta.RESTMapper, authorization breakGlassAuthorization) field.ErrorList {
 	var allErrors field.ErrorList
 
-	allErrors = append(allErrors, validatePolicyUpdate(oldPolicyGroup, newPolicyGroup)...)
+	allErrors = append(allErrors, validatePolicyUpdate(oldPolicyGroup, newPolicyGroup, sensitiveResources, restMapper, authorization)...)
 	allErrors = append(allErrors, validatePolicyGroupMembers(newPolicyGroup)...)
 	if err := validatePolicyGroupExpressionField(newPolicyGroup); err != nil {
 		allErrors = append(allErrors, err)
 	}
+	// The message is only validated when it changes, so that the policy
+	// groups created before the message was validated can still be updated.
+	if oldPolicyGroup.GetMessage() != newPolicyGroup.GetMessage() {
+		if err := validatePolicyGroupMessageField(newPolicyGroup); err != nil {
+			allErrors = append(allErrors, err)
+		}
+	}
 
 	return allErrors
 }
@@ -59,8 +71,12 @@ func validatePolicyGroupMembers(policyGroup PolicyGroup) field.ErrorList {
 	if len(policyGroup.GetPolicyGroupMembersWithContext()) == 0 {
 		allErrors = append(allErrors, field.Required(field.NewPath("spec").Child("policies"), "policy groups must have at least one policy member"))
 	}
-	for memberName := range policyGroup.GetPolicyGroupMembersWithContext() {
+	for memberName, member := range policyGroup.GetPolicyGroupMembersWithContext() {
+		allErrors = append(allErrors, validatePolicyGroupMemberSource(member, field.NewPath("spec").Child("policies").Key(memberName))...)
 		_, matchReservedSymbol := celReservedSymbols[memberName]
+		if policyGroup.GetExpressionVersion() == GroupExpressionVersionV2 && memberName == groupExpressionRequestVariable {
+			matchReservedSymbol = true
+		}
 		if len(memberName) == 0 || matchReservedSymbol || !idenRegex.MatchString(memberName) {
 			allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("policies"), memberName, "policy group member name is invalid"))
 		}
@@ -69,9 +85,69 @@ func validatePolicyGroupMembers(policyGroup PolicyGroup) field.ErrorList {
 	return allErrors
 }
 
-// validatePolicyGroupExpressionField validates that the expression is a valid CEL expression that evaluates to a boolean.
-// Only the following operators are allowed: equals, not equals, logical or, logical and, and logical not.
-// Policy members are imported as custom functions that take no arguments and return a boolean.
+// validatePolicyGroupMemberSource validates that the member sets exactly one
+// of module, moduleRef and policyRef. The members referencing a policy take
+// their settings and context aware resources from it.
+func validatePolicyGroupMemberSource(member PolicyGroupMemberWithContext, fldPath *field.Path) field.ErrorList {
+	if member.PolicyRef == "" {
+		return validateModuleReference(member.Module, member.ModuleRef, fldPath)
+	}
+
+	var allErrors field.ErrorList
+	if member.Module != "" || member.ModuleRef != "" {
+		allErrors = append(allErrors, field.Forbidden(fldPath.Child("policyRef"), "policyRef is mutually exclusive with module and moduleRef"))
+	}
+	if len(member.Settings.Raw) != 0 || member.Settings.Object != nil {
+		allErrors = append(allErrors, field.Forbidden(fldPath.Child("settings"), "the settings are taken from the referenced policy"))
+	}
+	if len(member.ContextAwareResources) != 0 {
+		allErrors = append(allErrors, field.Forbidden(fldPath.Child("contextAwareResources"), "the context aware resources are taken from the referenced policy"))
+	}
+
+	return allErrors
+}
+
+// validatePolicyGroupMessageField validates that the message is a valid
+// template, which only uses the fields available when it is rendered.
+func validatePolicyGroupMessageField(policyGroup PolicyGroup) *field.Error {
+	if _, err := ParseGroupMessageTemplate(policyGroup.GetMessage()); err != nil {
+		return field.Invalid(field.NewPath("spec").Child("message"), policyGroup.GetMessage(), err.Error())
+	}
+
+	return nil
+}
+
+// groupExpressionRequestVariable is the variable of the "v2" group
+// expressions holding the operation, namespace and userInfo of the request.
+const groupExpressionRequestVariable = "request"
+
+// groupExpressionRequestType is the type of the request variable of the "v2"
+// group expressions.
+//
+//nolint:gochecknoglobals // Using a global variable to avoid recreating it every evaluation
+var groupExpressionRequestType = apiservercel.NewObjectType("kubewarden.GroupExpressionRequest", map[string]*apiservercel.DeclField{
+	"operation": apiservercel.NewDeclField("operation", apiservercel.StringType, true, nil, nil),
+	"namespace": apiservercel.NewDeclField("namespace", apiservercel.StringType, true, nil, nil),
+	"userInfo": apiservercel.NewDeclField("userInfo", apiservercel.NewObjectType("kubewarden.GroupExpressionRequest.UserInfo", map[string]*apiservercel.DeclField{
+		"username": apiservercel.NewDeclField("username", apiservercel.StringType, true, nil, nil),
+		"uid":      apiservercel.NewDeclField("uid", apiservercel.StringType, true, nil, nil),
+		"groups":   apiservercel.NewDeclField("groups", apiservercel.NewListType(apiservercel.StringType, -1), true, nil, nil),
+		"extra": apiservercel.NewDeclField("extra",
+			apiservercel.NewMapType(apiservercel.StringType, apiservercel.NewListType(apiservercel.StringType, -1), -1), true, nil, nil),
+	}), true, nil, nil),
+})
+
+// groupExpressionMemberResultType is the type of the variables of the "v2"
+// group expressions holding the results of the policy members.
+//
+//nolint:gochecknoglobals // Using a global variable to avoid recreating it every evaluation
+var groupExpressionMemberResultType = apiservercel.NewObjectType("kubewarden.GroupExpressionMemberResult", map[string]*apiservercel.DeclField{
+	"allowed": apiservercel.NewDeclField("allowed", apiservercel.BoolType, true, nil, nil),
+	"message": apiservercel.NewDeclField("message", apiservercel.StringType, true, nil, nil),
+})
+
+// validatePolicyGroupExpressionField validates that the expression is a valid
+// CEL expression of the environment of its version, that evaluates to a boolean.
 func validatePolicyGroupExpressionField(policyGroup PolicyGroup) *field.Error {
 	expressionField := field.NewPath("spec").Child("expression")
 
@@ -79,13 +155,36 @@ func validatePolicyGroupExpressionField(policyGroup PolicyGroup) *field.Error {
 		return field.Required(expressionField, "must be non-empty")
 	}
 
-	// Create a CEL environment with custom functions for each policy member
-	var opts []cel.EnvOption
-	for policyName := range policyGroup.GetPolicyGroupMembersWithContext() {
-		fn := cel.Function(policyName, cel.Overload(policyName, []*cel.Type{}, types.BoolType))
-		opts = append(opts, fn)
+	var env *cel.Env
+	var err error
+	switch policyGroup.GetExpressionVersion() {
+	case GroupExpressionVersionV2:
+		env, err = newGroupExpressionEnvV2(policyGroup.GetPolicyGroupMembersWithContext())
+	default:
+		env, err = newGroupExpressionEnvV1(policyGroup.GetPolicyGroupMembersWithContext())
+	}
+	if err != nil {
+		return field.InternalError(expressionField, fmt.Errorf("error creating CEL environment: %w", err))
+	}
+
+	ast, issues := env.Compile(policyGroup.GetExpression())
+	if issues != nil && issues.Err() != nil {
+		return field.Invalid(expressionField, policyGroup.GetExpression(), fmt.Sprintf("compilation failed: %v", issues.Err()))
+	}
+	if ast.OutputType() != types.BoolType {
+		return field.Invalid(expressionField, policyGroup.GetExpression(), "must evaluate to bool")
 	}
 
+	return nil
+}
+
+// newGroupExpressionEnvV1 returns the environment of the "v1" group
+// expressions. Only the following operators are allowed: equals, not equals,
+// logical or, logical and, and logical not. Policy members are imported as
+// custom functions that take no arguments and return a boolean.
+func newGroupExpressionEnvV1(members PolicyGroupMembersWithContext) (*cel.Env, error) {
+	opts := groupMemberFunctions(members)
+
 	// Import only equals, not equals, logical or, logical and, and logical not operators
 	// from the standard library
 	allowedOperators := map[string]bool{
@@ -106,20 +205,40 @@ func validatePolicyGroupExpressionField(policyGroup PolicyGroup) *field.Error {
 			}))
 	}
 
-	env, err := cel.NewCustomEnv(
-		opts...,
-	)
+	return cel.NewCustomEnv(opts...)
+}
+
+// newGroupExpressionEnvV2 returns the environment of the "v2" group
+// expressions: the "v1" member functions, the CEL standard library, the
+// request variable, and a variable for each member holding its result as an
+// object with the allowed and message fields.
+func newGroupExpressionEnvV2(members PolicyGroupMembersWithContext) (*cel.Env, error) {
+	baseEnv, err := cel.NewEnv()
 	if err != nil {
-		return field.InternalError(expressionField, fmt.Errorf("error creating CEL environment: %w", err))
+		return nil, err
+	}
+	typeProvider := apiservercel.NewDeclTypeProvider(groupExpressionRequestType, groupExpressionMemberResultType)
+	opts, err := typeProvider.EnvOptions(baseEnv.CELTypeProvider())
+	if err != nil {
+		return nil, err
 	}
 
-	ast, issues := env.Compile(policyGroup.GetExpression())
-	if issues != nil && issues.Err() != nil {
-		return field.Invalid(expressionField, policyGroup.GetExpression(), fmt.Sprintf("compilation failed: %v", issues.Err()))
+	opts = append(opts, groupMemberFunctions(members)...)
+	for memberName := range members {
+		opts = append(opts, cel.Variable(memberName, groupExpressionMemberResultType.CelType()))
 	}
-	if ast.OutputType() != types.BoolType {
-		return field.Invalid(expressionField, policyGroup.GetExpression(), "must evaluate to bool")
+	opts = append(opts, cel.Variable(groupExpressionRequestVariable, groupExpressionRequestType.CelType()))
+
+	return baseEnv.Extend(opts...)
+}
+
+// groupMemberFunctions declares a function for each policy member, returning
+// whether the member accepts the request.
+func groupMemberFunctions(members PolicyGroupMembersWithContext) []cel.EnvOption {
+	opts := make([]cel.EnvOption, 0, len(members))
+	for policyName := range members {
+		opts = append(opts, cel.Function(policyName, cel.Overload(policyName, []*cel.Type{}, types.BoolType)))
 	}
 
-	return nil
+	return opts
 }
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 func TestValidatePolicyGroupExpressionField(t *testing.T) {
@@ -341,3 +343,98 @@ func TestValidatePolicyGroupMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePolicyGroupMemberSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		member         PolicyGroupMemberWithContext
+		expectedErrors int
+	}{
+		{
+			"with policyRef",
+			PolicyGroupMemberWithContext{PolicyGroupMember: PolicyGroupMember{PolicyRef: "privileged-pods"}},
+			0,
+		},
+		{
+			"with policyRef and module",
+			PolicyGroupMemberWithContext{PolicyGroupMember: PolicyGroupMember{PolicyRef: "privileged-pods", Module: "ghcr.io/kubewarden/tests/pod-privileged:v0.2.5"}},
+			1,
+		},
+		{
+			"with policyRef and settings",
+			PolicyGroupMemberWithContext{PolicyGroupMember: PolicyGroupMember{PolicyRef: "privileged-pods", Settings: runtime.RawExtension{Raw: []byte(`{}`)}}},
+			1,
+		},
+		{
+			"with policyRef and context aware resources",
+			PolicyGroupMemberWithContext{
+				PolicyGroupMember:     PolicyGroupMember{PolicyRef: "privileged-pods"},
+				ContextAwareResources: []ContextAwareResource{{APIVersion: "v1", Kind: "Pod"}},
+			},
+			1,
+		},
+		{
+			"without module, moduleRef and policyRef",
+			PolicyGroupMemberWithContext{},
+			1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Len(t, validatePolicyGroupMemberSource(test.member, field.NewPath("spec").Child("policies").Key("member")), test.expectedErrors)
+		})
+	}
+}
+
+func TestValidatePolicyGroupExpressionFieldVersions(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       GroupExpressionVersion
+		expression    string
+		expectedError bool
+	}{
+		{"v1 expression", GroupExpressionVersionV1, "pod_privileged() && user_group_psp()", false},
+		{"v1 expression with ternary", GroupExpressionVersionV1, "pod_privileged() ? true : user_group_psp()", true},
+		{"v1 expression with request", GroupExpressionVersionV1, `request.namespace == "kube-system" || pod_privileged()`, true},
+		{"v1 expression in v2", GroupExpressionVersionV2, "pod_privileged() && user_group_psp()", false},
+		{"v2 threshold", GroupExpressionVersionV2, "[pod_privileged(), user_group_psp()].filter(allowed, allowed).size() >= 1", false},
+		{"v2 ternary", GroupExpressionVersionV2, "pod_privileged() ? true : user_group_psp()", false},
+		{"v2 request", GroupExpressionVersionV2, `request.namespace == "kube-system" || request.operation == "DELETE" || pod_privileged()`, false},
+		{"v2 user info", GroupExpressionVersionV2, `"system:masters" in request.userInfo.groups || pod_privileged()`, false},
+		{"v2 member results", GroupExpressionVersionV2, `pod_privileged.allowed || user_group_psp.message.contains("ignored")`, false},
+		{"v2 unknown member", GroupExpressionVersionV2, "unknown.allowed", true},
+		{"v2 non boolean", GroupExpressionVersionV2, "size([pod_privileged(), user_group_psp()])", true},
+		{"v2 non boolean member result", GroupExpressionVersionV2, "pod_privileged.message", true},
+		{"v2 non boolean request field", GroupExpressionVersionV2, "request.namespace", true},
+		{"v2 unknown request field", GroupExpressionVersionV2, `request.resource == "pods"`, true},
+		{"v2 unknown member result field", GroupExpressionVersionV2, "pod_privileged.code == 403", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policyGroup := NewClusterAdmissionPolicyGroupFactory().Build()
+			policyGroup.Spec.Expression = test.expression
+			policyGroup.Spec.ExpressionVersion = test.version
+
+			err := validatePolicyGroupExpressionField(policyGroup)
+			if test.expectedError {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyGroupMembersReservedInV2(t *testing.T) {
+	policyGroup := NewClusterAdmissionPolicyGroupFactory().
+		WithMembers(PolicyGroupMembersWithContext{
+			"request": {PolicyGroupMember: PolicyGroupMember{Module: "ghcr.io/kubewarden/tests/user-group-psp:v0.4.9"}},
+		}).
+		Build()
+	require.Empty(t, validatePolicyGroupMembers(policyGroup))
+
+	policyGroup.Spec.ExpressionVersion = GroupExpressionVersionV2
+	require.Len(t, validatePolicyGroupMembers(policyGroup), 1)
+}
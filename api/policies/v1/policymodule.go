@@ -0,0 +1,168 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const registryModulePrefix = "registry://"
+
+// isRegistryModule returns true when the module is served by an
+// OCI-compatible registry, which is the default when the module has no
+// prefix.
+func isRegistryModule(module string) bool {
+	return strings.HasPrefix(module, registryModulePrefix) || !strings.Contains(module, "://")
+}
+
+// splitModuleDigest splits the module into its reference without digest, and
+// its digest.
+func splitModuleDigest(module string) (string, string) {
+	if !isRegistryModule(module) {
+		return module, ""
+	}
+	reference, digest, _ := strings.Cut(module, "@")
+
+	return reference, digest
+}
+
+// ResolvedModule returns the module served to the Policy Servers. When the
+// digest is set, the module is pinned to it, and its tag is dropped.
+func (r *PolicyModule) ResolvedModule() string {
+	if r.Spec.Digest == "" {
+		return r.Spec.Module
+	}
+
+	reference, _ := splitModuleDigest(r.Spec.Module)
+	if tagIndex := strings.LastIndex(reference, ":"); tagIndex > strings.LastIndex(reference, "/") {
+		reference = reference[:tagIndex]
+	}
+
+	return reference + "@" + r.Spec.Digest
+}
+
+// ResolvedDigest returns the digest the module is pinned to, either by the
+// digest field or by the module reference itself.
+func (r *PolicyModule) ResolvedDigest() string {
+	if r.Spec.Digest != "" {
+		return r.Spec.Digest
+	}
+	_, digest := splitModuleDigest(r.Spec.Module)
+
+	return digest
+}
+
+// HasSignatures returns true when the module must be signed.
+func (r *PolicyModule) HasSignatures() bool {
+	return r.Spec.Signatures != nil && (len(r.Spec.Signatures.PublicKeys) > 0 || len(r.Spec.Signatures.Keyless) > 0)
+}
+
+// ResolvePolicyModule returns the module of the policy. When the policy
+// references a PolicyModule, the module served by the PolicyModule is
+// returned.
+func ResolvePolicyModule(ctx context.Context, k8sReader client.Reader, policy Policy) (string, error) {
+	if policy.GetModuleRef() == "" {
+		return policy.GetModule(), nil
+	}
+
+	var policyModule PolicyModule
+	if err := k8sReader.Get(ctx, client.ObjectKey{Name: policy.GetModuleRef()}, &policyModule); err != nil {
+		return "", err
+	}
+
+	return policyModule.ResolvedModule(), nil
+}
+
+// ModuleRefs returns the sorted names of the PolicyModules referenced by the
+// policy, or by the members of the policy group.
+func ModuleRefs(policy Policy) []string {
+	moduleRefs := []string{}
+	if policy.GetModuleRef() != "" {
+		moduleRefs = append(moduleRefs, policy.GetModuleRef())
+	}
+	if policyGroup, ok := policy.(PolicyGroup); ok {
+		for _, member := range policyGroup.GetPolicyGroupMembersWithContext() {
+			if member.ModuleRef != "" {
+				moduleRefs = append(moduleRefs, member.ModuleRef)
+			}
+		}
+	}
+	slices.Sort(moduleRefs)
+
+	return slices.Compact(moduleRefs)
+}
+
+// ListPolicyModuleConsumers returns the policies and the policy groups
+// referencing the given PolicyModule, including the policy groups with members
+// referencing a policy that uses it.
+func ListPolicyModuleConsumers(ctx context.Context, k8sReader client.Reader, moduleName string) ([]Policy, error) {
+	var clusterAdmissionPolicies ClusterAdmissionPolicyList
+	if err := k8sReader.List(ctx, &clusterAdmissionPolicies); err != nil {
+		return nil, errors.Join(errors.New("cannot list ClusterAdmissionPolicies"), err)
+	}
+	var admissionPolicies AdmissionPolicyList
+	if err := k8sReader.List(ctx, &admissionPolicies); err != nil {
+		return nil, errors.Join(errors.New("cannot list AdmissionPolicies"), err)
+	}
+	var clusterAdmissionPolicyGroups ClusterAdmissionPolicyGroupList
+	if err := k8sReader.List(ctx, &clusterAdmissionPolicyGroups); err != nil {
+		return nil, errors.Join(errors.New("cannot list ClusterAdmissionPolicyGroups"), err)
+	}
+	var admissionPolicyGroups AdmissionPolicyGroupList
+	if err := k8sReader.List(ctx, &admissionPolicyGroups); err != nil {
+		return nil, errors.Join(errors.New("cannot list AdmissionPolicyGroups"), err)
+	}
+
+	policies := []Policy{}
+	for i := range clusterAdmissionPolicies.Items {
+		policies = append(policies, &clusterAdmissionPolicies.Items[i])
+	}
+	for i := range admissionPolicies.Items {
+		policies = append(policies, &admissionPolicies.Items[i])
+	}
+	for i := range clusterAdmissionPolicyGroups.Items {
+		policies = append(policies, &clusterAdmissionPolicyGroups.Items[i])
+	}
+	for i := range admissionPolicyGroups.Items {
+		policies = append(policies, &admissionPolicyGroups.Items[i])
+	}
+
+	consumerKeys := []client.ObjectKey{}
+	for _, policy := range policies {
+		if _, ok := policy.(PolicyGroup); !ok && slices.Contains(ModuleRefs(policy), moduleName) {
+			consumerKeys = append(consumerKeys, client.ObjectKeyFromObject(policy))
+		}
+	}
+
+	return slices.DeleteFunc(policies, func(policy Policy) bool {
+		if slices.Contains(ModuleRefs(policy), moduleName) {
+			return false
+		}
+		policyGroup, ok := policy.(PolicyGroup)
+		if !ok {
+			return true
+		}
+		return !slices.ContainsFunc(PolicyRefs(policyGroup), func(policyRef client.ObjectKey) bool {
+			return slices.Contains(consumerKeys, policyRef)
+		})
+	}), nil
+}
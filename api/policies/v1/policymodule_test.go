@@ -0,0 +1,213 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestPolicyModuleResolvedModule(t *testing.T) {
+	tests := []struct {
+		name                   string
+		spec                   PolicyModuleSpec
+		expectedResolvedModule string
+		expectedResolvedDigest string
+	}{
+		{
+			"without digest",
+			PolicyModuleSpec{Module: "registry://ghcr.io/kubewarden/policies/pod-privileged:v0.4.0"},
+			"registry://ghcr.io/kubewarden/policies/pod-privileged:v0.4.0",
+			"",
+		},
+		{
+			"with digest",
+			PolicyModuleSpec{Module: "registry://ghcr.io/kubewarden/policies/pod-privileged:v0.4.0", Digest: testDigest},
+			"registry://ghcr.io/kubewarden/policies/pod-privileged@" + testDigest,
+			testDigest,
+		},
+		{
+			"with digest and registry port",
+			PolicyModuleSpec{Module: "localhost:5000/pod-privileged", Digest: testDigest},
+			"localhost:5000/pod-privileged@" + testDigest,
+			testDigest,
+		},
+		{
+			"with digest in the module",
+			PolicyModuleSpec{Module: "ghcr.io/kubewarden/policies/pod-privileged@" + testDigest},
+			"ghcr.io/kubewarden/policies/pod-privileged@" + testDigest,
+			testDigest,
+		},
+		{
+			"with an HTTP module",
+			PolicyModuleSpec{Module: "https://example.com/pod-privileged.wasm"},
+			"https://example.com/pod-privileged.wasm",
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policyModule := &PolicyModule{Spec: test.spec}
+			assert.Equal(t, test.expectedResolvedModule, policyModule.ResolvedModule())
+			assert.Equal(t, test.expectedResolvedDigest, policyModule.ResolvedDigest())
+		})
+	}
+}
+
+func TestValidatePolicyModule(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           PolicyModuleSpec
+		expectedErrors int
+	}{
+		{"registry module with digest", PolicyModuleSpec{Module: "registry://ghcr.io/kubewarden/policies/pod-privileged:v0.4.0", Digest: testDigest}, 0},
+		{"HTTP module with digest", PolicyModuleSpec{Module: "https://example.com/pod-privileged.wasm", Digest: testDigest}, 1},
+		{"module with the same digest", PolicyModuleSpec{Module: "ghcr.io/kubewarden/policies/pod-privileged@" + testDigest, Digest: testDigest}, 0},
+		{
+			"module with a conflicting digest",
+			PolicyModuleSpec{
+				Module: "ghcr.io/kubewarden/policies/pod-privileged@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+				Digest: testDigest,
+			},
+			1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Len(t, validatePolicyModule(&PolicyModule{Spec: test.spec}), test.expectedErrors)
+		})
+	}
+}
+
+func TestValidateModuleReference(t *testing.T) {
+	tests := []struct {
+		name          string
+		module        string
+		moduleRef     string
+		expectedError field.ErrorType
+	}{
+		{"with module", "ghcr.io/kubewarden/policies/pod-privileged:v0.4.0", "", ""},
+		{"with moduleRef", "", "pod-privileged", ""},
+		{"without module and moduleRef", "", "", field.ErrorTypeRequired},
+		{"with module and moduleRef", "ghcr.io/kubewarden/policies/pod-privileged:v0.4.0", "pod-privileged", field.ErrorTypeForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allErrors := validateModuleReference(test.module, test.moduleRef, field.NewPath("spec"))
+			if test.expectedError == "" {
+				assert.Empty(t, allErrors)
+				return
+			}
+			if assert.Len(t, allErrors, 1) {
+				assert.Equal(t, test.expectedError, allErrors[0].Type)
+			}
+		})
+	}
+}
+
+func TestModuleRefs(t *testing.T) {
+	policy := NewClusterAdmissionPolicyFactory().Build()
+	assert.Empty(t, ModuleRefs(policy))
+
+	policy.Spec.Module = ""
+	policy.Spec.ModuleRef = "pod-privileged"
+	assert.Equal(t, []string{"pod-privileged"}, ModuleRefs(policy))
+
+	policyGroup := NewClusterAdmissionPolicyGroupFactory().Build()
+	policyGroup.Spec.Policies = PolicyGroupMembersWithContext{
+		"privileged":     {PolicyGroupMember: PolicyGroupMember{ModuleRef: "pod-privileged"}},
+		"privileged_too": {PolicyGroupMember: PolicyGroupMember{ModuleRef: "pod-privileged"}},
+		"labels":         {PolicyGroupMember: PolicyGroupMember{ModuleRef: "safe-labels"}},
+		"inline":         {PolicyGroupMember: PolicyGroupMember{Module: "ghcr.io/kubewarden/tests/user-group-psp:v0.4.9"}},
+	}
+	assert.Equal(t, []string{"pod-privileged", "safe-labels"}, ModuleRefs(policyGroup))
+}
+
+func TestListPolicyModuleConsumers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	policy := NewClusterAdmissionPolicyFactory().WithName("privileged").Build()
+	policy.Spec.Module = ""
+	policy.Spec.ModuleRef = "pod-privileged"
+	otherPolicy := NewClusterAdmissionPolicyFactory().WithName("other").Build()
+	referencingPolicyGroup := NewClusterAdmissionPolicyGroupFactory().WithName("referencing-policy").Build()
+	referencingPolicyGroup.Spec.Policies = PolicyGroupMembersWithContext{
+		"privileged": {PolicyGroupMember: PolicyGroupMember{PolicyRef: policy.GetName()}},
+	}
+	referencingModuleGroup := NewClusterAdmissionPolicyGroupFactory().WithName("referencing-module").Build()
+	referencingModuleGroup.Spec.Policies = PolicyGroupMembersWithContext{
+		"privileged": {PolicyGroupMember: PolicyGroupMember{ModuleRef: "pod-privileged"}},
+	}
+	unrelatedPolicyGroup := NewClusterAdmissionPolicyGroupFactory().WithName("unrelated").Build()
+	unrelatedPolicyGroup.Spec.Policies = PolicyGroupMembersWithContext{
+		"other": {PolicyGroupMember: PolicyGroupMember{PolicyRef: otherPolicy.GetName()}},
+	}
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(policy, otherPolicy, referencingPolicyGroup, referencingModuleGroup, unrelatedPolicyGroup).
+		Build()
+
+	consumers, err := ListPolicyModuleConsumers(context.Background(), k8sClient, "pod-privileged")
+	require.NoError(t, err)
+
+	consumerNames := []string{}
+	for _, consumer := range consumers {
+		consumerNames = append(consumerNames, consumer.GetName())
+	}
+	assert.ElementsMatch(t, []string{"privileged", "referencing-policy", "referencing-module"}, consumerNames)
+}
+
+func TestResolvePolicyModule(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+
+	policyModule := &PolicyModule{
+		ObjectMeta: metav1.ObjectMeta{Name: "cel-policy"},
+		Spec: PolicyModuleSpec{
+			Module: "registry://ghcr.io/kubewarden/policies/cel-policy:v1.0.0",
+			Digest: testDigest,
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policyModule).Build()
+
+	policy := NewClusterAdmissionPolicyFactory().Build()
+	module, err := ResolvePolicyModule(context.Background(), k8sClient, policy)
+	require.NoError(t, err)
+	assert.Equal(t, policy.Spec.Module, module)
+
+	policy.Spec.Module = ""
+	policy.Spec.ModuleRef = policyModule.GetName()
+	module, err = ResolvePolicyModule(context.Background(), k8sClient, policy)
+	require.NoError(t, err)
+	assert.Equal(t, "registry://ghcr.io/kubewarden/policies/cel-policy@"+testDigest, module)
+
+	policy.Spec.ModuleRef = "missing"
+	_, err = ResolvePolicyModule(context.Background(), k8sClient, policy)
+	require.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,157 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PolicyModuleKind = "PolicyModule"
+
+	// PolicyModuleSignaturesVerified represents the condition of the
+	// verification of the module signatures by the Policy Servers running
+	// the module.
+	PolicyModuleSignaturesVerified PolicyConditionType = "PolicyModuleSignaturesVerified"
+	// PolicyModuleResolved represents the condition of the resolution of the
+	// PolicyModules referenced by a policy.
+	PolicyModuleResolved PolicyConditionType = "PolicyModuleResolved"
+)
+
+// PolicyModuleKeylessSignature is the identity of a keyless signature.
+type PolicyModuleKeylessSignature struct {
+	// Issuer of the OIDC token used to sign the module.
+	// +kubebuilder:validation:MinLength=1
+	Issuer string `json:"issuer"`
+
+	// Subject of the OIDC token used to sign the module.
+	// +kubebuilder:validation:MinLength=1
+	Subject string `json:"subject"`
+}
+
+// PolicyModuleSignatures are the signatures the module must have. The module
+// must be signed by at least one of the public keys or keyless identities.
+type PolicyModuleSignatures struct {
+	// PublicKeys are PEM encoded public keys.
+	// +optional
+	PublicKeys []string `json:"publicKeys,omitempty"`
+
+	// Keyless are the identities of the accepted keyless signatures.
+	// +optional
+	Keyless []PolicyModuleKeylessSignature `json:"keyless,omitempty"`
+}
+
+// PolicyModuleSpec defines a WASM module shared by the policies and the
+// policy group members referencing it through their moduleRef.
+type PolicyModuleSpec struct {
+	// Module is the location of the WASM module to be loaded. Can be a
+	// local file (file://), a remote file served by an HTTP server
+	// (http://, https://), or an artifact served by an OCI-compatible
+	// registry (registry://).
+	// If prefix is missing, it will default to registry:// and use that
+	// internally.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Module string `json:"module"`
+
+	// Digest is the expected digest of the module. The module served to the
+	// Policy Servers is pinned to the digest, which is only supported by the
+	// modules served by an OCI-compatible registry.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Digest string `json:"digest,omitempty"`
+
+	// Signatures are the signatures the module must have. They are added to
+	// the Sigstore verification configuration generated for the Policy
+	// Servers running the module. The verification configuration of a
+	// Policy Server applies to all its modules: they must be signed by one
+	// of the signatures of the PolicyModules it runs. The Policy Servers
+	// with a verificationConfig keep it, and are reported in the status.
+	// +optional
+	Signatures *PolicyModuleSignatures `json:"signatures,omitempty"`
+}
+
+// PolicyModuleConsumer identifies a policy using the module.
+type PolicyModuleConsumer struct {
+	// Kind of the policy.
+	Kind string `json:"kind"`
+
+	// Name of the policy.
+	Name string `json:"name"`
+
+	// Namespace of the AdmissionPolicy or AdmissionPolicyGroup.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Members of the policy group using the module.
+	// +optional
+	Members []string `json:"members,omitempty"`
+
+	// PolicyServer the policy is bound to.
+	// +optional
+	PolicyServer string `json:"policyServer,omitempty"`
+}
+
+// PolicyModuleStatus defines the observed state of PolicyModule.
+type PolicyModuleStatus struct {
+	// ResolvedModule is the module served to the Policy Servers.
+	// +optional
+	ResolvedModule string `json:"resolvedModule,omitempty"`
+
+	// Digest is the digest the module is pinned to, if any.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// Consumers are the policies and the policy groups using the module.
+	// +optional
+	Consumers []PolicyModuleConsumer `json:"consumers,omitempty"`
+
+	// Conditions represent the observed conditions of the module.
+	// Known .status.conditions.types are: "PolicyModuleSignaturesVerified"
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// PolicyModule is a WASM module shared by many policies
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=pmod
+// +kubebuilder:printcolumn:name="Module",type=string,JSONPath=`.status.resolvedModule`,description="Module served to the Policy Servers"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PolicyModule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicyModuleSpec   `json:"spec,omitempty"`
+	Status PolicyModuleStatus `json:"status,omitempty"`
+}
+
+// PolicyModuleList contains a list of PolicyModule
+// +kubebuilder:object:root=true
+type PolicyModuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyModule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicyModule{}, &PolicyModuleList{})
+}
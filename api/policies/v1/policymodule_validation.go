@@ -0,0 +1,68 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validatePolicyModule checks that the digest is only set on the modules
+// served by an OCI-compatible registry, and that it does not conflict with
+// the digest of the module reference.
+func validatePolicyModule(policyModule *PolicyModule) field.ErrorList {
+	var allErrors field.ErrorList
+
+	if policyModule.Spec.Digest == "" {
+		return allErrors
+	}
+
+	digestPath := field.NewPath("spec").Child("digest")
+	if !isRegistryModule(policyModule.Spec.Module) {
+		return append(allErrors, field.Forbidden(digestPath, "only allowed on modules served by an OCI-compatible registry"))
+	}
+	if _, digest := splitModuleDigest(policyModule.Spec.Module); digest != "" && digest != policyModule.Spec.Digest {
+		allErrors = append(allErrors, field.Invalid(digestPath, policyModule.Spec.Digest,
+			fmt.Sprintf("conflicts with the digest %s of the module", digest)))
+	}
+
+	return allErrors
+}
+
+// validatePolicyModuleDelete checks that the module is no longer referenced.
+func validatePolicyModuleDelete(consumers []Policy) field.ErrorList {
+	var allErrors field.ErrorList
+
+	for _, consumer := range consumers {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("metadata").Child("name"),
+			fmt.Sprintf("the module is referenced by %s %s", PolicyKind(consumer), client.ObjectKeyFromObject(consumer))))
+	}
+
+	return allErrors
+}
+
+// prepareInvalidPolicyModuleAPIError is a shorthand for generating an invalid apierrors.StatusError with data from a PolicyModule.
+func prepareInvalidPolicyModuleAPIError(policyModule *PolicyModule, errorList field.ErrorList) *apierrors.StatusError {
+	return apierrors.NewInvalid(
+		GroupVersion.WithKind(PolicyModuleKind).GroupKind(),
+		policyModule.GetName(),
+		errorList,
+	)
+}
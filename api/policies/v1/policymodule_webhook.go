@@ -0,0 +1,115 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/go-logr/logr"
+)
+
+// SetupWebhookWithManager registers the PolicyModule webhook with the controller manager.
+func (r *PolicyModule) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&policyModuleValidator{
+			k8sReader: mgr.GetAPIReader(),
+			logger:    mgr.GetLogger().WithName("policymodule-webhook"),
+		}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed enrolling webhook with manager: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-policies-kubewarden-io-v1-policymodule,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kubewarden.io,resources=policymodules,verbs=create;update;delete,versions=v1,name=vpolicymodule.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// policyModuleValidator validates PolicyModule objects when they are created, updated, or deleted.
+type policyModuleValidator struct {
+	k8sReader client.Reader
+	logger    logr.Logger
+}
+
+var _ webhook.CustomValidator = &policyModuleValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *policyModuleValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policyModule, ok := obj.(*PolicyModule)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyModule object, got %T", obj)
+	}
+
+	v.logger.Info("Validating PolicyModule creation", "name", policyModule.GetName())
+
+	allErrors := validatePolicyModule(policyModule)
+	if len(allErrors) != 0 {
+		return nil, prepareInvalidPolicyModuleAPIError(policyModule, allErrors)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *policyModuleValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	policyModule, ok := newObj.(*PolicyModule)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyModule object, got %T", newObj)
+	}
+
+	v.logger.Info("Validating PolicyModule update", "name", policyModule.GetName())
+
+	allErrors := validatePolicyModule(policyModule)
+	if len(allErrors) != 0 {
+		return nil, prepareInvalidPolicyModuleAPIError(policyModule, allErrors)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+// The deletion is rejected while the module is referenced by a policy.
+func (v *policyModuleValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policyModule, ok := obj.(*PolicyModule)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyModule object, got %T", obj)
+	}
+
+	v.logger.Info("Validating PolicyModule delete", "name", policyModule.GetName())
+
+	consumers, err := ListPolicyModuleConsumers(ctx, v.k8sReader, policyModule.GetName())
+	if err != nil {
+		return nil, errors.Join(errors.New("cannot list the consumers of the PolicyModule"), err)
+	}
+	allErrors := validatePolicyModuleDelete(consumers)
+	if len(allErrors) != 0 {
+		return nil, prepareInvalidPolicyModuleAPIError(policyModule, allErrors)
+	}
+
+	return nil, nil
+}
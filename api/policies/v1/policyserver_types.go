@@ -89,7 +89,9 @@ type PolicyServerSpec struct {
 
 	// Name of VerificationConfig configmap in the same namespace, containing
 	// Sigstore verification configuration. The configuration must be under a
-	// key named verification-config in the Configmap.
+	// key named verification-config in the Configmap. It takes precedence
+	// over the verification configuration generated from the signatures of
+	// the PolicyModules.
 	// +optional
 	VerificationConfig string `json:"verificationConfig,omitempty"`
 
@@ -0,0 +1,186 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MergeSettings merges the settings of a binding on top of the default
+// settings of a template: the objects are merged recursively, and any other
+// value of the overrides replaces the default one.
+func MergeSettings(defaults, overrides runtime.RawExtension) (runtime.RawExtension, error) {
+	if len(overrides.Raw) == 0 {
+		return *defaults.DeepCopy(), nil
+	}
+	if len(defaults.Raw) == 0 {
+		return *overrides.DeepCopy(), nil
+	}
+
+	var defaultValues, overrideValues interface{}
+	if err := json.Unmarshal(defaults.Raw, &defaultValues); err != nil {
+		return runtime.RawExtension{}, errors.Join(errors.New("cannot decode the default settings"), err)
+	}
+	if err := json.Unmarshal(overrides.Raw, &overrideValues); err != nil {
+		return runtime.RawExtension{}, errors.Join(errors.New("cannot decode the settings"), err)
+	}
+
+	merged, err := json.Marshal(mergeSettingsValues(defaultValues, overrideValues))
+	if err != nil {
+		return runtime.RawExtension{}, errors.Join(errors.New("cannot encode the merged settings"), err)
+	}
+
+	return runtime.RawExtension{Raw: merged}, nil
+}
+
+func mergeSettingsValues(defaults, overrides interface{}) interface{} {
+	defaultObject, defaultIsObject := defaults.(map[string]interface{})
+	overrideObject, overrideIsObject := overrides.(map[string]interface{})
+	if !defaultIsObject || !overrideIsObject {
+		return overrides
+	}
+
+	merged := make(map[string]interface{}, len(defaultObject)+len(overrideObject))
+	for key, value := range defaultObject {
+		merged[key] = value
+	}
+	for key, value := range overrideObject {
+		merged[key] = mergeSettingsValues(defaultObject[key], value)
+	}
+
+	return merged
+}
+
+// settingsSchemaValidator returns the validator of the settings schema of the
+// template, or nil when the template has no schema.
+func (r *PolicyTemplate) settingsSchemaValidator() (validation.SchemaValidator, error) {
+	if len(r.Spec.SettingsSchema.Raw) == 0 || string(r.Spec.SettingsSchema.Raw) == "null" {
+		return nil, nil
+	}
+
+	var schema apiextensionsv1.JSONSchemaProps
+	if err := json.Unmarshal(r.Spec.SettingsSchema.Raw, &schema); err != nil {
+		return nil, errors.Join(errors.New("cannot decode the settings schema"), err)
+	}
+	var internalSchema apiextensions.JSONSchemaProps
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&schema, &internalSchema, nil); err != nil {
+		return nil, errors.Join(errors.New("cannot convert the settings schema"), err)
+	}
+	validator, _, err := validation.NewSchemaValidator(&internalSchema)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid settings schema"), err)
+	}
+
+	return validator, nil
+}
+
+// ValidateSettings validates the given settings against the settings schema
+// of the template.
+func (r *PolicyTemplate) ValidateSettings(settings runtime.RawExtension, fldPath *field.Path) field.ErrorList {
+	validator, err := r.settingsSchemaValidator()
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, string(r.Spec.SettingsSchema.Raw), err.Error())}
+	}
+	if validator == nil {
+		return nil
+	}
+
+	var values interface{} = map[string]interface{}{}
+	if len(settings.Raw) != 0 {
+		if err = json.Unmarshal(settings.Raw, &values); err != nil {
+			return field.ErrorList{field.Invalid(fldPath, string(settings.Raw), err.Error())}
+		}
+	}
+
+	return validation.ValidateCustomResource(fldPath, values, validator)
+}
+
+// Instantiate expands the binding into the policy it defines: an
+// AdmissionPolicy for a PolicyBinding, and a ClusterAdmissionPolicy for a
+// ClusterPolicyBinding. The settings of the binding are merged on top of the
+// default settings of the template, and validated against its schema.
+func (r *PolicyTemplate) Instantiate(binding Binding) (Policy, error) {
+	spec := binding.GetBindingSpec()
+	settings, err := MergeSettings(r.Spec.Settings, spec.Settings)
+	if err != nil {
+		return nil, err
+	}
+	if errs := r.ValidateSettings(settings, field.NewPath("spec").Child("settings")); len(errs) != 0 {
+		return nil, errs.ToAggregate()
+	}
+
+	policySpec := PolicySpec{
+		PolicyServer:   spec.PolicyServer,
+		Mode:           spec.Mode,
+		Module:         r.Spec.Module,
+		Settings:       settings,
+		Rules:          r.Spec.Rules,
+		Mutating:       r.Spec.Mutating,
+		ObjectSelector: spec.ObjectSelector,
+	}
+
+	switch binding.(type) {
+	case *PolicyBinding:
+		policy := &AdmissionPolicy{Spec: AdmissionPolicySpec{PolicySpec: policySpec}}
+		policy.SetName(binding.GetName())
+		policy.SetNamespace(binding.GetNamespace())
+		return policy, nil
+	case *ClusterPolicyBinding:
+		policy := &ClusterAdmissionPolicy{Spec: ClusterAdmissionPolicySpec{PolicySpec: policySpec, NamespaceSelector: spec.NamespaceSelector}}
+		policy.SetName(binding.GetName())
+		return policy, nil
+	default:
+		return nil, fmt.Errorf("unknown binding kind %s", binding.GetKind())
+	}
+}
+
+// ListTemplateBindings returns the PolicyBindings and ClusterPolicyBindings
+// referencing the given template.
+func ListTemplateBindings(ctx context.Context, k8sReader client.Reader, templateName string) ([]Binding, error) {
+	var policyBindings PolicyBindingList
+	if err := k8sReader.List(ctx, &policyBindings); err != nil {
+		return nil, errors.Join(errors.New("cannot list PolicyBindings"), err)
+	}
+	var clusterPolicyBindings ClusterPolicyBindingList
+	if err := k8sReader.List(ctx, &clusterPolicyBindings); err != nil {
+		return nil, errors.Join(errors.New("cannot list ClusterPolicyBindings"), err)
+	}
+
+	bindings := []Binding{}
+	for i := range policyBindings.Items {
+		if policyBindings.Items[i].Spec.TemplateRef == templateName {
+			bindings = append(bindings, &policyBindings.Items[i])
+		}
+	}
+	for i := range clusterPolicyBindings.Items {
+		if clusterPolicyBindings.Items[i].Spec.TemplateRef == templateName {
+			bindings = append(bindings, &clusterPolicyBindings.Items[i])
+		}
+	}
+
+	return bindings, nil
+}
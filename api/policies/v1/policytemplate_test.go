@@ -0,0 +1,216 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func newTestPolicyTemplate() *PolicyTemplate {
+	return &PolicyTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-registries"},
+		Spec: PolicyTemplateSpec{
+			Module: "registry://ghcr.io/kubewarden/policies/trusted-repos:v0.2.0",
+			Rules: []admissionregistrationv1.RuleWithOperations{
+				{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{""},
+						APIVersions: []string{"v1"},
+						Resources:   []string{"pods"},
+					},
+				},
+			},
+			Settings: runtime.RawExtension{Raw: []byte(`{"registries": {"reject": ["docker.io"]}, "strict": false}`)},
+			SettingsSchema: runtime.RawExtension{Raw: []byte(`{
+				"type": "object",
+				"properties": {
+					"registries": {"type": "object", "properties": {"allow": {"type": "array", "items": {"type": "string"}}, "reject": {"type": "array", "items": {"type": "string"}}}},
+					"strict": {"type": "boolean"}
+				}
+			}`)},
+		},
+	}
+}
+
+func TestMergeSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaults  string
+		overrides string
+		expected  string
+	}{
+		{"no overrides", `{"a": 1}`, "", `{"a": 1}`},
+		{"no defaults", "", `{"a": 1}`, `{"a": 1}`},
+		{"objects are merged recursively", `{"a": {"b": 1, "c": 2}, "d": 3}`, `{"a": {"b": 10}}`, `{"a": {"b": 10, "c": 2}, "d": 3}`},
+		{"lists are replaced", `{"a": [1, 2]}`, `{"a": [3]}`, `{"a": [3]}`},
+		{"null values are kept", `{"a": 1}`, `{"a": null}`, `{"a": null}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := MergeSettings(runtime.RawExtension{Raw: []byte(test.defaults)}, runtime.RawExtension{Raw: []byte(test.overrides)})
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(merged.Raw))
+		})
+	}
+}
+
+func TestPolicyTemplateValidateSettings(t *testing.T) {
+	template := newTestPolicyTemplate()
+	settingsPath := field.NewPath("spec").Child("settings")
+
+	assert.Empty(t, template.ValidateSettings(runtime.RawExtension{Raw: []byte(`{"strict": true}`)}, settingsPath))
+	assert.Len(t, template.ValidateSettings(runtime.RawExtension{Raw: []byte(`{"strict": "yes"}`)}, settingsPath), 1)
+
+	template.Spec.SettingsSchema = runtime.RawExtension{}
+	assert.Empty(t, template.ValidateSettings(runtime.RawExtension{Raw: []byte(`{"strict": "yes"}`)}, settingsPath))
+}
+
+func TestPolicyTemplateInstantiate(t *testing.T) {
+	template := newTestPolicyTemplate()
+	namespaceSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+
+	policy, err := template.Instantiate(&ClusterPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "registries"},
+		Spec: PolicyBindingSpec{
+			TemplateRef:       template.GetName(),
+			Mode:              PolicyModeMonitor,
+			Settings:          runtime.RawExtension{Raw: []byte(`{"registries": {"allow": ["registry.example.com"]}}`)},
+			NamespaceSelector: namespaceSelector,
+		},
+	})
+	require.NoError(t, err)
+	clusterAdmissionPolicy, ok := policy.(*ClusterAdmissionPolicy)
+	require.True(t, ok)
+	assert.Equal(t, "registries", clusterAdmissionPolicy.GetName())
+	assert.Equal(t, template.Spec.Module, clusterAdmissionPolicy.GetModule())
+	assert.Equal(t, PolicyModeMonitor, clusterAdmissionPolicy.GetPolicyMode())
+	assert.Equal(t, namespaceSelector, clusterAdmissionPolicy.Spec.NamespaceSelector)
+	assert.JSONEq(t, `{"registries": {"allow": ["registry.example.com"], "reject": ["docker.io"]}, "strict": false}`,
+		string(clusterAdmissionPolicy.GetSettings().Raw))
+
+	policy, err = template.Instantiate(&PolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "registries", Namespace: "team-a"}})
+	require.NoError(t, err)
+	assert.IsType(t, &AdmissionPolicy{}, policy)
+	assert.Equal(t, "team-a", policy.GetNamespace())
+
+	_, err = template.Instantiate(&PolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "registries", Namespace: "team-a"},
+		Spec:       PolicyBindingSpec{Settings: runtime.RawExtension{Raw: []byte(`{"strict": "yes"}`)}},
+	})
+	require.Error(t, err)
+}
+
+func TestValidatePolicyTemplate(t *testing.T) {
+	template := newTestPolicyTemplate()
+	assert.Empty(t, validatePolicyTemplate(template))
+
+	template.Spec.SettingsSchema = runtime.RawExtension{Raw: []byte(`{"type": 1}`)}
+	assert.Len(t, validatePolicyTemplate(template), 1)
+
+	template = newTestPolicyTemplate()
+	template.Spec.Rules = nil
+	assert.Len(t, validatePolicyTemplate(template), 1)
+}
+
+func TestValidateBinding(t *testing.T) {
+	template := newTestPolicyTemplate()
+	namespaceSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+
+	tests := []struct {
+		name           string
+		binding        Binding
+		template       *PolicyTemplate
+		expectedErrors int
+	}{
+		{
+			"valid binding",
+			&PolicyBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "registries", Namespace: "team-a"},
+				Spec:       PolicyBindingSpec{TemplateRef: template.GetName(), Settings: runtime.RawExtension{Raw: []byte(`{"strict": true}`)}},
+			},
+			template,
+			0,
+		},
+		{
+			"binding of a missing template",
+			&PolicyBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "registries", Namespace: "team-a"},
+				Spec:       PolicyBindingSpec{TemplateRef: "missing", Settings: runtime.RawExtension{Raw: []byte(`{"strict": "yes"}`)}},
+			},
+			nil,
+			0,
+		},
+		{
+			"settings not conforming to the schema",
+			&PolicyBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "registries", Namespace: "team-a"},
+				Spec:       PolicyBindingSpec{TemplateRef: template.GetName(), Settings: runtime.RawExtension{Raw: []byte(`{"strict": "yes"}`)}},
+			},
+			template,
+			1,
+		},
+		{
+			"namespace selector on a PolicyBinding",
+			&PolicyBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "registries", Namespace: "team-a"},
+				Spec:       PolicyBindingSpec{TemplateRef: template.GetName(), NamespaceSelector: namespaceSelector},
+			},
+			template,
+			1,
+		},
+		{
+			"namespace selector on a ClusterPolicyBinding",
+			&ClusterPolicyBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "registries"},
+				Spec:       PolicyBindingSpec{TemplateRef: template.GetName(), NamespaceSelector: namespaceSelector},
+			},
+			template,
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Len(t, validateBindingCreate(test.binding, test.template, SensitiveResources{}), test.expectedErrors)
+		})
+	}
+}
+
+func TestValidateBindingUpdate(t *testing.T) {
+	template := newTestPolicyTemplate()
+	oldBinding := &ClusterPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "registries"},
+		Spec:       PolicyBindingSpec{TemplateRef: template.GetName(), PolicyServer: "default", Mode: PolicyModeProtect},
+	}
+
+	newBinding := oldBinding.DeepCopy()
+	newBinding.Spec.Settings = runtime.RawExtension{Raw: []byte(`{"strict": true}`)}
+	assert.Empty(t, validateBindingUpdate(oldBinding, newBinding, template, SensitiveResources{}))
+
+	newBinding = oldBinding.DeepCopy()
+	newBinding.Spec.PolicyServer = "other"
+	newBinding.Spec.Mode = PolicyModeMonitor
+	assert.Len(t, validateBindingUpdate(oldBinding, newBinding, template, SensitiveResources{}), 2)
+}
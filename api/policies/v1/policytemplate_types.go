@@ -0,0 +1,241 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	PolicyTemplateKind       = "PolicyTemplate"
+	PolicyBindingKind        = "PolicyBinding"
+	ClusterPolicyBindingKind = "ClusterPolicyBinding"
+
+	// PolicyBindingReady represents the condition of the expansion of a
+	// binding into its policy.
+	PolicyBindingReady PolicyConditionType = "PolicyBindingReady"
+)
+
+// PolicyTemplateSpec defines a policy that is instantiated by the
+// PolicyBindings and ClusterPolicyBindings referencing the template, each one
+// with its own settings.
+type PolicyTemplateSpec struct {
+	// Module is the location of the WASM module to be loaded. Can be a
+	// local file (file://), a remote file served by an HTTP server
+	// (http://, https://), or an artifact served by an OCI-compatible
+	// registry (registry://).
+	// If prefix is missing, it will default to registry:// and use that
+	// internally.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Module string `json:"module"`
+
+	// Settings are the default settings of the policy. The settings of the
+	// bindings are merged on top of them.
+	// +optional
+	// +nullable
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// x-kubernetes-embedded-resource: false
+	Settings runtime.RawExtension `json:"settings,omitempty"`
+
+	// SettingsSchema is an OpenAPI v3 schema, as used by the
+	// CustomResourceDefinitions, that the settings of every binding must
+	// conform to, once merged with the default settings.
+	// +optional
+	// +nullable
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// x-kubernetes-embedded-resource: false
+	SettingsSchema runtime.RawExtension `json:"settingsSchema,omitempty"`
+
+	// Rules describes what operations on what resources/subresources the webhook cares about.
+	// The webhook cares about an operation if it matches _any_ Rule.
+	Rules []admissionregistrationv1.RuleWithOperations `json:"rules"`
+
+	// Mutating indicates whether a policy has the ability to mutate
+	// incoming requests or not.
+	Mutating bool `json:"mutating"`
+}
+
+// PolicyTemplate is a policy definition shared by many bindings
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=ptpl
+// +kubebuilder:printcolumn:name="Module",type=string,JSONPath=`.spec.module`,description="Policy module"
+// +kubebuilder:printcolumn:name="Mutating",type=boolean,JSONPath=`.spec.mutating`,description="Whether the policy is mutating"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PolicyTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PolicyTemplateSpec `json:"spec,omitempty"`
+}
+
+// PolicyTemplateList contains a list of PolicyTemplate
+// +kubebuilder:object:root=true
+type PolicyTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyTemplate `json:"items"`
+}
+
+// PolicyBindingSpec defines an instance of a PolicyTemplate. The binding is
+// expanded into a policy with the same name: an AdmissionPolicy for a
+// PolicyBinding, and a ClusterAdmissionPolicy for a ClusterPolicyBinding.
+type PolicyBindingSpec struct {
+	// TemplateRef is the name of the PolicyTemplate to instantiate.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	TemplateRef string `json:"templateRef"`
+
+	// PolicyServer identifies an existing PolicyServer resource. It is
+	// defaulted like the policyServer of the policies.
+	// +optional
+	PolicyServer string `json:"policyServer"`
+
+	// Mode defines the execution mode of the policy. Can be set to
+	// "protect", "warn" or "monitor". It is disallowed to transition to a
+	// less strict mode.
+	// +kubebuilder:default:=protect
+	// +optional
+	Mode PolicyMode `json:"mode,omitempty"`
+
+	// Settings are merged on top of the default settings of the template:
+	// the objects are merged recursively, and any other value replaces the
+	// default one.
+	// +optional
+	// +nullable
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// x-kubernetes-embedded-resource: false
+	Settings runtime.RawExtension `json:"settings,omitempty"`
+
+	// NamespaceSelector decides whether to run the policy on an object based
+	// on whether the namespace for that object matches the selector. Only
+	// allowed for the ClusterPolicyBindings.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ObjectSelector decides whether to run the policy based on if the
+	// object has matching labels.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+}
+
+// PolicyBindingStatus defines the observed state of PolicyBinding and
+// ClusterPolicyBinding.
+type PolicyBindingStatus struct {
+	// TemplateGeneration is the generation of the template rolled out to the
+	// policy.
+	// +optional
+	TemplateGeneration int64 `json:"templateGeneration,omitempty"`
+
+	// Conditions represent the observed conditions of the binding.
+	// Known .status.conditions.types are: "PolicyBindingReady"
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// PolicyBinding instantiates a PolicyTemplate in its namespace
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=pb
+// +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.templateRef`,description="Instantiated PolicyTemplate"
+// +kubebuilder:printcolumn:name="Policy Server",type=string,JSONPath=`.spec.policyServer`,description="Bound to Policy Server"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`,description="Policy deployment mode"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PolicyBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicyBindingSpec   `json:"spec,omitempty"`
+	Status PolicyBindingStatus `json:"status,omitempty"`
+}
+
+// PolicyBindingList contains a list of PolicyBinding
+// +kubebuilder:object:root=true
+type PolicyBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyBinding `json:"items"`
+}
+
+// ClusterPolicyBinding instantiates a PolicyTemplate for the whole cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cpb
+// +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.templateRef`,description="Instantiated PolicyTemplate"
+// +kubebuilder:printcolumn:name="Policy Server",type=string,JSONPath=`.spec.policyServer`,description="Bound to Policy Server"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`,description="Policy deployment mode"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterPolicyBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicyBindingSpec   `json:"spec,omitempty"`
+	Status PolicyBindingStatus `json:"status,omitempty"`
+}
+
+// ClusterPolicyBindingList contains a list of ClusterPolicyBinding
+// +kubebuilder:object:root=true
+type ClusterPolicyBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPolicyBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicyTemplate{}, &PolicyTemplateList{})
+	SchemeBuilder.Register(&PolicyBinding{}, &PolicyBindingList{})
+	SchemeBuilder.Register(&ClusterPolicyBinding{}, &ClusterPolicyBindingList{})
+}
+
+// +kubebuilder:object:generate:=false
+type Binding interface {
+	client.Object
+	GetKind() string
+	GetBindingSpec() *PolicyBindingSpec
+	GetBindingStatus() *PolicyBindingStatus
+}
+
+func (r *PolicyBinding) GetKind() string {
+	return PolicyBindingKind
+}
+
+func (r *PolicyBinding) GetBindingSpec() *PolicyBindingSpec {
+	return &r.Spec
+}
+
+func (r *PolicyBinding) GetBindingStatus() *PolicyBindingStatus {
+	return &r.Status
+}
+
+func (r *ClusterPolicyBinding) GetKind() string {
+	return ClusterPolicyBindingKind
+}
+
+func (r *ClusterPolicyBinding) GetBindingSpec() *PolicyBindingSpec {
+	return &r.Spec
+}
+
+func (r *ClusterPolicyBinding) GetBindingStatus() *PolicyBindingStatus {
+	return &r.Status
+}
@@ -0,0 +1,123 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validatePolicyTemplate(template *PolicyTemplate) field.ErrorList {
+	var allErrors field.ErrorList
+
+	if _, err := template.settingsSchemaValidator(); err != nil {
+		allErrors = append(allErrors, field.Invalid(field.NewPath("spec").Child("settingsSchema"), string(template.Spec.SettingsSchema.Raw), err.Error()))
+	}
+
+	// The sensitive resources are checked against the policies instantiated by
+	// the bindings, which are either namespaced or cluster-wide.
+	policy := &ClusterAdmissionPolicy{Spec: ClusterAdmissionPolicySpec{PolicySpec: PolicySpec{Rules: template.Spec.Rules}}}
+	allErrors = append(allErrors, validateRulesField(policy, SensitiveResources{})...)
+
+	return allErrors
+}
+
+// validateBindingCreate validates the binding and, when its template exists,
+// the policy it is expanded into.
+func validateBindingCreate(binding Binding, template *PolicyTemplate, sensitiveResources SensitiveResources) field.ErrorList {
+	var allErrors field.ErrorList
+
+	allErrors = append(allErrors, validateBindingSpec(binding)...)
+	if template != nil {
+		allErrors = append(allErrors, validateBindingInstance(binding, template, sensitiveResources)...)
+	}
+
+	return allErrors
+}
+
+func validateBindingUpdate(oldBinding, newBinding Binding, template *PolicyTemplate, sensitiveResources SensitiveResources) field.ErrorList {
+	allErrors := validateBindingCreate(newBinding, template, sensitiveResources)
+
+	specPath := field.NewPath("spec")
+	oldSpec, newSpec := oldBinding.GetBindingSpec(), newBinding.GetBindingSpec()
+	if oldSpec.PolicyServer != newSpec.PolicyServer {
+		allErrors = append(allErrors, field.Forbidden(specPath.Child("policyServer"), "the field is immutable"))
+	}
+	if oldSpec.Mode.IsStricterThan(newSpec.Mode) {
+		allErrors = append(allErrors, field.Forbidden(specPath.Child("mode"),
+			fmt.Sprintf("field cannot transition from %s to %s. Recreate instead.", oldSpec.Mode, newSpec.Mode)))
+	}
+
+	return allErrors
+}
+
+func validateBindingSpec(binding Binding) field.ErrorList {
+	var allErrors field.ErrorList
+	spec := binding.GetBindingSpec()
+	specPath := field.NewPath("spec")
+
+	if spec.TemplateRef == "" {
+		allErrors = append(allErrors, field.Required(specPath.Child("templateRef"), "a PolicyTemplate must be referenced"))
+	}
+
+	labelSelectorValidationOptions := metav1validation.LabelSelectorValidationOptions{}
+	if spec.NamespaceSelector != nil {
+		if binding.GetNamespace() != "" {
+			allErrors = append(allErrors, field.Forbidden(specPath.Child("namespaceSelector"), "a PolicyBinding only applies to its own namespace"))
+		} else {
+			allErrors = append(allErrors, metav1validation.ValidateLabelSelector(spec.NamespaceSelector, labelSelectorValidationOptions, specPath.Child("namespaceSelector"))...)
+		}
+	}
+	if spec.ObjectSelector != nil {
+		allErrors = append(allErrors, metav1validation.ValidateLabelSelector(spec.ObjectSelector, labelSelectorValidationOptions, specPath.Child("objectSelector"))...)
+	}
+
+	return allErrors
+}
+
+// validateBindingInstance validates the settings of the binding against the
+// schema of the template, and the policy the binding is expanded into.
+func validateBindingInstance(binding Binding, template *PolicyTemplate, sensitiveResources SensitiveResources) field.ErrorList {
+	settingsPath := field.NewPath("spec").Child("settings")
+
+	settings, err := MergeSettings(template.Spec.Settings, binding.GetBindingSpec().Settings)
+	if err != nil {
+		return field.ErrorList{field.Invalid(settingsPath, string(binding.GetBindingSpec().Settings.Raw), err.Error())}
+	}
+	if allErrors := template.ValidateSettings(settings, settingsPath); len(allErrors) != 0 {
+		return allErrors
+	}
+
+	policy, err := template.Instantiate(binding)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+
+	return validateRulesField(policy, sensitiveResources)
+}
+
+// prepareInvalidBindingAPIError is a shorthand for generating an invalid apierrors.StatusError with data from a binding.
+func prepareInvalidBindingAPIError(binding Binding, errorList field.ErrorList) *apierrors.StatusError {
+	return apierrors.NewInvalid(
+		GroupVersion.WithKind(binding.GetKind()).GroupKind(),
+		binding.GetName(),
+		errorList,
+	)
+}
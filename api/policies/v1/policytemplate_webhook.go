@@ -0,0 +1,248 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/go-logr/logr"
+)
+
+// SetupWebhookWithManager registers the PolicyTemplate webhook with the controller manager.
+func (r *PolicyTemplate) SetupWebhookWithManager(mgr ctrl.Manager, sensitiveResources SensitiveResources) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&policyTemplateValidator{
+			k8sReader:          mgr.GetAPIReader(),
+			sensitiveResources: sensitiveResources,
+			logger:             mgr.GetLogger().WithName("policytemplate-webhook"),
+		}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed enrolling webhook with manager: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWebhookWithManager registers the PolicyBinding webhook with the controller manager.
+func (r *PolicyBinding) SetupWebhookWithManager(mgr ctrl.Manager, sensitiveResources SensitiveResources) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&bindingValidator{
+			k8sReader:          mgr.GetAPIReader(),
+			sensitiveResources: sensitiveResources,
+			logger:             mgr.GetLogger().WithName("policybinding-webhook"),
+		}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed enrolling webhook with manager: %w", err)
+	}
+
+	return nil
+}
+
+// SetupWebhookWithManager registers the ClusterPolicyBinding webhook with the controller manager.
+func (r *ClusterPolicyBinding) SetupWebhookWithManager(mgr ctrl.Manager, sensitiveResources SensitiveResources) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&bindingValidator{
+			k8sReader:          mgr.GetAPIReader(),
+			sensitiveResources: sensitiveResources,
+			logger:             mgr.GetLogger().WithName("clusterpolicybinding-webhook"),
+		}).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("failed enrolling webhook with manager: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-policies-kubewarden-io-v1-policytemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kubewarden.io,resources=policytemplates,verbs=create;update,versions=v1,name=vpolicytemplate.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// policyTemplateValidator validates PolicyTemplate objects when they are created, updated, or deleted.
+type policyTemplateValidator struct {
+	k8sReader          client.Reader
+	sensitiveResources SensitiveResources
+	logger             logr.Logger
+}
+
+var _ webhook.CustomValidator = &policyTemplateValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *policyTemplateValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(*PolicyTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyTemplate object, got %T", obj)
+	}
+
+	v.logger.Info("Validating PolicyTemplate creation", "name", template.GetName())
+
+	allErrors := validatePolicyTemplate(template)
+	if len(allErrors) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind(PolicyTemplateKind).GroupKind(), template.GetName(), allErrors)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+// The update is rolled out to every binding of the template: the bindings
+// which can no longer be expanded are reported as warnings.
+func (v *policyTemplateValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	template, ok := newObj.(*PolicyTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyTemplate object, got %T", newObj)
+	}
+
+	v.logger.Info("Validating PolicyTemplate update", "name", template.GetName())
+
+	allErrors := validatePolicyTemplate(template)
+	if len(allErrors) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind(PolicyTemplateKind).GroupKind(), template.GetName(), allErrors)
+	}
+
+	bindings, err := ListTemplateBindings(ctx, v.k8sReader, template.GetName())
+	if err != nil {
+		v.logger.Error(err, "cannot list the bindings of the PolicyTemplate", "name", template.GetName())
+		return nil, nil
+	}
+
+	var warnings admission.Warnings
+	for _, binding := range bindings {
+		if bindingErrors := validateBindingInstance(binding, template, v.sensitiveResources); len(bindingErrors) != 0 {
+			warnings = append(warnings, fmt.Sprintf("%s %s cannot be expanded with the updated template: %s",
+				binding.GetKind(), client.ObjectKeyFromObject(binding), bindingErrors.ToAggregate()))
+		}
+	}
+
+	return warnings, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *policyTemplateValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(*PolicyTemplate)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyTemplate object, got %T", obj)
+	}
+
+	v.logger.Info("Validating PolicyTemplate delete", "name", template.GetName())
+
+	return nil, nil
+}
+
+//+kubebuilder:webhook:path=/validate-policies-kubewarden-io-v1-policybinding,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kubewarden.io,resources=policybindings,verbs=create;update,versions=v1,name=vpolicybinding.kb.io,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/validate-policies-kubewarden-io-v1-clusterpolicybinding,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kubewarden.io,resources=clusterpolicybindings,verbs=create;update,versions=v1,name=vclusterpolicybinding.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// bindingValidator validates PolicyBinding and ClusterPolicyBinding objects when they are created, updated, or deleted.
+type bindingValidator struct {
+	k8sReader          client.Reader
+	sensitiveResources SensitiveResources
+	logger             logr.Logger
+}
+
+var _ webhook.CustomValidator = &bindingValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *bindingValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	binding, ok := obj.(Binding)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyBinding or ClusterPolicyBinding object, got %T", obj)
+	}
+
+	v.logger.Info("Validating binding creation", "kind", binding.GetKind(), "name", binding.GetName(), "namespace", binding.GetNamespace())
+
+	template, warnings, err := v.getTemplate(ctx, binding)
+	if err != nil {
+		return nil, err
+	}
+
+	allErrors := validateBindingCreate(binding, template, v.sensitiveResources)
+	if len(allErrors) != 0 {
+		return nil, prepareInvalidBindingAPIError(binding, allErrors)
+	}
+
+	return warnings, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *bindingValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldBinding, ok := oldObj.(Binding)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyBinding or ClusterPolicyBinding object, got %T", oldObj)
+	}
+	newBinding, ok := newObj.(Binding)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyBinding or ClusterPolicyBinding object, got %T", newObj)
+	}
+
+	v.logger.Info("Validating binding update", "kind", newBinding.GetKind(), "name", newBinding.GetName(), "namespace", newBinding.GetNamespace())
+
+	template, warnings, err := v.getTemplate(ctx, newBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	allErrors := validateBindingUpdate(oldBinding, newBinding, template, v.sensitiveResources)
+	if len(allErrors) != 0 {
+		return nil, prepareInvalidBindingAPIError(newBinding, allErrors)
+	}
+
+	return warnings, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *bindingValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	binding, ok := obj.(Binding)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyBinding or ClusterPolicyBinding object, got %T", obj)
+	}
+
+	v.logger.Info("Validating binding delete", "kind", binding.GetKind(), "name", binding.GetName(), "namespace", binding.GetNamespace())
+
+	return nil, nil
+}
+
+// getTemplate returns the template referenced by the binding. A missing
+// template is not an error, the binding is expanded once it is created.
+func (v *bindingValidator) getTemplate(ctx context.Context, binding Binding) (*PolicyTemplate, admission.Warnings, error) {
+	templateRef := binding.GetBindingSpec().TemplateRef
+	if templateRef == "" || v.k8sReader == nil {
+		return nil, nil, nil
+	}
+
+	template := &PolicyTemplate{}
+	err := v.k8sReader.Get(ctx, types.NamespacedName{Name: templateRef}, template)
+	if apierrors.IsNotFound(err) {
+		return nil, admission.Warnings{fmt.Sprintf("PolicyTemplate %s not found, the binding is expanded once it is created", templateRef)}, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get PolicyTemplate %s: %w", templateRef, err)
+	}
+
+	return template, nil, nil
+}
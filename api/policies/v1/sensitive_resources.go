@@ -0,0 +1,186 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DefaultNamespacedSensitiveResources are the resources that cannot be
+	// targeted by AdmissionPolicies and AdmissionPolicyGroups by default:
+	// the PolicyReports, the Kubewarden resources, the CRDs and the Secrets
+	// of the kube-system Namespace.
+	DefaultNamespacedSensitiveResources = "policyreports.wgpolicyk8s.io,*.policies.kubewarden.io,customresourcedefinitions.apiextensions.k8s.io,kube-system/secrets"
+	// DefaultClusterSensitiveResources are the resources that cannot be
+	// targeted by ClusterAdmissionPolicies and ClusterAdmissionPolicyGroups by
+	// default.
+	DefaultClusterSensitiveResources = ""
+
+	namespaceNameLabelKey = "kubernetes.io/metadata.name"
+)
+
+// SensitiveResource is a resource that cannot be targeted by the rules of a
+// policy. When Namespace is set, only the resources of that Namespace are
+// protected.
+// +kubebuilder:object:generate:=false
+type SensitiveResource struct {
+	APIGroup  string
+	Resource  string
+	Namespace string
+}
+
+func (sr SensitiveResource) String() string {
+	if sr.Namespace != "" {
+		return fmt.Sprintf("APIGroup: %s, Resource: %s, Namespace: %s", sr.APIGroup, sr.Resource, sr.Namespace)
+	}
+
+	return fmt.Sprintf("APIGroup: %s, Resource: %s", sr.APIGroup, sr.Resource)
+}
+
+// MatchesRules returns true when the given rule API groups and resources
+// target the sensitive resource.
+func (sr SensitiveResource) MatchesRules(apiGroups []string, resource []string) bool {
+	apiGroupMatches := false
+	for _, apiGroup := range apiGroups {
+		if apiGroup == sr.APIGroup || apiGroup == "*" {
+			apiGroupMatches = true
+			break
+		}
+	}
+
+	resourceMatches := false
+	for _, res := range resource {
+		if sr.Resource == "*" || res == sr.Resource || res == "*" || res == "*/*" || strings.HasPrefix(res, sr.Resource+"/") {
+			resourceMatches = true
+			break
+		}
+	}
+
+	return apiGroupMatches && resourceMatches
+}
+
+// MatchesNamespace returns true when the sensitive resource is protected in
+// the Namespace of the given policy. The Namespace of a cluster-wide policy
+// is matched unless it is excluded by the policy namespaceSelector.
+func (sr SensitiveResource) MatchesNamespace(policy Policy) bool {
+	if sr.Namespace == "" {
+		return true
+	}
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() == sr.Namespace
+	}
+
+	return !namespaceSelectorExcludes(policy.GetNamespaceSelector(), sr.Namespace)
+}
+
+// namespaceSelectorExcludes returns true when the selector explicitly excludes
+// the given Namespace by its name.
+func namespaceSelectorExcludes(selector *metav1.LabelSelector, namespace string) bool {
+	if selector == nil {
+		return false
+	}
+	if name, ok := selector.MatchLabels[namespaceNameLabelKey]; ok && name != namespace {
+		return true
+	}
+	for _, expression := range selector.MatchExpressions {
+		if expression.Key != namespaceNameLabelKey {
+			continue
+		}
+		switch expression.Operator {
+		case metav1.LabelSelectorOpIn:
+			if !slices.Contains(expression.Values, namespace) {
+				return true
+			}
+		case metav1.LabelSelectorOpNotIn:
+			if slices.Contains(expression.Values, namespace) {
+				return true
+			}
+		case metav1.LabelSelectorOpDoesNotExist:
+			return true
+		case metav1.LabelSelectorOpExists:
+		}
+	}
+
+	return false
+}
+
+// SensitiveResources holds the resources that cannot be targeted by the
+// namespaced and the cluster-wide policies.
+// +kubebuilder:object:generate:=false
+type SensitiveResources struct {
+	// Namespaced applies to AdmissionPolicies and AdmissionPolicyGroups.
+	Namespaced []SensitiveResource
+	// Cluster applies to ClusterAdmissionPolicies and ClusterAdmissionPolicyGroups.
+	Cluster []SensitiveResource
+}
+
+// DefaultSensitiveResources returns the sensitive resources protected by default.
+func DefaultSensitiveResources() SensitiveResources {
+	namespaced, err := ParseSensitiveResources(DefaultNamespacedSensitiveResources)
+	if err != nil {
+		panic(err)
+	}
+	cluster, err := ParseSensitiveResources(DefaultClusterSensitiveResources)
+	if err != nil {
+		panic(err)
+	}
+
+	return SensitiveResources{
+		Namespaced: namespaced,
+		Cluster:    cluster,
+	}
+}
+
+// ParseSensitiveResources parses a comma separated list of sensitive
+// resources. Each entry has the form [<namespace>/]<resource>[.<api group>],
+// like "secrets", "kube-system/secrets", "policyreports.wgpolicyk8s.io" or
+// "*.policies.kubewarden.io".
+func ParseSensitiveResources(value string) ([]SensitiveResource, error) {
+	sensitiveResources := []SensitiveResource{}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		var sensitiveResource SensitiveResource
+		groupResource := entry
+		if namespace, rest, found := strings.Cut(entry, "/"); found {
+			if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+				return nil, fmt.Errorf("invalid namespace in sensitive resource %q: %s", entry, strings.Join(errs, ", "))
+			}
+			sensitiveResource.Namespace = namespace
+			groupResource = rest
+		}
+		sensitiveResource.Resource, sensitiveResource.APIGroup, _ = strings.Cut(groupResource, ".")
+		if sensitiveResource.Resource == "" || strings.Contains(groupResource, "/") {
+			return nil, errors.New("invalid sensitive resource: " + entry)
+		}
+
+		sensitiveResources = append(sensitiveResources, sensitiveResource)
+	}
+
+	return sensitiveResources, nil
+}
@@ -0,0 +1,190 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSensitiveResources(t *testing.T) {
+	tests := []struct {
+		name                       string
+		value                      string
+		expectedSensitiveResources []SensitiveResource
+		expectedError              bool
+	}{
+		{
+			"empty list",
+			"",
+			[]SensitiveResource{},
+			false,
+		},
+		{
+			"core resource",
+			"secrets",
+			[]SensitiveResource{{Resource: "secrets"}},
+			false,
+		},
+		{
+			"resources with API groups and Namespaces",
+			"policyreports.wgpolicyk8s.io, *.policies.kubewarden.io,kube-system/secrets",
+			[]SensitiveResource{
+				{APIGroup: "wgpolicyk8s.io", Resource: "policyreports"},
+				{APIGroup: "policies.kubewarden.io", Resource: "*"},
+				{Resource: "secrets", Namespace: "kube-system"},
+			},
+			false,
+		},
+		{
+			"invalid Namespace",
+			"Kube_System/secrets",
+			nil,
+			true,
+		},
+		{
+			"subresource",
+			"kube-system/pods/exec",
+			nil,
+			true,
+		},
+		{
+			"missing resource",
+			".apps",
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sensitiveResources, err := ParseSensitiveResources(test.value)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedSensitiveResources, sensitiveResources)
+		})
+	}
+}
+
+func TestDefaultSensitiveResources(t *testing.T) {
+	require.NotPanics(t, func() { DefaultSensitiveResources() })
+}
+
+func TestClusterSensitiveResources(t *testing.T) {
+	sensitiveResources := SensitiveResources{
+		Cluster: []SensitiveResource{{Resource: "secrets", Namespace: "kube-system"}},
+	}
+	rules := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"secrets"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		namespaceSelector *metav1.LabelSelector
+		expectedError     bool
+	}{
+		{
+			"without namespaceSelector",
+			nil,
+			true,
+		},
+		{
+			"with a namespaceSelector not selecting by name",
+			&metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			true,
+		},
+		{
+			"with a namespaceSelector excluding the Namespace",
+			&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "kubernetes.io/metadata.name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system"}},
+				},
+			},
+			false,
+		},
+		{
+			"with a namespaceSelector selecting another Namespace",
+			&metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "team-a"}},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewClusterAdmissionPolicyFactory().WithRules(rules).Build()
+			policy.Spec.NamespaceSelector = test.namespaceSelector
+
+			allErrors := validateRulesField(policy, sensitiveResources)
+			if test.expectedError {
+				require.ErrorContains(t, prepareInvalidAPIError(policy, allErrors),
+					"spec.rules: Forbidden: {APIGroup: , Resource: secrets, Namespace: kube-system} resources cannot be targeted by ClusterAdmissionPolicy or ClusterAdmissionPolicyGroup")
+			} else {
+				require.Empty(t, allErrors)
+			}
+		})
+	}
+}
+
+func TestSensitiveResourcesOnUpdate(t *testing.T) {
+	sensitiveRules := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"policies.kubewarden.io"},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"admissionpolicies"},
+			},
+		},
+	}
+	sensitiveResources := DefaultSensitiveResources()
+	oldPolicy := NewAdmissionPolicyFactory().WithRules(sensitiveRules).Build()
+
+	t.Run("rules unchanged", func(t *testing.T) {
+		newPolicy := oldPolicy.DeepCopy()
+		newPolicy.Spec.Mode = PolicyModeMonitor
+
+		require.Empty(t, validateRulesField(newPolicy, sensitiveResourcesOnUpdate(oldPolicy, newPolicy, sensitiveResources)))
+	})
+
+	t.Run("policy being deleted", func(t *testing.T) {
+		newPolicy := oldPolicy.DeepCopy()
+		newPolicy.Finalizers = nil
+		newPolicy.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		require.Empty(t, validateRulesField(newPolicy, sensitiveResourcesOnUpdate(oldPolicy, newPolicy, sensitiveResources)))
+	})
+
+	t.Run("rules changed", func(t *testing.T) {
+		newPolicy := oldPolicy.DeepCopy()
+		newPolicy.Spec.Rules[0].Operations = append(newPolicy.Spec.Rules[0].Operations, admissionregistrationv1.Update)
+
+		require.NotEmpty(t, validateRulesField(newPolicy, sensitiveResourcesOnUpdate(oldPolicy, newPolicy, sensitiveResources)))
+	})
+}
@@ -166,6 +166,13 @@ func (in *AdmissionPolicyList) DeepCopyObject() runtime.Object {
 func (in *AdmissionPolicySpec) DeepCopyInto(out *AdmissionPolicySpec) {
 	*out = *in
 	in.PolicySpec.DeepCopyInto(&out.PolicySpec)
+	if in.ContextAwareResources != nil {
+		in, out := &in.ContextAwareResources, &out.ContextAwareResources
+		*out = make([]ContextAwareResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPolicySpec.
@@ -329,7 +336,9 @@ func (in *ClusterAdmissionPolicySpec) DeepCopyInto(out *ClusterAdmissionPolicySp
 	if in.ContextAwareResources != nil {
 		in, out := &in.ContextAwareResources, &out.ContextAwareResources
 		*out = make([]ContextAwareResource, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -343,6 +352,124 @@ func (in *ClusterAdmissionPolicySpec) DeepCopy() *ClusterAdmissionPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPolicyBinding) DeepCopyInto(out *ClusterPolicyBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicyBinding.
+func (in *ClusterPolicyBinding) DeepCopy() *ClusterPolicyBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPolicyBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPolicyBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPolicyBindingList) DeepCopyInto(out *ClusterPolicyBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPolicyBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicyBindingList.
+func (in *ClusterPolicyBindingList) DeepCopy() *ClusterPolicyBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPolicyBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPolicyBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPolicyException) DeepCopyInto(out *ClusterPolicyException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicyException.
+func (in *ClusterPolicyException) DeepCopy() *ClusterPolicyException {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPolicyException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPolicyException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPolicyExceptionList) DeepCopyInto(out *ClusterPolicyExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPolicyException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicyExceptionList.
+func (in *ClusterPolicyExceptionList) DeepCopy() *ClusterPolicyExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPolicyExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPolicyExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPolicyGroupSpec) DeepCopyInto(out *ClusterPolicyGroupSpec) {
 	*out = *in
@@ -369,6 +496,26 @@ func (in *ClusterPolicyGroupSpec) DeepCopy() *ClusterPolicyGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContextAwareResource) DeepCopyInto(out *ContextAwareResource) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextAwareResource.
@@ -384,6 +531,16 @@ func (in *ContextAwareResource) DeepCopy() *ContextAwareResource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
 	*out = *in
+	if in.Enforcement != nil {
+		in, out := &in.Enforcement, &out.Enforcement
+		*out = new(PolicyEnforcement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(PolicyExpiration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Rules != nil {
 		in, out := &in.Rules, &out.Rules
 		*out = make([]admissionregistrationv1.RuleWithOperations, len(*in))
@@ -434,109 +591,143 @@ func (in *GroupSpec) DeepCopy() *GroupSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyGroupMember) DeepCopyInto(out *PolicyGroupMember) {
+func (in *PolicyBinding) DeepCopyInto(out *PolicyBinding) {
 	*out = *in
-	in.Settings.DeepCopyInto(&out.Settings)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMember.
-func (in *PolicyGroupMember) DeepCopy() *PolicyGroupMember {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBinding.
+func (in *PolicyBinding) DeepCopy() *PolicyBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyGroupMember)
+	out := new(PolicyBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyGroupMemberWithContext) DeepCopyInto(out *PolicyGroupMemberWithContext) {
+func (in *PolicyBindingList) DeepCopyInto(out *PolicyBindingList) {
 	*out = *in
-	in.PolicyGroupMember.DeepCopyInto(&out.PolicyGroupMember)
-	if in.ContextAwareResources != nil {
-		in, out := &in.ContextAwareResources, &out.ContextAwareResources
-		*out = make([]ContextAwareResource, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMemberWithContext.
-func (in *PolicyGroupMemberWithContext) DeepCopy() *PolicyGroupMemberWithContext {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBindingList.
+func (in *PolicyBindingList) DeepCopy() *PolicyBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyGroupMemberWithContext)
+	out := new(PolicyBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in PolicyGroupMembers) DeepCopyInto(out *PolicyGroupMembers) {
-	{
-		in := &in
-		*out = make(PolicyGroupMembers, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+func (in *PolicyBindingSpec) DeepCopyInto(out *PolicyBindingSpec) {
+	*out = *in
+	in.Settings.DeepCopyInto(&out.Settings)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMembers.
-func (in PolicyGroupMembers) DeepCopy() PolicyGroupMembers {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBindingSpec.
+func (in *PolicyBindingSpec) DeepCopy() *PolicyBindingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyGroupMembers)
+	out := new(PolicyBindingSpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in PolicyGroupMembersWithContext) DeepCopyInto(out *PolicyGroupMembersWithContext) {
-	{
-		in := &in
-		*out = make(PolicyGroupMembersWithContext, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+func (in *PolicyBindingStatus) DeepCopyInto(out *PolicyBindingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMembersWithContext.
-func (in PolicyGroupMembersWithContext) DeepCopy() PolicyGroupMembersWithContext {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBindingStatus.
+func (in *PolicyBindingStatus) DeepCopy() *PolicyBindingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyGroupMembersWithContext)
+	out := new(PolicyBindingStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyGroupSpec) DeepCopyInto(out *PolicyGroupSpec) {
+func (in *PolicyEnforcement) DeepCopyInto(out *PolicyEnforcement) {
 	*out = *in
-	in.GroupSpec.DeepCopyInto(&out.GroupSpec)
-	if in.Policies != nil {
-		in, out := &in.Policies, &out.Policies
-		*out = make(PolicyGroupMembers, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.MonitorPeriod != nil {
+		in, out := &in.MonitorPeriod, &out.MonitorPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.WarnPeriod != nil {
+		in, out := &in.WarnPeriod, &out.WarnPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ProtectAt != nil {
+		in, out := &in.ProtectAt, &out.ProtectAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupSpec.
-func (in *PolicyGroupSpec) DeepCopy() *PolicyGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyEnforcement.
+func (in *PolicyEnforcement) DeepCopy() *PolicyEnforcement {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyGroupSpec)
+	out := new(PolicyEnforcement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyServer) DeepCopyInto(out *PolicyServer) {
+func (in *PolicyException) DeepCopyInto(out *PolicyException) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -544,18 +735,18 @@ func (in *PolicyServer) DeepCopyInto(out *PolicyServer) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyServer.
-func (in *PolicyServer) DeepCopy() *PolicyServer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyException.
+func (in *PolicyException) DeepCopy() *PolicyException {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyServer)
+	out := new(PolicyException)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PolicyServer) DeepCopyObject() runtime.Object {
+func (in *PolicyException) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -563,31 +754,31 @@ func (in *PolicyServer) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyServerList) DeepCopyInto(out *PolicyServerList) {
+func (in *PolicyExceptionList) DeepCopyInto(out *PolicyExceptionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PolicyServer, len(*in))
+		*out = make([]PolicyException, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyServerList.
-func (in *PolicyServerList) DeepCopy() *PolicyServerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionList.
+func (in *PolicyExceptionList) DeepCopy() *PolicyExceptionList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyServerList)
+	out := new(PolicyExceptionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PolicyServerList) DeepCopyObject() runtime.Object {
+func (in *PolicyExceptionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -595,42 +786,516 @@ func (in *PolicyServerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyServerSecurity) DeepCopyInto(out *PolicyServerSecurity) {
+func (in *PolicyExceptionReference) DeepCopyInto(out *PolicyExceptionReference) {
 	*out = *in
-	if in.Container != nil {
-		in, out := &in.Container, &out.Container
-		*out = new(corev1.SecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Pod != nil {
-		in, out := &in.Pod, &out.Pod
-		*out = new(corev1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyServerSecurity.
-func (in *PolicyServerSecurity) DeepCopy() *PolicyServerSecurity {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionReference.
+func (in *PolicyExceptionReference) DeepCopy() *PolicyExceptionReference {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyServerSecurity)
+	out := new(PolicyExceptionReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyServerSpec) DeepCopyInto(out *PolicyServerSpec) {
+func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
 	*out = *in
-	if in.MinAvailable != nil {
-		in, out := &in.MinAvailable, &out.MinAvailable
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyExceptionTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchConditions != nil {
+		in, out := &in.MatchConditions, &out.MatchConditions
+		*out = make([]admissionregistrationv1.MatchCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionSpec.
+func (in *PolicyExceptionSpec) DeepCopy() *PolicyExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionStatus) DeepCopyInto(out *PolicyExceptionStatus) {
+	*out = *in
+	if in.MatchedNamespaces != nil {
+		in, out := &in.MatchedNamespaces, &out.MatchedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionStatus.
+func (in *PolicyExceptionStatus) DeepCopy() *PolicyExceptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionTarget) DeepCopyInto(out *PolicyExceptionTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionTarget.
+func (in *PolicyExceptionTarget) DeepCopy() *PolicyExceptionTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExpiration) DeepCopyInto(out *PolicyExpiration) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExpiration.
+func (in *PolicyExpiration) DeepCopy() *PolicyExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyGroupMember) DeepCopyInto(out *PolicyGroupMember) {
+	*out = *in
+	in.Settings.DeepCopyInto(&out.Settings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMember.
+func (in *PolicyGroupMember) DeepCopy() *PolicyGroupMember {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyGroupMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyGroupMemberWithContext) DeepCopyInto(out *PolicyGroupMemberWithContext) {
+	*out = *in
+	in.PolicyGroupMember.DeepCopyInto(&out.PolicyGroupMember)
+	if in.ContextAwareResources != nil {
+		in, out := &in.ContextAwareResources, &out.ContextAwareResources
+		*out = make([]ContextAwareResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMemberWithContext.
+func (in *PolicyGroupMemberWithContext) DeepCopy() *PolicyGroupMemberWithContext {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyGroupMemberWithContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PolicyGroupMembers) DeepCopyInto(out *PolicyGroupMembers) {
+	{
+		in := &in
+		*out = make(PolicyGroupMembers, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMembers.
+func (in PolicyGroupMembers) DeepCopy() PolicyGroupMembers {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyGroupMembers)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PolicyGroupMembersWithContext) DeepCopyInto(out *PolicyGroupMembersWithContext) {
+	{
+		in := &in
+		*out = make(PolicyGroupMembersWithContext, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupMembersWithContext.
+func (in PolicyGroupMembersWithContext) DeepCopy() PolicyGroupMembersWithContext {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyGroupMembersWithContext)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyGroupSpec) DeepCopyInto(out *PolicyGroupSpec) {
+	*out = *in
+	in.GroupSpec.DeepCopyInto(&out.GroupSpec)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make(PolicyGroupMembersWithContext, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyGroupSpec.
+func (in *PolicyGroupSpec) DeepCopy() *PolicyGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModeTransition) DeepCopyInto(out *PolicyModeTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModeTransition.
+func (in *PolicyModeTransition) DeepCopy() *PolicyModeTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModeTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModule) DeepCopyInto(out *PolicyModule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModule.
+func (in *PolicyModule) DeepCopy() *PolicyModule {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyModule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModuleConsumer) DeepCopyInto(out *PolicyModuleConsumer) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModuleConsumer.
+func (in *PolicyModuleConsumer) DeepCopy() *PolicyModuleConsumer {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModuleConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModuleKeylessSignature) DeepCopyInto(out *PolicyModuleKeylessSignature) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModuleKeylessSignature.
+func (in *PolicyModuleKeylessSignature) DeepCopy() *PolicyModuleKeylessSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModuleKeylessSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModuleList) DeepCopyInto(out *PolicyModuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyModule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModuleList.
+func (in *PolicyModuleList) DeepCopy() *PolicyModuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyModuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModuleSignatures) DeepCopyInto(out *PolicyModuleSignatures) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = make([]PolicyModuleKeylessSignature, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModuleSignatures.
+func (in *PolicyModuleSignatures) DeepCopy() *PolicyModuleSignatures {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModuleSignatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModuleSpec) DeepCopyInto(out *PolicyModuleSpec) {
+	*out = *in
+	if in.Signatures != nil {
+		in, out := &in.Signatures, &out.Signatures
+		*out = new(PolicyModuleSignatures)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModuleSpec.
+func (in *PolicyModuleSpec) DeepCopy() *PolicyModuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyModuleStatus) DeepCopyInto(out *PolicyModuleStatus) {
+	*out = *in
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]PolicyModuleConsumer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyModuleStatus.
+func (in *PolicyModuleStatus) DeepCopy() *PolicyModuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyModuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyServer) DeepCopyInto(out *PolicyServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyServer.
+func (in *PolicyServer) DeepCopy() *PolicyServer {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyServerList) DeepCopyInto(out *PolicyServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyServerList.
+func (in *PolicyServerList) DeepCopy() *PolicyServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyServerSecurity) DeepCopyInto(out *PolicyServerSecurity) {
+	*out = *in
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyServerSecurity.
+func (in *PolicyServerSecurity) DeepCopy() *PolicyServerSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyServerSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyServerSpec) DeepCopyInto(out *PolicyServerSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
 	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
@@ -727,6 +1392,16 @@ func (in *PolicyServerStatus) DeepCopy() *PolicyServerStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 	*out = *in
+	if in.Enforcement != nil {
+		in, out := &in.Enforcement, &out.Enforcement
+		*out = new(PolicyEnforcement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(PolicyExpiration)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Settings.DeepCopyInto(&out.Settings)
 	if in.Rules != nil {
 		in, out := &in.Rules, &out.Rules
@@ -740,6 +1415,16 @@ func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 		*out = new(admissionregistrationv1.FailurePolicyType)
 		**out = **in
 	}
+	if in.ReinvocationPolicy != nil {
+		in, out := &in.ReinvocationPolicy, &out.ReinvocationPolicy
+		*out = new(admissionregistrationv1.ReinvocationPolicyType)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
 	if in.MatchPolicy != nil {
 		in, out := &in.MatchPolicy, &out.MatchPolicy
 		*out = new(admissionregistrationv1.MatchPolicyType)
@@ -780,6 +1465,23 @@ func (in *PolicySpec) DeepCopy() *PolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	*out = *in
+	if in.EffectiveNamespaceSelector != nil {
+		in, out := &in.EffectiveNamespaceSelector, &out.EffectiveNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exceptions != nil {
+		in, out := &in.Exceptions, &out.Exceptions
+		*out = make([]PolicyExceptionReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextModeTransition != nil {
+		in, out := &in.NextModeTransition, &out.NextModeTransition
+		*out = new(PolicyModeTransition)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -798,3 +1500,85 @@ func (in *PolicyStatus) DeepCopy() *PolicyStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTemplate) DeepCopyInto(out *PolicyTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTemplate.
+func (in *PolicyTemplate) DeepCopy() *PolicyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTemplateList) DeepCopyInto(out *PolicyTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTemplateList.
+func (in *PolicyTemplateList) DeepCopy() *PolicyTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTemplateSpec) DeepCopyInto(out *PolicyTemplateSpec) {
+	*out = *in
+	in.Settings.DeepCopyInto(&out.Settings)
+	in.SettingsSchema.DeepCopyInto(&out.SettingsSchema)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]admissionregistrationv1.RuleWithOperations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTemplateSpec.
+func (in *PolicyTemplateSpec) DeepCopy() *PolicyTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+// ConvertTo converts this ClusterAdmissionPolicy to the Hub version (v1).
+func (r *ClusterAdmissionPolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*policiesv1.ClusterAdmissionPolicy)
+	if !ok {
+		return fmt.Errorf("expected a v1 ClusterAdmissionPolicy object, got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *r.ObjectMeta.DeepCopy()
+
+	data := &policyConversionData{}
+	if err := popConversionData(&dst.ObjectMeta, data); err != nil {
+		return err
+	}
+
+	convertPolicySpecToV1(&r.Spec.PolicySpec, &dst.Spec.PolicySpec, data)
+	dst.Spec.NamespaceSelector = r.Spec.NamespaceSelector.DeepCopy()
+	dst.Spec.ContextAwareResources = data.ContextAwareResources
+	dst.Spec.Backend = data.Backend
+	convertPolicyStatusToV1(&r.Status, &dst.Status, data)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (r *ClusterAdmissionPolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*policiesv1.ClusterAdmissionPolicy)
+	if !ok {
+		return fmt.Errorf("expected a v1 ClusterAdmissionPolicy object, got %T", srcRaw)
+	}
+
+	r.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	data := &policyConversionData{}
+	convertPolicySpecFromV1(&src.Spec.PolicySpec, &r.Spec.PolicySpec, data)
+	r.Spec.NamespaceSelector = src.Spec.NamespaceSelector.DeepCopy()
+	for _, resource := range src.Spec.ContextAwareResources {
+		data.ContextAwareResources = append(data.ContextAwareResources, *resource.DeepCopy())
+	}
+	data.Backend = src.Spec.Backend
+	convertPolicyStatusFromV1(&src.Status, &r.Status, data)
+
+	return setConversionData(&r.ObjectMeta, data)
+}
@@ -0,0 +1,200 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+// policyConversionData holds the v1 policy fields that cannot be
+// represented in v1alpha2. It is stored in the ConversionDataAnnotationKey
+// annotation of the v1alpha2 object, so that converting it back to v1 does
+// not lose any information.
+type policyConversionData struct {
+	// BackgroundAudit is only stored when it differs from the v1 default (true).
+	BackgroundAudit       *bool                                    `json:"backgroundAudit,omitempty"`
+	MatchConditions       []admissionregistrationv1.MatchCondition `json:"matchConditions,omitempty"`
+	ContextAwareResources []policiesv1.ContextAwareResource        `json:"contextAwareResources,omitempty"`
+	Backend               policiesv1.PolicyBackend                 `json:"backend,omitempty"`
+	ModuleRef             string                                   `json:"moduleRef,omitempty"`
+	// Mode and PolicyMode are only stored when the policy is in "warn" mode,
+	// which is converted to the "monitor" mode of v1alpha2: both modes accept
+	// the requests rejected by the policy. They are only restored while the
+	// v1alpha2 mode is still "monitor", so that the mode changes made through
+	// v1alpha2 are preserved.
+	Mode        policiesv1.PolicyMode         `json:"mode,omitempty"`
+	PolicyMode  policiesv1.PolicyModeStatus   `json:"policyMode,omitempty"`
+	Enforcement *policiesv1.PolicyEnforcement `json:"enforcement,omitempty"`
+	Expiration  *policiesv1.PolicyExpiration  `json:"expiration,omitempty"`
+	Suspended   bool                          `json:"suspended,omitempty"`
+	// ReinvocationPolicy and Priority order the mutating policies.
+	ReinvocationPolicy *admissionregistrationv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+	Priority           *int32                                          `json:"priority,omitempty"`
+	// PolicyStatus is only stored when the policy is suspended, which is
+	// converted to the "unscheduled" status of v1alpha2: the webhook of the
+	// policy is not registered in both cases. It is only restored while the
+	// v1alpha2 status is still "unscheduled".
+	PolicyStatus policiesv1.PolicyStatusEnum `json:"policyStatus,omitempty"`
+	// EffectiveNamespaceSelector, Exceptions and NextModeTransition are
+	// stored to preserve the status of the policy.
+	EffectiveNamespaceSelector *metav1.LabelSelector                 `json:"effectiveNamespaceSelector,omitempty"`
+	Exceptions                 []policiesv1.PolicyExceptionReference `json:"exceptions,omitempty"`
+	NextModeTransition         *policiesv1.PolicyModeTransition      `json:"nextModeTransition,omitempty"`
+}
+
+// setConversionData stores data into the conversion annotation of the given
+// object. Nothing is stored when data has no fields set.
+func setConversionData(objectMeta *metav1.ObjectMeta, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("cannot marshal conversion data: %w", err)
+	}
+	if string(raw) == "{}" {
+		return nil
+	}
+
+	if objectMeta.Annotations == nil {
+		objectMeta.Annotations = make(map[string]string)
+	}
+	objectMeta.Annotations[constants.ConversionDataAnnotationKey] = string(raw)
+
+	return nil
+}
+
+// popConversionData loads data from the conversion annotation of the given
+// object, if any, and removes the annotation.
+func popConversionData(objectMeta *metav1.ObjectMeta, data any) error {
+	raw, ok := objectMeta.Annotations[constants.ConversionDataAnnotationKey]
+	if !ok {
+		return nil
+	}
+
+	delete(objectMeta.Annotations, constants.ConversionDataAnnotationKey)
+	if len(objectMeta.Annotations) == 0 {
+		objectMeta.Annotations = nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), data); err != nil {
+		return fmt.Errorf("cannot unmarshal conversion data: %w", err)
+	}
+
+	return nil
+}
+
+func convertPolicySpecToV1(src *PolicySpec, dst *policiesv1.PolicySpec, data *policyConversionData) {
+	in := src.DeepCopy()
+
+	dst.PolicyServer = in.PolicyServer
+	dst.Module = in.Module
+	dst.Mode = policiesv1.PolicyMode(in.Mode)
+	if data.Mode != "" && in.Mode == PolicyMode(policiesv1.PolicyModeMonitor) {
+		dst.Mode = data.Mode
+	}
+	dst.Settings = in.Settings
+	dst.Rules = in.Rules
+	dst.FailurePolicy = in.FailurePolicy
+	dst.Mutating = in.Mutating
+	dst.MatchPolicy = in.MatchPolicy
+	dst.ObjectSelector = in.ObjectSelector
+	dst.SideEffects = in.SideEffects
+	dst.TimeoutSeconds = in.TimeoutSeconds
+
+	dst.BackgroundAudit = true
+	if data.BackgroundAudit != nil {
+		dst.BackgroundAudit = *data.BackgroundAudit
+	}
+	dst.ModuleRef = data.ModuleRef
+	dst.MatchConditions = data.MatchConditions
+	dst.Enforcement = data.Enforcement
+	dst.Expiration = data.Expiration
+	dst.Suspended = data.Suspended
+	dst.ReinvocationPolicy = data.ReinvocationPolicy
+	dst.Priority = data.Priority
+}
+
+func convertPolicySpecFromV1(src *policiesv1.PolicySpec, dst *PolicySpec, data *policyConversionData) {
+	in := src.DeepCopy()
+
+	dst.PolicyServer = in.PolicyServer
+	dst.Module = in.Module
+	dst.Mode = PolicyMode(in.Mode)
+	if in.Mode == policiesv1.PolicyModeWarn {
+		dst.Mode = PolicyMode(policiesv1.PolicyModeMonitor)
+		data.Mode = in.Mode
+	}
+	dst.Settings = in.Settings
+	dst.Rules = in.Rules
+	dst.FailurePolicy = in.FailurePolicy
+	dst.Mutating = in.Mutating
+	dst.MatchPolicy = in.MatchPolicy
+	dst.ObjectSelector = in.ObjectSelector
+	dst.SideEffects = in.SideEffects
+	dst.TimeoutSeconds = in.TimeoutSeconds
+
+	if !in.BackgroundAudit {
+		backgroundAudit := false
+		data.BackgroundAudit = &backgroundAudit
+	}
+	data.ModuleRef = in.ModuleRef
+	data.MatchConditions = in.MatchConditions
+	data.Enforcement = in.Enforcement
+	data.Expiration = in.Expiration
+	data.Suspended = in.Suspended
+	data.ReinvocationPolicy = in.ReinvocationPolicy
+	data.Priority = in.Priority
+}
+
+func convertPolicyStatusToV1(src *PolicyStatus, dst *policiesv1.PolicyStatus, data *policyConversionData) {
+	in := src.DeepCopy()
+
+	dst.PolicyStatus = policiesv1.PolicyStatusEnum(in.PolicyStatus)
+	if data.PolicyStatus != "" && in.PolicyStatus == PolicyStatusUnscheduled {
+		dst.PolicyStatus = data.PolicyStatus
+	}
+	dst.PolicyMode = policiesv1.PolicyModeStatus(in.PolicyMode)
+	if data.PolicyMode != "" && in.PolicyMode == PolicyModeStatusMonitor {
+		dst.PolicyMode = data.PolicyMode
+	}
+	dst.Conditions = in.Conditions
+	dst.EffectiveNamespaceSelector = data.EffectiveNamespaceSelector
+	dst.Exceptions = data.Exceptions
+	dst.NextModeTransition = data.NextModeTransition
+}
+
+func convertPolicyStatusFromV1(src *policiesv1.PolicyStatus, dst *PolicyStatus, data *policyConversionData) {
+	in := src.DeepCopy()
+
+	dst.PolicyStatus = PolicyStatusEnum(in.PolicyStatus)
+	if in.PolicyStatus == policiesv1.PolicyStatusSuspended {
+		dst.PolicyStatus = PolicyStatusUnscheduled
+		data.PolicyStatus = in.PolicyStatus
+	}
+	dst.PolicyMode = PolicyModeStatus(in.PolicyMode)
+	if in.PolicyMode == policiesv1.PolicyModeStatusWarn {
+		dst.PolicyMode = PolicyModeStatusMonitor
+		data.PolicyMode = in.PolicyMode
+	}
+	dst.Conditions = in.Conditions
+	data.EffectiveNamespaceSelector = in.EffectiveNamespaceSelector
+	data.Exceptions = in.Exceptions
+	data.NextModeTransition = in.NextModeTransition
+}
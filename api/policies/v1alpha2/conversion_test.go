@@ -0,0 +1,211 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"math/rand"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+const fuzzIterations = 1000
+
+func newFuzzer(t *testing.T) *fuzz.Fuzzer {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	require.NoError(t, policiesv1.AddToScheme(scheme))
+
+	return fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(rand.Int63()), runtimeserializer.NewCodecFactory(scheme)) //nolint:gosec // Not used for security purposes
+}
+
+// testHubSpokeHub checks that converting a fuzzed hub object to the spoke
+// version and back gives the original object.
+func testHubSpokeHub(t *testing.T, newHub func() conversion.Hub, newSpoke func() conversion.Convertible) {
+	t.Helper()
+
+	f := newFuzzer(t)
+	for range fuzzIterations {
+		hubBefore := newHub()
+		f.Fuzz(hubBefore)
+		// The conversion annotation is owned by the conversion itself.
+		delete(hubBefore.(metaObject).GetAnnotations(), constants.ConversionDataAnnotationKey)
+
+		spoke := newSpoke()
+		require.NoError(t, spoke.ConvertFrom(hubBefore))
+
+		hubAfter := newHub()
+		require.NoError(t, spoke.ConvertTo(hubAfter))
+
+		require.True(t, equality.Semantic.DeepEqual(hubBefore, hubAfter), "hub-spoke-hub round trip mismatch:\nbefore: %#v\nafter:  %#v", hubBefore, hubAfter)
+	}
+}
+
+// testSpokeHubSpoke checks that converting a fuzzed spoke object to the hub
+// version and back gives the original object.
+func testSpokeHubSpoke(t *testing.T, newHub func() conversion.Hub, newSpoke func() conversion.Convertible) {
+	t.Helper()
+
+	f := newFuzzer(t)
+	for range fuzzIterations {
+		spokeBefore := newSpoke()
+		f.Fuzz(spokeBefore)
+		delete(spokeBefore.(metaObject).GetAnnotations(), constants.ConversionDataAnnotationKey)
+
+		hub := newHub()
+		require.NoError(t, spokeBefore.ConvertTo(hub))
+
+		spokeAfter := newSpoke()
+		require.NoError(t, spokeAfter.ConvertFrom(hub))
+
+		require.True(t, equality.Semantic.DeepEqual(spokeBefore, spokeAfter), "spoke-hub-spoke round trip mismatch:\nbefore: %#v\nafter:  %#v", spokeBefore, spokeAfter)
+	}
+}
+
+type metaObject interface {
+	GetAnnotations() map[string]string
+}
+
+func TestAdmissionPolicyConversion(t *testing.T) {
+	newHub := func() conversion.Hub { return &policiesv1.AdmissionPolicy{} }
+	newSpoke := func() conversion.Convertible { return &AdmissionPolicy{} }
+
+	t.Run("hub-spoke-hub", func(t *testing.T) { testHubSpokeHub(t, newHub, newSpoke) })
+	t.Run("spoke-hub-spoke", func(t *testing.T) { testSpokeHubSpoke(t, newHub, newSpoke) })
+}
+
+func TestClusterAdmissionPolicyConversion(t *testing.T) {
+	newHub := func() conversion.Hub { return &policiesv1.ClusterAdmissionPolicy{} }
+	newSpoke := func() conversion.Convertible { return &ClusterAdmissionPolicy{} }
+
+	t.Run("hub-spoke-hub", func(t *testing.T) { testHubSpokeHub(t, newHub, newSpoke) })
+	t.Run("spoke-hub-spoke", func(t *testing.T) { testSpokeHubSpoke(t, newHub, newSpoke) })
+}
+
+func TestPolicyServerConversion(t *testing.T) {
+	newHub := func() conversion.Hub { return &policiesv1.PolicyServer{} }
+	newSpoke := func() conversion.Convertible { return &PolicyServer{} }
+
+	t.Run("hub-spoke-hub", func(t *testing.T) { testHubSpokeHub(t, newHub, newSpoke) })
+	t.Run("spoke-hub-spoke", func(t *testing.T) { testSpokeHubSpoke(t, newHub, newSpoke) })
+}
+
+func TestConversionWithoutLossyFieldsDoesNotAddAnnotation(t *testing.T) {
+	hub := &policiesv1.AdmissionPolicy{
+		Spec: policiesv1.AdmissionPolicySpec{
+			PolicySpec: policiesv1.PolicySpec{
+				PolicyServer:    "default",
+				Module:          "ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+				BackgroundAudit: true,
+			},
+		},
+	}
+
+	spoke := &AdmissionPolicy{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	require.NotContains(t, spoke.GetAnnotations(), constants.ConversionDataAnnotationKey)
+
+	hub.Spec.BackgroundAudit = false
+	require.NoError(t, spoke.ConvertFrom(hub))
+	require.JSONEq(t, `{"backgroundAudit":false}`, spoke.GetAnnotations()[constants.ConversionDataAnnotationKey])
+}
+
+func TestWarnModeConversion(t *testing.T) {
+	hub := &policiesv1.ClusterAdmissionPolicy{
+		Spec: policiesv1.ClusterAdmissionPolicySpec{
+			PolicySpec: policiesv1.PolicySpec{
+				PolicyServer:    "default",
+				Module:          "ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+				Mode:            policiesv1.PolicyModeWarn,
+				BackgroundAudit: true,
+			},
+		},
+		Status: policiesv1.PolicyStatus{PolicyMode: policiesv1.PolicyModeStatusWarn},
+	}
+
+	spoke := &ClusterAdmissionPolicy{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	require.Equal(t, PolicyMode("monitor"), spoke.Spec.Mode)
+	require.Equal(t, PolicyModeStatusMonitor, spoke.Status.PolicyMode)
+
+	hubAfter := &policiesv1.ClusterAdmissionPolicy{}
+	require.NoError(t, spoke.ConvertTo(hubAfter))
+	require.Equal(t, policiesv1.PolicyModeWarn, hubAfter.Spec.Mode)
+	require.Equal(t, policiesv1.PolicyModeStatusWarn, hubAfter.Status.PolicyMode)
+}
+
+func TestSuspendedPolicyConversion(t *testing.T) {
+	hub := &policiesv1.AdmissionPolicy{
+		Spec: policiesv1.AdmissionPolicySpec{
+			PolicySpec: policiesv1.PolicySpec{
+				PolicyServer:    "default",
+				Module:          "ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+				BackgroundAudit: true,
+				Suspended:       true,
+			},
+		},
+		Status: policiesv1.PolicyStatus{PolicyStatus: policiesv1.PolicyStatusSuspended},
+	}
+
+	spoke := &AdmissionPolicy{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	require.Equal(t, PolicyStatusUnscheduled, spoke.Status.PolicyStatus)
+
+	hubAfter := &policiesv1.AdmissionPolicy{}
+	require.NoError(t, spoke.ConvertTo(hubAfter))
+	require.True(t, hubAfter.Spec.Suspended)
+	require.Equal(t, policiesv1.PolicyStatusSuspended, hubAfter.Status.PolicyStatus)
+}
+
+func TestModeChangedOnSpokeConversion(t *testing.T) {
+	hub := &policiesv1.ClusterAdmissionPolicy{
+		Spec: policiesv1.ClusterAdmissionPolicySpec{
+			PolicySpec: policiesv1.PolicySpec{
+				PolicyServer:    "default",
+				Module:          "ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+				Mode:            policiesv1.PolicyModeWarn,
+				BackgroundAudit: true,
+			},
+		},
+		Status: policiesv1.PolicyStatus{
+			PolicyStatus: policiesv1.PolicyStatusSuspended,
+			PolicyMode:   policiesv1.PolicyModeStatusWarn,
+		},
+	}
+
+	spoke := &ClusterAdmissionPolicy{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	spoke.Spec.Mode = "protect"
+	spoke.Status.PolicyStatus = PolicyStatusActive
+	spoke.Status.PolicyMode = PolicyModeStatusProtect
+
+	hubAfter := &policiesv1.ClusterAdmissionPolicy{}
+	require.NoError(t, spoke.ConvertTo(hubAfter))
+	require.Equal(t, policiesv1.PolicyModeProtect, hubAfter.Spec.Mode)
+	require.Equal(t, policiesv1.PolicyStatusActive, hubAfter.Status.PolicyStatus)
+	require.Equal(t, policiesv1.PolicyModeStatusProtect, hubAfter.Status.PolicyMode)
+}
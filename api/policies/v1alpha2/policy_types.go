@@ -26,7 +26,10 @@ type PolicyMode string
 
 type PolicySpec struct {
 	// PolicyServer identifies an existing PolicyServer resource.
-	// +kubebuilder:default:=default
+	// When empty, it is defaulted to the PolicyServer named by the
+	// kubewarden.io/default-policy-server annotation of the policy namespace
+	// (namespaced policies only), then to the default PolicyServer configured
+	// on the controller, and finally to "default".
 	// +optional
 	PolicyServer string `json:"policyServer"`
 
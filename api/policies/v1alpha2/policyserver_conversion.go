@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+// policyServerConversionData holds the v1 PolicyServer fields that cannot be
+// represented in v1alpha2.
+type policyServerConversionData struct {
+	MinAvailable     *intstr.IntOrString              `json:"minAvailable,omitempty"`
+	MaxUnavailable   *intstr.IntOrString              `json:"maxUnavailable,omitempty"`
+	SecurityContexts *policiesv1.PolicyServerSecurity `json:"securityContexts,omitempty"`
+	Affinity         *corev1.Affinity                 `json:"affinity,omitempty"`
+	Limits           corev1.ResourceList              `json:"limits,omitempty"`
+	Requests         corev1.ResourceList              `json:"requests,omitempty"`
+	Tolerations      []corev1.Toleration              `json:"tolerations,omitempty"`
+}
+
+// ConvertTo converts this PolicyServer to the Hub version (v1).
+func (ps *PolicyServer) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*policiesv1.PolicyServer)
+	if !ok {
+		return fmt.Errorf("expected a v1 PolicyServer object, got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *ps.ObjectMeta.DeepCopy()
+
+	data := &policyServerConversionData{}
+	if err := popConversionData(&dst.ObjectMeta, data); err != nil {
+		return err
+	}
+
+	in := ps.Spec.DeepCopy()
+	dst.Spec.Image = in.Image
+	dst.Spec.Replicas = in.Replicas
+	dst.Spec.Annotations = in.Annotations
+	dst.Spec.Env = in.Env
+	dst.Spec.ServiceAccountName = in.ServiceAccountName
+	dst.Spec.ImagePullSecret = in.ImagePullSecret
+	dst.Spec.InsecureSources = in.InsecureSources
+	dst.Spec.SourceAuthorities = in.SourceAuthorities
+	dst.Spec.VerificationConfig = in.VerificationConfig
+
+	dst.Spec.MinAvailable = data.MinAvailable
+	dst.Spec.MaxUnavailable = data.MaxUnavailable
+	if data.SecurityContexts != nil {
+		dst.Spec.SecurityContexts = *data.SecurityContexts
+	}
+	if data.Affinity != nil {
+		dst.Spec.Affinity = *data.Affinity
+	}
+	dst.Spec.Limits = data.Limits
+	dst.Spec.Requests = data.Requests
+	dst.Spec.Tolerations = data.Tolerations
+
+	dst.Status.Conditions = ps.Status.DeepCopy().Conditions
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (ps *PolicyServer) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*policiesv1.PolicyServer)
+	if !ok {
+		return fmt.Errorf("expected a v1 PolicyServer object, got %T", srcRaw)
+	}
+
+	ps.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	in := src.Spec.DeepCopy()
+	ps.Spec.Image = in.Image
+	ps.Spec.Replicas = in.Replicas
+	ps.Spec.Annotations = in.Annotations
+	ps.Spec.Env = in.Env
+	ps.Spec.ServiceAccountName = in.ServiceAccountName
+	ps.Spec.ImagePullSecret = in.ImagePullSecret
+	ps.Spec.InsecureSources = in.InsecureSources
+	ps.Spec.SourceAuthorities = in.SourceAuthorities
+	ps.Spec.VerificationConfig = in.VerificationConfig
+
+	data := &policyServerConversionData{
+		MinAvailable:   in.MinAvailable,
+		MaxUnavailable: in.MaxUnavailable,
+		Limits:         in.Limits,
+		Requests:       in.Requests,
+		Tolerations:    in.Tolerations,
+	}
+	if in.SecurityContexts != (policiesv1.PolicyServerSecurity{}) {
+		data.SecurityContexts = &in.SecurityContexts
+	}
+	if in.Affinity != (corev1.Affinity{}) {
+		data.Affinity = &in.Affinity
+	}
+
+	ps.Status.Conditions = src.Status.DeepCopy().Conditions
+
+	return setConversionData(&ps.ObjectMeta, data)
+}
@@ -21,6 +21,8 @@ import (
 	"errors"
 	"flag"
 	"os"
+	"slices"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -29,18 +31,24 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8spoliciesv1 "k8s.io/api/policy/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
 	"github.com/kubewarden/kubewarden-controller/api/policies/v1alpha2"
@@ -59,6 +67,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(v1alpha2.AddToScheme(scheme))
 	utilruntime.Must(policiesv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -81,6 +90,16 @@ func main() {
 	var openTelemetryClientCertificateSecret string
 	var openTelemetryCertificateSecret string
 	var clientCAConfigMapName string
+	var defaultPolicyServer string
+	var migrateStorageVersion bool
+	var shardWebhookConfigurations bool
+	var namespacedSensitiveResources string
+	var clusterSensitiveResources string
+	var excludedNamespaces string
+	var excludedNamespaceLabels string
+	var breakGlassGroup string
+	var dropSuspendedPolicies bool
+	var strictServedResourcesValidation bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8088", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -108,6 +127,53 @@ func main() {
 		false,
 		"Always accept admission reviews targeting the deployments-namespace.")
 	flag.StringVar(&clientCAConfigMapName, "client-ca-configmap-name", "", "The name of the ConfigMap containing the client CA certificate. If provided, mTLS will be enabled.")
+	flag.StringVar(&defaultPolicyServer,
+		"default-policy-server",
+		"",
+		"The PolicyServer assigned to policies that do not set one and whose namespace has no kubewarden.io/default-policy-server annotation. If empty, the \"default\" PolicyServer is used.")
+	flag.BoolVar(&migrateStorageVersion,
+		"migrate-storage-version",
+		false,
+		"Rewrite all the PolicyServers and policies at the storage version of their CRD on startup, and trim the CRDs status.storedVersions accordingly.")
+
+	flag.BoolVar(&shardWebhookConfigurations,
+		"shard-webhook-configurations",
+		false,
+		"Aggregate the webhooks of all the policies of a PolicyServer into one ValidatingWebhookConfiguration and one MutatingWebhookConfiguration, instead of creating one webhook configuration per policy.")
+	flag.StringVar(&namespacedSensitiveResources,
+		"namespaced-policies-sensitive-resources",
+		policiesv1.DefaultNamespacedSensitiveResources,
+		"Comma separated list of resources that cannot be targeted by AdmissionPolicies and AdmissionPolicyGroups. "+
+			"Each entry has the form [<namespace>/]<resource>[.<api group>]. The Secrets of the deployments namespace are always protected.")
+	flag.StringVar(&clusterSensitiveResources,
+		"cluster-policies-sensitive-resources",
+		policiesv1.DefaultClusterSensitiveResources,
+		"Comma separated list of resources that cannot be targeted by ClusterAdmissionPolicies and ClusterAdmissionPolicyGroups. "+
+			"Each entry has the form [<namespace>/]<resource>[.<api group>]. Namespaced entries are allowed when the namespaceSelector of the policy excludes the namespace.")
+	flag.StringVar(&excludedNamespaces,
+		"excluded-namespaces",
+		"",
+		"Comma separated list of namespaces excluded from all the ClusterAdmissionPolicies and ClusterAdmissionPolicyGroups, like \"kube-system\". The deployments namespace is always excluded.")
+	flag.StringVar(&excludedNamespaceLabels,
+		"excluded-namespace-labels",
+		"",
+		"Comma separated list of labels excluding the namespaces having them from all the ClusterAdmissionPolicies and ClusterAdmissionPolicyGroups, like \"kubewarden.io/exempt=true\". "+
+			"An entry without value excludes the namespaces having the label, whatever its value.")
+	flag.BoolVar(&dropSuspendedPolicies,
+		"drop-suspended-policies",
+		false,
+		"Remove the suspended policies from the Policy Server configuration. By default they are kept, so that suspending and unsuspending a policy does not roll out its Policy Server.")
+	flag.StringVar(&breakGlassGroup,
+		"break-glass-group",
+		"",
+		"The group whose members can downgrade a policy from protect mode by setting the policies.kubewarden.io/break-glass-reason and policies.kubewarden.io/break-glass-until annotations. "+
+			"The policy is reverted to protect mode at the given deadline. If empty, the break-glass downgrade is disabled.")
+
+	flag.BoolVar(&strictServedResourcesValidation,
+		"strict-served-resources-validation",
+		false,
+		"Reject the policies whose rules or context aware resources target resources that are not served by the cluster. "+
+			"By default, these policies are accepted with a warning.")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -149,6 +215,11 @@ func main() {
 		setupLog.Error(err, "unable to check for feature gate AdmissionWebhookMatchConditions")
 	}
 
+	featureGateValidatingAdmissionPolicy, err := featuregates.CheckValidatingAdmissionPolicy(ctrl.GetConfigOrDie())
+	if err != nil {
+		setupLog.Error(err, "unable to check for ValidatingAdmissionPolicy support")
+	}
+
 	otelConfiguration := controller.TelemetryConfiguration{
 		MetricsEnabled:              enableMetrics,
 		TracingEnabled:              enableTracing,
@@ -156,20 +227,64 @@ func main() {
 		OtelCertificateSecret:       openTelemetryCertificateSecret,
 		OtelClientCertificateSecret: openTelemetryClientCertificateSecret,
 	}
+	namespaceExclusions, err := controller.ParseNamespaceExclusions(excludedNamespaces, excludedNamespaceLabels)
+	if err != nil {
+		setupLog.Error(err, "invalid namespace exclusions")
+		retcode = 1
+		return
+	}
+
+	servedResourcesMapper, err := setupServedResourcesMapper(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create the served resources mapper")
+		retcode = 1
+		return
+	}
+
 	if err = setupReconcilers(mgr,
 		deploymentsNamespace,
 		webhookServiceName,
 		alwaysAcceptAdmissionReviewsOnDeploymentsNamespace,
 		featureGateAdmissionWebhookMatchConditions,
+		featureGateValidatingAdmissionPolicy,
+		shardWebhookConfigurations,
+		namespaceExclusions,
 		otelConfiguration,
 		clientCAConfigMapName,
+		dropSuspendedPolicies,
+		servedResourcesMapper,
 	); err != nil {
 		setupLog.Error(err, "unable to create controllers")
 		retcode = 1
 		return
 	}
 
-	if err = setupWebhooks(mgr, deploymentsNamespace); err != nil {
+	if migrateStorageVersion {
+		if err = (&controller.StorageVersionMigrator{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Recorder:  mgr.GetEventRecorderFor("kubewarden-storage-version-migrator"),
+			Log:       ctrl.Log.WithName("storage-version-migrator"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create storage version migrator")
+			retcode = 1
+			return
+		}
+	}
+
+	sensitiveResources, err := parseSensitiveResources(deploymentsNamespace, namespacedSensitiveResources, clusterSensitiveResources)
+	if err != nil {
+		setupLog.Error(err, "unable to parse the sensitive resources")
+		retcode = 1
+		return
+	}
+
+	warningsSettings := policiesv1.PolicyWarningsSettings{
+		DeploymentsNamespace:                       deploymentsNamespace,
+		FeatureGateAdmissionWebhookMatchConditions: featureGateAdmissionWebhookMatchConditions,
+	}
+
+	if err = setupWebhooks(mgr, deploymentsNamespace, defaultPolicyServer, sensitiveResources, breakGlassGroup, strictServedResourcesValidation, warningsSettings); err != nil {
 		setupLog.Error(err, "unable to create webhooks")
 		retcode = 1
 		return
@@ -250,6 +365,36 @@ func setupManager(deploymentsNamespace string, metricsAddr string, probeAddr str
 	return mgr, err
 }
 
+// setupServedResourcesMapper creates the RESTMapper used by the policy
+// reconcilers to check the resources targeted by the policies. Unlike the
+// RESTMapper of the manager, its cache is reset periodically, so that the
+// resources removed from the cluster are detected.
+func setupServedResourcesMapper(mgr ctrl.Manager) (meta.RESTMapper, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to create discovery client"), err)
+	}
+	servedResourcesMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		ticker := time.NewTicker(constants.ServedResourcesValidationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				servedResourcesMapper.Reset()
+			}
+		}
+	}))
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to add the served resources mapper reset"), err)
+	}
+
+	return servedResourcesMapper, nil
+}
+
 func setupProbes(mgr ctrl.Manager) error {
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return errors.Join(errors.New("unable to set up health check"), err)
@@ -264,9 +409,14 @@ func setupReconcilers(mgr ctrl.Manager,
 	deploymentsNamespace,
 	webhookServiceName string,
 	alwaysAcceptAdmissionReviewsOnDeploymentsNamespace,
-	featureGateAdmissionWebhookMatchConditions bool,
+	featureGateAdmissionWebhookMatchConditions,
+	featureGateValidatingAdmissionPolicy,
+	shardWebhookConfigurations bool,
+	namespaceExclusions controller.NamespaceExclusions,
 	otelConfiguration controller.TelemetryConfiguration,
 	clientCAConfigMapName string,
+	dropSuspendedPolicies bool,
+	servedResourcesMapper meta.RESTMapper,
 ) error {
 	if err := (&controller.PolicyServerReconciler{
 		Client:               mgr.GetClient(),
@@ -276,6 +426,7 @@ func setupReconcilers(mgr ctrl.Manager,
 		AlwaysAcceptAdmissionReviewsInDeploymentsNamespace: alwaysAcceptAdmissionReviewsOnDeploymentsNamespace,
 		TelemetryConfiguration:                             otelConfiguration,
 		ClientCAConfigMapName:                              clientCAConfigMapName,
+		DropSuspendedPolicies:                              dropSuspendedPolicies,
 	}).SetupWithManager(mgr); err != nil {
 		return errors.Join(errors.New("unable to create PolicyServer controller"), err)
 	}
@@ -286,6 +437,9 @@ func setupReconcilers(mgr ctrl.Manager,
 		Log:                  ctrl.Log.WithName("admission-policy-reconciler"),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: featureGateAdmissionWebhookMatchConditions,
+		ShardWebhookConfigurations:                 shardWebhookConfigurations,
+		Recorder:                                   mgr.GetEventRecorderFor("kubewarden-admission-policy-controller"),
+		RESTMapper:                                 servedResourcesMapper,
 	}).SetupWithManager(mgr); err != nil {
 		return errors.Join(errors.New("unable to create AdmissionPolicy controller"), err)
 	}
@@ -296,6 +450,11 @@ func setupReconcilers(mgr ctrl.Manager,
 		Log:                  ctrl.Log.WithName("cluster-admission-policy-reconciler"),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: featureGateAdmissionWebhookMatchConditions,
+		FeatureGateValidatingAdmissionPolicy:       featureGateValidatingAdmissionPolicy,
+		ShardWebhookConfigurations:                 shardWebhookConfigurations,
+		NamespaceExclusions:                        namespaceExclusions,
+		Recorder:                                   mgr.GetEventRecorderFor("kubewarden-cluster-admission-policy-controller"),
+		RESTMapper:                                 servedResourcesMapper,
 	}).SetupWithManager(mgr); err != nil {
 		return errors.Join(errors.New("unable to create ClusterAdmissionPolicy controller"), err)
 	}
@@ -317,6 +476,9 @@ func setupReconcilers(mgr ctrl.Manager,
 		Log:                  ctrl.Log.WithName("admission-policy-group-reconciler"),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: featureGateAdmissionWebhookMatchConditions,
+		ShardWebhookConfigurations:                 shardWebhookConfigurations,
+		Recorder:                                   mgr.GetEventRecorderFor("kubewarden-admission-policy-group-controller"),
+		RESTMapper:                                 servedResourcesMapper,
 	}).SetupWithManager(mgr); err != nil {
 		return errors.Join(errors.New("unable to create AdmissionPolicyGroup controller"), err)
 	}
@@ -327,27 +489,124 @@ func setupReconcilers(mgr ctrl.Manager,
 		Log:                  ctrl.Log.WithName("cluster-admission-policy-group-reconciler"),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: featureGateAdmissionWebhookMatchConditions,
+		ShardWebhookConfigurations:                 shardWebhookConfigurations,
+		NamespaceExclusions:                        namespaceExclusions,
+		Recorder:                                   mgr.GetEventRecorderFor("kubewarden-cluster-admission-policy-group-controller"),
+		RESTMapper:                                 servedResourcesMapper,
 	}).SetupWithManager(mgr); err != nil {
 		return errors.Join(errors.New("unable to create ClusterAdmissionPolicyGroup controller"), err)
 	}
+
+	if err := (&controller.PolicyExceptionReconciler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor("kubewarden-policy-exception-controller"),
+		Log:      ctrl.Log.WithName("policy-exception-reconciler"),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create PolicyException controller"), err)
+	}
+
+	if err := (&controller.ClusterPolicyExceptionReconciler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor("kubewarden-cluster-policy-exception-controller"),
+		Log:      ctrl.Log.WithName("cluster-policy-exception-reconciler"),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create ClusterPolicyException controller"), err)
+	}
+
+	if err := (&controller.PolicyBindingReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("kubewarden-policy-binding-controller"),
+		Log:      ctrl.Log.WithName("policy-binding-reconciler"),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create PolicyBinding controller"), err)
+	}
+
+	if err := (&controller.ClusterPolicyBindingReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("kubewarden-cluster-policy-binding-controller"),
+		Log:      ctrl.Log.WithName("cluster-policy-binding-reconciler"),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create ClusterPolicyBinding controller"), err)
+	}
+
+	if err := (&controller.PolicyModuleReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("policy-module-reconciler"),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create PolicyModule controller"), err)
+	}
+
+	if err := (&controller.WebhookConfigurationGarbageCollector{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorderFor("kubewarden-webhook-configuration-gc"),
+		Log:       ctrl.Log.WithName("webhook-configuration-gc"),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create webhook configuration garbage collector"), err)
+	}
 	return nil
 }
 
-func setupWebhooks(mgr ctrl.Manager, deploymentsNamespace string) error {
+// parseSensitiveResources parses the sensitive resources of the namespaced and
+// the cluster-wide policies. The Secrets of the deployments namespace hold the
+// certificates used by Kubewarden, hence they are always protected from the
+// namespaced policies.
+func parseSensitiveResources(deploymentsNamespace, namespacedSensitiveResources, clusterSensitiveResources string) (policiesv1.SensitiveResources, error) {
+	namespaced, err := policiesv1.ParseSensitiveResources(namespacedSensitiveResources)
+	if err != nil {
+		return policiesv1.SensitiveResources{}, errors.Join(errors.New("invalid namespaced policies sensitive resources"), err)
+	}
+	cluster, err := policiesv1.ParseSensitiveResources(clusterSensitiveResources)
+	if err != nil {
+		return policiesv1.SensitiveResources{}, errors.Join(errors.New("invalid cluster policies sensitive resources"), err)
+	}
+
+	deploymentsNamespaceSecrets := policiesv1.SensitiveResource{Resource: "secrets", Namespace: deploymentsNamespace}
+	if deploymentsNamespace != "" && !slices.Contains(namespaced, deploymentsNamespaceSecrets) {
+		namespaced = append(namespaced, deploymentsNamespaceSecrets)
+	}
+
+	return policiesv1.SensitiveResources{
+		Namespaced: namespaced,
+		Cluster:    cluster,
+	}, nil
+}
+
+func setupWebhooks(mgr ctrl.Manager, deploymentsNamespace, defaultPolicyServer string, sensitiveResources policiesv1.SensitiveResources, breakGlassGroup string, strictServedResources bool, warningsSettings policiesv1.PolicyWarningsSettings) error {
 	if err := (&policiesv1.PolicyServer{}).SetupWebhookWithManager(mgr, deploymentsNamespace); err != nil {
 		return errors.Join(errors.New("unable to create webhook for policy servers"), err)
 	}
-	if err := (&policiesv1.ClusterAdmissionPolicy{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&policiesv1.ClusterAdmissionPolicy{}).SetupWebhookWithManager(mgr, defaultPolicyServer, sensitiveResources, breakGlassGroup, strictServedResources, warningsSettings); err != nil {
 		return errors.Join(errors.New("unable to create webhook for cluster admission policies"), err)
 	}
-	if err := (&policiesv1.AdmissionPolicy{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&policiesv1.AdmissionPolicy{}).SetupWebhookWithManager(mgr, defaultPolicyServer, sensitiveResources, breakGlassGroup, strictServedResources, warningsSettings); err != nil {
 		return errors.Join(errors.New("unable to create webhook for admission policies"), err)
 	}
-	if err := (&policiesv1.AdmissionPolicyGroup{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&policiesv1.AdmissionPolicyGroup{}).SetupWebhookWithManager(mgr, defaultPolicyServer, sensitiveResources, breakGlassGroup, strictServedResources, warningsSettings); err != nil {
 		return errors.Join(errors.New("unable to create webhook for admission policies groups"), err)
 	}
-	if err := (&policiesv1.ClusterAdmissionPolicyGroup{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&policiesv1.ClusterAdmissionPolicyGroup{}).SetupWebhookWithManager(mgr, defaultPolicyServer, sensitiveResources, breakGlassGroup, strictServedResources, warningsSettings); err != nil {
 		return errors.Join(errors.New("unable to create webhook for cluster admission policies groups"), err)
 	}
+	if err := (&policiesv1.PolicyException{}).SetupWebhookWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create webhook for policy exceptions"), err)
+	}
+	if err := (&policiesv1.ClusterPolicyException{}).SetupWebhookWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create webhook for cluster policy exceptions"), err)
+	}
+	if err := (&policiesv1.PolicyTemplate{}).SetupWebhookWithManager(mgr, sensitiveResources); err != nil {
+		return errors.Join(errors.New("unable to create webhook for policy templates"), err)
+	}
+	if err := (&policiesv1.PolicyBinding{}).SetupWebhookWithManager(mgr, sensitiveResources); err != nil {
+		return errors.Join(errors.New("unable to create webhook for policy bindings"), err)
+	}
+	if err := (&policiesv1.ClusterPolicyBinding{}).SetupWebhookWithManager(mgr, sensitiveResources); err != nil {
+		return errors.Join(errors.New("unable to create webhook for cluster policy bindings"), err)
+	}
+	if err := (&policiesv1.PolicyModule{}).SetupWebhookWithManager(mgr); err != nil {
+		return errors.Join(errors.New("unable to create webhook for policy modules"), err)
+	}
 	return nil
 }
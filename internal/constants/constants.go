@@ -7,6 +7,13 @@ const (
 	// policies does not have a policy server name defined.
 	DefaultPolicyServer = "default"
 
+	// Default PolicyServer selection.
+	NamespaceDefaultPolicyServerAnnotationKey = "kubewarden.io/default-policy-server"
+	PolicyServerDefaultedFromAnnotationKey    = "kubewarden.io/policy-server-defaulted-from"
+	PolicyServerDefaultedFromNamespace        = "namespace"
+	PolicyServerDefaultedFromController       = "controller"
+	PolicyServerDefaultedFromBuiltin          = "builtin"
+
 	// PolicyServer Deployment.
 	PolicyServerEnableMetricsEnvVar                 = "KUBEWARDEN_ENABLE_METRICS"
 	PolicyServerDeploymentConfigVersionAnnotation   = "kubewarden/config-version"
@@ -52,6 +59,17 @@ const (
 	// Webhook Configurations.
 	WebhookConfigurationPolicyNameAnnotationKey      = "kubewardenPolicyName"
 	WebhookConfigurationPolicyNamespaceAnnotationKey = "kubewardenPolicyNamespace"
+	WebhookNameSuffix                                = ".kubewarden.admission"
+
+	// Break-glass policy mode downgrade.
+	BreakGlassReasonAnnotationKey = "policies.kubewarden.io/break-glass-reason"
+	BreakGlassUntilAnnotationKey  = "policies.kubewarden.io/break-glass-until"
+
+	// Policy templates.
+	PolicyTemplateLabelKey = "policies.kubewarden.io/policy-template"
+
+	// API conversion.
+	ConversionDataAnnotationKey = "policies.kubewarden.io/conversion-data"
 
 	// Scope.
 	NamespacePolicyScope = "namespace"
@@ -61,6 +79,15 @@ const (
 	/// requeued.
 	TimeToRequeuePolicyReconciliation = 2 * time.Second
 	MetricsShutdownTimeout            = 5 * time.Second
+	// Duration before the expiration of a policy during which warnings are
+	// emitted.
+	TimeToWarnBeforePolicyExpiration = 24 * time.Hour
+	// Duration before a mode transition of a policy enforcement during which
+	// the transition is announced.
+	TimeToAnnouncePolicyModeTransition = 24 * time.Hour
+	// Interval at which the resources targeted by the policies are checked
+	// against the resources served by the cluster.
+	ServedResourcesValidationInterval = 10 * time.Minute
 
 	// Server Cert Secrets.
 	WebhookServerCertSecretName = "kubewarden-webhook-server-cert" //nolint:gosec // This is not a credential
@@ -262,7 +262,7 @@ var _ = Describe("AdmissionPolicy controller", Label("real-cluster"), func() {
 
 			By("reconciling the MutatingWebhookConfiguration to its original state")
 			Eventually(func() (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
-				return getTestMutatingWebhookConfiguration(ctx, fmt.Sprintf("namespaced-%s-%s", policyNamespace, policyName))
+				return getTestMutatingWebhookConfiguration(ctx, fmt.Sprintf("namespaced.%s.%s", policyNamespace, policyName))
 			}, timeout, pollInterval).Should(
 				And(
 					HaveField("Labels", Equal(originalMutatingWebhookConfiguration.Labels)),
@@ -273,7 +273,7 @@ var _ = Describe("AdmissionPolicy controller", Label("real-cluster"), func() {
 
 			// simulate unitialized labels and annotation maps (behaviour of Kubewarden <= 1.9.0), or user change
 			By("by setting the MutatingWebhookConfiguration labels and annotation to nil")
-			mutatingWebhookConfiguration, err := getTestMutatingWebhookConfiguration(ctx, fmt.Sprintf("namespaced-%s-%s", policyNamespace, policyName))
+			mutatingWebhookConfiguration, err := getTestMutatingWebhookConfiguration(ctx, fmt.Sprintf("namespaced.%s.%s", policyNamespace, policyName))
 			Expect(err).ToNot(HaveOccurred())
 			originalMutatingWebhookConfiguration = mutatingWebhookConfiguration.DeepCopy()
 			mutatingWebhookConfiguration.Labels = nil
@@ -284,7 +284,7 @@ var _ = Describe("AdmissionPolicy controller", Label("real-cluster"), func() {
 
 			By("reconciling the MutatingWebhookConfiguration to its original state")
 			Eventually(func() (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
-				return getTestMutatingWebhookConfiguration(ctx, fmt.Sprintf("namespaced-%s-%s", policyNamespace, policyName))
+				return getTestMutatingWebhookConfiguration(ctx, fmt.Sprintf("namespaced.%s.%s", policyNamespace, policyName))
 			}, timeout, pollInterval).Should(
 				And(
 					HaveField("Labels", Equal(originalMutatingWebhookConfiguration.Labels)),
@@ -24,7 +24,9 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -54,7 +56,13 @@ type AdmissionPolicyGroupReconciler struct {
 	Scheme                                     *runtime.Scheme
 	DeploymentsNamespace                       string
 	FeatureGateAdmissionWebhookMatchConditions bool
-	policySubReconciler                        *policySubReconciler
+	ShardWebhookConfigurations                 bool
+	Recorder                                   record.EventRecorder
+	// RESTMapper is used to check periodically that the resources targeted
+	// by the policies are served by the cluster. The check is disabled when
+	// it is not set.
+	RESTMapper          apimeta.RESTMapper
+	policySubReconciler *policySubReconciler
 }
 
 // Reconcile reconciles admission policies.
@@ -74,6 +82,11 @@ func (r *AdmissionPolicyGroupReconciler) SetupWithManager(mgr ctrl.Manager) erro
 		r.Log,
 		r.DeploymentsNamespace,
 		r.FeatureGateAdmissionWebhookMatchConditions,
+		false,
+		r.ShardWebhookConfigurations,
+		NamespaceExclusions{},
+		r.Recorder,
+		r.RESTMapper,
 	}
 
 	err := ctrl.NewControllerManagedBy(mgr).
@@ -86,6 +99,18 @@ func (r *AdmissionPolicyGroupReconciler) SetupWithManager(mgr ctrl.Manager) erro
 			&admissionregistrationv1.ValidatingWebhookConfiguration{},
 			handler.EnqueueRequestsFromMapFunc(r.findAdmissionPolicyForWebhookConfiguration),
 		).
+		Watches(
+			&policiesv1.PolicyException{},
+			handler.EnqueueRequestsFromMapFunc(r.findAdmissionPolicyGroupsForException),
+		).
+		Watches(
+			&policiesv1.ClusterPolicyException{},
+			handler.EnqueueRequestsFromMapFunc(r.findAdmissionPolicyGroupsForException),
+		).
+		Watches(
+			&policiesv1.AdmissionPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findPolicyGroupsForReferencedPolicy),
+		).
 		Complete(r)
 	if err != nil {
 		return errors.Join(errors.New("failed enrolling controller with manager"), err)
@@ -98,14 +123,14 @@ func (r *AdmissionPolicyGroupReconciler) findAdmissionPoliciesForPod(ctx context
 	return findPoliciesForPod(ctx, r.Client, object)
 }
 
-func (r *AdmissionPolicyGroupReconciler) findAdmissionPolicyForWebhookConfiguration(_ context.Context, webhookConfiguration client.Object) []reconcile.Request {
+func (r *AdmissionPolicyGroupReconciler) findAdmissionPolicyForWebhookConfiguration(ctx context.Context, webhookConfiguration client.Object) []reconcile.Request {
 	if !hasKubewardenLabel(webhookConfiguration.GetLabels()) {
 		return []reconcile.Request{}
 	}
 
 	policyName := webhookConfiguration.GetAnnotations()[constants.WebhookConfigurationPolicyNameAnnotationKey]
 	if policyName == "" {
-		return []reconcile.Request{}
+		return findPoliciesForShardedWebhookConfiguration(ctx, r.Client, webhookConfiguration, &policiesv1.AdmissionPolicyGroupList{})
 	}
 
 	policyNamespace := webhookConfiguration.GetAnnotations()[constants.WebhookConfigurationPolicyNamespaceAnnotationKey]
@@ -122,3 +147,11 @@ func (r *AdmissionPolicyGroupReconciler) findAdmissionPolicyForWebhookConfigurat
 		},
 	}
 }
+
+func (r *AdmissionPolicyGroupReconciler) findAdmissionPolicyGroupsForException(_ context.Context, exception client.Object) []reconcile.Request {
+	return findPoliciesForException(exception, policiesv1.AdmissionPolicyGroupKind)
+}
+
+func (r *AdmissionPolicyGroupReconciler) findPolicyGroupsForReferencedPolicy(ctx context.Context, policy client.Object) []reconcile.Request {
+	return findPolicyGroupsForReferencedPolicy(ctx, r.Client, r.Log, policy)
+}
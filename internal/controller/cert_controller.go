@@ -9,6 +9,8 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,6 +22,18 @@ import (
 
 const tickerDuration = 12 * time.Hour
 
+// conversionWebhookCRDs are the CRDs served by more than one API version,
+// whose conversion webhook is implemented by the controller.
+//
+//nolint:gochecknoglobals // This is a constant list of CRD names
+var conversionWebhookCRDs = []string{
+	"admissionpolicies.policies.kubewarden.io",
+	"clusteradmissionpolicies.policies.kubewarden.io",
+	"policyservers.policies.kubewarden.io",
+}
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;patch
+
 type CertReconciler struct {
 	client.Client
 	Log                         logr.Logger
@@ -114,6 +128,11 @@ func (r *CertReconciler) reconcileCARoot(ctx context.Context, caRootSecret *core
 			return fmt.Errorf("failed to reconcile webhook configurations: %w", err)
 		}
 
+		err = r.reconcileConversionWebhooks(ctx, append(caCert, oldCACert...))
+		if err != nil {
+			return fmt.Errorf("failed to reconcile conversion webhooks: %w", err)
+		}
+
 		r.Log.Info("CA root certificate rotated successfully")
 	}
 
@@ -147,6 +166,11 @@ func (r *CertReconciler) reconcileOldCARoot(ctx context.Context, caRootSecret *c
 			return fmt.Errorf("failed to reconcile webhook configurations: %w", err)
 		}
 
+		err = r.reconcileConversionWebhooks(ctx, caCert)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile conversion webhooks: %w", err)
+		}
+
 		r.Log.Info("Old CA root certificate removed successfully")
 	}
 
@@ -202,6 +226,44 @@ func (r *CertReconciler) reconcileWebhookConfigurations(ctx context.Context, caB
 	return nil
 }
 
+// reconcileConversionWebhooks injects the CA bundle into the conversion webhook
+// configuration of the Kubewarden CRDs served by the controller webhook service.
+// CRDs that are not installed or that do not use the controller as conversion
+// webhook are skipped.
+func (r *CertReconciler) reconcileConversionWebhooks(ctx context.Context, caBundle []byte) error {
+	for _, name := range conversionWebhookCRDs {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+
+		if crd.Spec.Conversion == nil ||
+			crd.Spec.Conversion.Strategy != apiextensionsv1.WebhookConverter ||
+			crd.Spec.Conversion.Webhook == nil ||
+			crd.Spec.Conversion.Webhook.ClientConfig == nil ||
+			crd.Spec.Conversion.Webhook.ClientConfig.Service == nil ||
+			crd.Spec.Conversion.Webhook.ClientConfig.Service.Name != r.WebhookServiceName ||
+			crd.Spec.Conversion.Webhook.ClientConfig.Service.Namespace != r.DeploymentsNamespace {
+			continue
+		}
+
+		original := crd.DeepCopy()
+		crd.Spec.Conversion.Webhook.ClientConfig.CABundle = caBundle
+
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			return r.Patch(ctx, crd, client.MergeFrom(original))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to patch CRD %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
 // reconcileServerCerts reconciles the webhook server and policy server certificates by rotating them if they are about to expire.
 func (r *CertReconciler) reconcileServerCerts(ctx context.Context, caRootSecret *corev1.Secret) error {
 	webhookServerCertSecret := &corev1.Secret{}
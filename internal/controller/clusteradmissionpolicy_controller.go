@@ -24,7 +24,9 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -54,7 +56,18 @@ type ClusterAdmissionPolicyReconciler struct {
 	Scheme                                     *runtime.Scheme
 	DeploymentsNamespace                       string
 	FeatureGateAdmissionWebhookMatchConditions bool
-	policySubReconciler                        *policySubReconciler
+	// FeatureGateValidatingAdmissionPolicy is true when the cluster serves
+	// the ValidatingAdmissionPolicies, which can enforce the policies
+	// requesting the ValidatingAdmissionPolicy backend.
+	FeatureGateValidatingAdmissionPolicy bool
+	ShardWebhookConfigurations           bool
+	NamespaceExclusions                  NamespaceExclusions
+	Recorder                             record.EventRecorder
+	// RESTMapper is used to check periodically that the resources targeted
+	// by the policies are served by the cluster. The check is disabled when
+	// it is not set.
+	RESTMapper          apimeta.RESTMapper
+	policySubReconciler *policySubReconciler
 }
 
 // Reconcile reconciles admission policies.
@@ -74,6 +87,11 @@ func (r *ClusterAdmissionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) er
 		r.Log,
 		r.DeploymentsNamespace,
 		r.FeatureGateAdmissionWebhookMatchConditions,
+		r.FeatureGateValidatingAdmissionPolicy,
+		r.ShardWebhookConfigurations,
+		r.NamespaceExclusions,
+		r.Recorder,
+		r.RESTMapper,
 	}
 
 	err := ctrl.NewControllerManagedBy(mgr).
@@ -90,6 +108,14 @@ func (r *ClusterAdmissionPolicyReconciler) SetupWithManager(mgr ctrl.Manager) er
 			&admissionregistrationv1.MutatingWebhookConfiguration{},
 			handler.EnqueueRequestsFromMapFunc(r.findClusterAdmissionPolicyForWebhookConfiguration),
 		).
+		Watches(
+			&policiesv1.PolicyException{},
+			handler.EnqueueRequestsFromMapFunc(r.findClusterAdmissionPoliciesForException),
+		).
+		Watches(
+			&policiesv1.ClusterPolicyException{},
+			handler.EnqueueRequestsFromMapFunc(r.findClusterAdmissionPoliciesForException),
+		).
 		Complete(r)
 	if err != nil {
 		return errors.Join(errors.New("failed enrolling controller with manager"), err)
@@ -101,14 +127,14 @@ func (r *ClusterAdmissionPolicyReconciler) findClusterAdmissionPoliciesForPod(ct
 	return findClusterPoliciesForPod(ctx, r.Client, object)
 }
 
-func (r *ClusterAdmissionPolicyReconciler) findClusterAdmissionPolicyForWebhookConfiguration(_ context.Context, webhookConfiguration client.Object) []reconcile.Request {
+func (r *ClusterAdmissionPolicyReconciler) findClusterAdmissionPolicyForWebhookConfiguration(ctx context.Context, webhookConfiguration client.Object) []reconcile.Request {
 	if !hasKubewardenLabel(webhookConfiguration.GetLabels()) {
 		return []reconcile.Request{}
 	}
 
 	policyName := webhookConfiguration.GetAnnotations()[constants.WebhookConfigurationPolicyNameAnnotationKey]
 	if policyName == "" {
-		return []reconcile.Request{}
+		return findPoliciesForShardedWebhookConfiguration(ctx, r.Client, webhookConfiguration, &policiesv1.ClusterAdmissionPolicyList{})
 	}
 
 	return []reconcile.Request{
@@ -119,3 +145,7 @@ func (r *ClusterAdmissionPolicyReconciler) findClusterAdmissionPolicyForWebhookC
 		},
 	}
 }
+
+func (r *ClusterAdmissionPolicyReconciler) findClusterAdmissionPoliciesForException(_ context.Context, exception client.Object) []reconcile.Request {
+	return findPoliciesForException(exception, policiesv1.ClusterAdmissionPolicyKind)
+}
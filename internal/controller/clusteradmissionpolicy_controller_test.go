@@ -235,7 +235,7 @@ var _ = Describe("ClusterAdmissionPolicy controller", Label("real-cluster"), fun
 
 			By("reconciling the MutatingWebhookConfiguration to its original state")
 			Eventually(func() (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
-				return getTestMutatingWebhookConfiguration(ctx, "clusterwide-"+policyName)
+				return getTestMutatingWebhookConfiguration(ctx, "clusterwide."+policyName)
 			}, timeout, pollInterval).Should(
 				And(
 					HaveField("Labels", Equal(originalMutatingWebhookConfiguration.Labels)),
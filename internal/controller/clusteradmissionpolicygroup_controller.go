@@ -24,7 +24,9 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -54,7 +56,14 @@ type ClusterAdmissionPolicyGroupReconciler struct {
 	Scheme                                     *runtime.Scheme
 	DeploymentsNamespace                       string
 	FeatureGateAdmissionWebhookMatchConditions bool
-	policySubReconciler                        *policySubReconciler
+	ShardWebhookConfigurations                 bool
+	NamespaceExclusions                        NamespaceExclusions
+	Recorder                                   record.EventRecorder
+	// RESTMapper is used to check periodically that the resources targeted
+	// by the policies are served by the cluster. The check is disabled when
+	// it is not set.
+	RESTMapper          apimeta.RESTMapper
+	policySubReconciler *policySubReconciler
 }
 
 // Reconcile reconciles admission policies.
@@ -74,6 +83,11 @@ func (r *ClusterAdmissionPolicyGroupReconciler) SetupWithManager(mgr ctrl.Manage
 		r.Log,
 		r.DeploymentsNamespace,
 		r.FeatureGateAdmissionWebhookMatchConditions,
+		false,
+		r.ShardWebhookConfigurations,
+		r.NamespaceExclusions,
+		r.Recorder,
+		r.RESTMapper,
 	}
 
 	err := ctrl.NewControllerManagedBy(mgr).
@@ -86,6 +100,18 @@ func (r *ClusterAdmissionPolicyGroupReconciler) SetupWithManager(mgr ctrl.Manage
 			&admissionregistrationv1.ValidatingWebhookConfiguration{},
 			handler.EnqueueRequestsFromMapFunc(r.findClusterAdmissionPolicyForWebhookConfiguration),
 		).
+		Watches(
+			&policiesv1.PolicyException{},
+			handler.EnqueueRequestsFromMapFunc(r.findClusterAdmissionPolicyGroupsForException),
+		).
+		Watches(
+			&policiesv1.ClusterPolicyException{},
+			handler.EnqueueRequestsFromMapFunc(r.findClusterAdmissionPolicyGroupsForException),
+		).
+		Watches(
+			&policiesv1.ClusterAdmissionPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findPolicyGroupsForReferencedPolicy),
+		).
 		Complete(r)
 	if err != nil {
 		return errors.Join(errors.New("failed enrolling controller with manager"), err)
@@ -97,14 +123,14 @@ func (r *ClusterAdmissionPolicyGroupReconciler) findClusterAdmissionPoliciesForP
 	return findClusterPoliciesForPod(ctx, r.Client, object)
 }
 
-func (r *ClusterAdmissionPolicyGroupReconciler) findClusterAdmissionPolicyForWebhookConfiguration(_ context.Context, webhookConfiguration client.Object) []reconcile.Request {
+func (r *ClusterAdmissionPolicyGroupReconciler) findClusterAdmissionPolicyForWebhookConfiguration(ctx context.Context, webhookConfiguration client.Object) []reconcile.Request {
 	if !hasKubewardenLabel(webhookConfiguration.GetLabels()) {
 		return []reconcile.Request{}
 	}
 
 	policyName := webhookConfiguration.GetAnnotations()[constants.WebhookConfigurationPolicyNameAnnotationKey]
 	if policyName == "" {
-		return []reconcile.Request{}
+		return findPoliciesForShardedWebhookConfiguration(ctx, r.Client, webhookConfiguration, &policiesv1.ClusterAdmissionPolicyGroupList{})
 	}
 
 	return []reconcile.Request{
@@ -115,3 +141,11 @@ func (r *ClusterAdmissionPolicyGroupReconciler) findClusterAdmissionPolicyForWeb
 		},
 	}
 }
+
+func (r *ClusterAdmissionPolicyGroupReconciler) findClusterAdmissionPolicyGroupsForException(_ context.Context, exception client.Object) []reconcile.Request {
+	return findPoliciesForException(exception, policiesv1.ClusterAdmissionPolicyGroupKind)
+}
+
+func (r *ClusterAdmissionPolicyGroupReconciler) findPolicyGroupsForReferencedPolicy(ctx context.Context, policy client.Object) []reconcile.Request {
+	return findPolicyGroupsForReferencedPolicy(ctx, r.Client, r.Log, policy)
+}
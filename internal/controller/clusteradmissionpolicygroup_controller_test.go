@@ -131,6 +131,33 @@ var _ = Describe("ClusterAdmissionPolicyGroup controller", Label("real-cluster")
 			)
 		})
 
+		It("should delete the ValidatingWebhookConfiguration while a referenced policy is missing", func() {
+			By("referencing a missing policy")
+			Eventually(func() error {
+				policyGroup, err := getTestClusterAdmissionPolicyGroup(ctx, policyName)
+				if err != nil {
+					return err
+				}
+				policyGroup.Spec.Policies["missing"] = policiesv1.PolicyGroupMemberWithContext{
+					PolicyGroupMember: policiesv1.PolicyGroupMember{PolicyRef: newName("missing-policy")},
+				}
+				return k8sClient.Update(ctx, policyGroup)
+			}, timeout, pollInterval).Should(Succeed())
+
+			By("waiting for the ValidatingWebhookConfiguration to be deleted")
+			Eventually(func(g Gomega) {
+				_, err := getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}, timeout, pollInterval).Should(Succeed())
+
+			Eventually(func() (*policiesv1.ClusterAdmissionPolicyGroup, error) {
+				return getTestClusterAdmissionPolicyGroup(ctx, policyName)
+			}, timeout, pollInterval).Should(
+				HaveField("Status.PolicyStatus", Equal(policiesv1.PolicyStatusPending)),
+			)
+		})
+
 		It("should delete the ValidatingWebhookConfiguration when the ClusterAdmissionPolicyGroup is deleted", func() {
 			By("deleting the ClusterAdmissionPolicyGroup")
 			Expect(
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// NamespaceExclusions holds the namespaces that are never evaluated by the
+// ClusterAdmissionPolicies and the ClusterAdmissionPolicyGroups, regardless
+// of their namespaceSelector. The deployments namespace is always excluded.
+type NamespaceExclusions struct {
+	// Names of the excluded namespaces.
+	Names []string
+	// Labels of the excluded namespaces. A namespace having any of these
+	// labels is excluded. An empty value excludes the namespaces having the
+	// label, whatever its value.
+	Labels map[string]string
+}
+
+// ParseNamespaceExclusions parses the comma separated lists of excluded
+// namespace names, like "kube-system,kube-public", and of excluded namespace
+// labels, like "kubewarden.io/exempt=true,example.com/skip".
+func ParseNamespaceExclusions(names, labels string) (NamespaceExclusions, error) {
+	exclusions := NamespaceExclusions{
+		Names:  []string{},
+		Labels: map[string]string{},
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) != 0 {
+			return NamespaceExclusions{}, fmt.Errorf("invalid excluded namespace %q: %s", name, strings.Join(errs, ", "))
+		}
+		if !slices.Contains(exclusions.Names, name) {
+			exclusions.Names = append(exclusions.Names, name)
+		}
+	}
+
+	for _, label := range strings.Split(labels, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(label, "=")
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return NamespaceExclusions{}, fmt.Errorf("invalid excluded namespace label key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return NamespaceExclusions{}, fmt.Errorf("invalid excluded namespace label value %q: %s", value, strings.Join(errs, ", "))
+		}
+		if _, found := exclusions.Labels[key]; found {
+			return NamespaceExclusions{}, errors.New("duplicated excluded namespace label key: " + key)
+		}
+		exclusions.Labels[key] = value
+	}
+
+	return exclusions, nil
+}
+
+// selectorRequirements returns the namespaceSelector requirements excluding
+// the deployments namespace and the configured namespaces.
+func (e NamespaceExclusions) selectorRequirements(deploymentsNamespace string) []metav1.LabelSelectorRequirement {
+	names := []string{deploymentsNamespace}
+	for _, name := range e.Names {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	requirements := []metav1.LabelSelectorRequirement{
+		{
+			Key:      corev1.LabelMetadataName,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   names,
+		},
+	}
+
+	// Sort the keys, the webhook configurations would be updated at every
+	// reconciliation otherwise.
+	for _, key := range slices.Sorted(maps.Keys(e.Labels)) {
+		if e.Labels[key] == "" {
+			requirements = append(requirements, metav1.LabelSelectorRequirement{
+				Key:      key,
+				Operator: metav1.LabelSelectorOpDoesNotExist,
+			})
+			continue
+		}
+		requirements = append(requirements, metav1.LabelSelectorRequirement{
+			Key:      key,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{e.Labels[key]},
+		})
+	}
+
+	return requirements
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -44,6 +46,11 @@ type policySubReconciler struct {
 	Log                                        logr.Logger
 	deploymentsNamespace                       string
 	featureGateAdmissionWebhookMatchConditions bool
+	featureGateValidatingAdmissionPolicy       bool
+	shardWebhookConfigurations                 bool
+	namespaceExclusions                        NamespaceExclusions
+	recorder                                   record.EventRecorder
+	restMapper                                 apimeta.RESTMapper
 }
 
 func (r *policySubReconciler) reconcile(ctx context.Context, policy policiesv1.Policy) (ctrl.Result, error) {
@@ -51,7 +58,34 @@ func (r *policySubReconciler) reconcile(ctx context.Context, policy policiesv1.P
 		return r.reconcilePolicyDeletion(ctx, policy)
 	}
 
+	nextExpirationStep, deleted, err := r.reconcileExpiration(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling policy expiration: %w", err)
+	}
+	if deleted {
+		// The deletion of the policy is reconciled once it is observed.
+		return ctrl.Result{}, nil
+	}
+
+	breakGlassDeadline, breakGlassActive, err := r.reconcileBreakGlass(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error reconciling policy break-glass: %w", err)
+	}
+
+	// The enforcement schedule is suspended while the policy mode is
+	// downgraded through the break-glass annotations.
+	var nextTransition time.Duration
+	if !breakGlassActive {
+		nextTransition, err = r.reconcileEnforcement(ctx, policy)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error reconciling policy enforcement: %w", err)
+		}
+	}
+
+	nextServedResourcesCheck := r.reconcileServedResources(policy)
+
 	reconcileResult, reconcileErr := r.reconcilePolicy(ctx, policy)
+	reconcileResult = requeueAfterShortest(reconcileResult, nextTransition, breakGlassDeadline, nextExpirationStep, nextServedResourcesCheck)
 
 	if err := r.setPolicyModeStatus(ctx, policy); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error setting policy status: %w", err)
@@ -61,15 +95,40 @@ func (r *policySubReconciler) reconcile(ctx context.Context, policy policiesv1.P
 		return ctrl.Result{}, fmt.Errorf("update admission policy status error: %w", err)
 	}
 
-	// record policy count metric
-	if err := metrics.RecordPolicyCount(ctx, policy); err != nil {
+	// record policy count metric, the missing PolicyModules being reported
+	// through the PolicyModuleResolved condition
+	module, err := policiesv1.ResolvePolicyModule(ctx, r.Client, policy)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("cannot resolve the policy module: %w", err)
+	}
+	if err = metrics.RecordPolicyCount(ctx, policy, module); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to record policy mestrics: %w", err)
 	}
 
 	return reconcileResult, reconcileErr
 }
 
+// requeueAfterShortest requeues the policy after the shortest of the given
+// delays. An immediate requeue is kept as it is, since controller-runtime
+// ignores it once RequeueAfter is set: the delays are computed again by the
+// next pass.
+func requeueAfterShortest(result ctrl.Result, requeueAfters ...time.Duration) ctrl.Result {
+	if result.Requeue && result.RequeueAfter == 0 {
+		return result
+	}
+	for _, requeueAfter := range requeueAfters {
+		result.RequeueAfter = shortestRequeueAfter(result.RequeueAfter, requeueAfter)
+	}
+
+	return result
+}
+
 func (r *policySubReconciler) reconcilePolicy(ctx context.Context, policy policiesv1.Policy) (ctrl.Result, error) {
+	policy.GetStatus().EffectiveNamespaceSelector = r.namespaceSelector(policy)
+	if policy.IsSuspended() {
+		return r.reconcileSuspendedPolicy(ctx, policy)
+	}
+
 	apimeta.SetStatusCondition(
 		&policy.GetStatus().Conditions,
 		metav1.Condition{
@@ -94,6 +153,26 @@ func (r *policySubReconciler) reconcilePolicy(ctx context.Context, policy polici
 		policy.SetStatus(policiesv1.PolicyStatusPending)
 	}
 
+	modulesResolved, err := r.reconcilePolicyModules(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	policyRefsResolved, err := r.reconcilePolicyRefs(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !modulesResolved || !policyRefsResolved {
+		// The policy is dropped from the configuration of its Policy Server
+		// until its references are resolved: its webhook is removed, otherwise
+		// the requests it matches would be sent to a Policy Server unable to
+		// evaluate them.
+		if err = r.reconcileWebhookConfigurationDeletion(ctx, policy); err != nil {
+			return ctrl.Result{}, errors.Join(errors.New("cannot delete the webhook of the policy with unresolved references"), err)
+		}
+		policy.SetStatus(policiesv1.PolicyStatusPending)
+		return ctrl.Result{RequeueAfter: constants.TimeToRequeuePolicyReconciliation}, nil
+	}
+
 	policyServerDeployment := appsv1.Deployment{}
 	if err = r.Get(ctx, types.NamespacedName{Namespace: r.deploymentsNamespace, Name: policyServerDeploymentName(policy.GetPolicyServer())}, &policyServerDeployment); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -130,29 +209,52 @@ func (r *policySubReconciler) reconcilePolicy(ctx context.Context, policy polici
 		return ctrl.Result{}, errors.Join(errors.New("cannot find policy server secret"), err)
 	}
 
-	if policy.IsMutating() {
-		if err = r.reconcileMutatingWebhookConfiguration(ctx, policy, &secret, policyServer.NameWithPrefix()); err != nil {
-			return ctrl.Result{}, errors.Join(errors.New("error reconciling mutating webhook"), err)
-		}
-	} else {
-		if err = r.reconcileValidatingWebhookConfiguration(ctx, policy, &secret, policyServer.NameWithPrefix()); err != nil {
-			return ctrl.Result{}, errors.Join(errors.New("error reconciling validating webhook"), err)
-		}
+	exceptions, err := r.getPolicyExceptions(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, errors.Join(errors.New("cannot get policy exceptions"), err)
 	}
+
+	if err = r.reconcileAdmissionBackend(ctx, policy, &secret, policyServer, exceptions); err != nil {
+		return ctrl.Result{}, errors.Join(errors.New("error reconciling webhook"), err)
+	}
+	r.setPolicyExceptionsStatus(policy, exceptions)
 	setPolicyAsActive(policy)
 
+	return ctrl.Result{RequeueAfter: nextExceptionExpiry(exceptions)}, nil
+}
+
+// reconcileSuspendedPolicy removes the webhook of the suspended policy. When
+// the policy is unsuspended, the webhook is registered again once the policy
+// is uniquely reachable.
+func (r *policySubReconciler) reconcileSuspendedPolicy(ctx context.Context, policy policiesv1.Policy) (ctrl.Result, error) {
+	if err := r.reconcileWebhookConfigurationDeletion(ctx, policy); err != nil {
+		return ctrl.Result{}, errors.Join(errors.New("cannot delete the webhook of the suspended policy"), err)
+	}
+	if err := r.reconcileValidatingAdmissionPolicyDeletion(ctx, policy); err != nil {
+		return ctrl.Result{}, errors.Join(errors.New("cannot delete the validating admission policy of the suspended policy"), err)
+	}
+
+	policy.SetStatus(policiesv1.PolicyStatusSuspended)
+	r.setPolicyExceptionsStatus(policy, nil)
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyActive),
+			Status:  metav1.ConditionFalse,
+			Reason:  "PolicySuspended",
+			Message: "The policy is suspended, its webhook has been removed",
+		},
+	)
+
 	return ctrl.Result{}, nil
 }
 
 func (r *policySubReconciler) reconcilePolicyDeletion(ctx context.Context, policy policiesv1.Policy) (ctrl.Result, error) {
-	if policy.IsMutating() {
-		if err := r.reconcileMutatingWebhookConfigurationDeletion(ctx, policy); err != nil {
-			return ctrl.Result{}, err
-		}
-	} else {
-		if err := r.reconcileValidatingWebhookConfigurationDeletion(ctx, policy); err != nil {
-			return ctrl.Result{}, err
-		}
+	if err := r.reconcileWebhookConfigurationDeletion(ctx, policy); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileValidatingAdmissionPolicyDeletion(ctx, policy); err != nil {
+		return ctrl.Result{}, err
 	}
 	// Remove the old finalizer used to ensure that the policy server created
 	// before this controller version is delete as well. As the upgrade path
@@ -167,6 +269,19 @@ func (r *policySubReconciler) reconcilePolicyDeletion(ctx context.Context, polic
 	return ctrl.Result{}, nil
 }
 
+// updatePolicySpec updates the policy spec and metadata. The update response
+// carries the stored status, which would drop the conditions already set
+// during this reconciliation, hence the in-memory status is restored.
+func (r *policySubReconciler) updatePolicySpec(ctx context.Context, policy policiesv1.Policy) error {
+	status := policy.GetStatus().DeepCopy()
+	if err := r.Update(ctx, policy); err != nil {
+		return err
+	}
+	*policy.GetStatus() = *status
+
+	return nil
+}
+
 func (r *policySubReconciler) setPolicyModeStatus(ctx context.Context, policy policiesv1.Policy) error {
 	policyServerDeployment := appsv1.Deployment{}
 	policyServerDeploymentName := policyServerDeploymentName(policy.GetPolicyServer())
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+// reconcileBreakGlass tracks the break-glass downgrade of the policy mode: it
+// records its use, and reverts the policy to protect mode at its deadline.
+// It returns the duration until the deadline, and whether the downgrade is
+// active.
+func (r *policySubReconciler) reconcileBreakGlass(ctx context.Context, policy policiesv1.Policy) (time.Duration, bool, error) {
+	breakGlass, err := policiesv1.GetBreakGlass(policy)
+	if err != nil {
+		r.Log.Error(err, "Invalid break-glass annotations, ignoring them", "policy", policy.GetName())
+	}
+	if breakGlass == nil || policy.GetPolicyMode() == policiesv1.PolicyModeProtect {
+		setBreakGlassInactiveCondition(policy, "BreakGlassInactive", "The policy mode is not downgraded")
+		return 0, false, nil
+	}
+
+	if !time.Now().Before(breakGlass.Until) {
+		previousMode := policy.GetPolicyMode()
+		policy.SetPolicyMode(policiesv1.PolicyModeProtect)
+		annotations := policy.GetAnnotations()
+		delete(annotations, constants.BreakGlassReasonAnnotationKey)
+		delete(annotations, constants.BreakGlassUntilAnnotationKey)
+		policy.SetAnnotations(annotations)
+		if err = r.updatePolicySpec(ctx, policy); err != nil {
+			return 0, false, fmt.Errorf("cannot revert the policy to protect mode: %w", err)
+		}
+		r.recorder.Eventf(policy, corev1.EventTypeNormal, "BreakGlassReverted",
+			"Policy mode reverted from %s to protect at the break-glass deadline", previousMode)
+		setBreakGlassInactiveCondition(policy, "BreakGlassReverted", "The policy mode was reverted to protect at the break-glass deadline")
+
+		return 0, false, nil
+	}
+
+	message := fmt.Sprintf("Policy mode downgraded to %s until %s: %s",
+		policy.GetPolicyMode(), breakGlass.Until.UTC().Format(time.RFC3339), breakGlass.Reason)
+	condition := apimeta.FindStatusCondition(policy.GetStatus().Conditions, string(policiesv1.PolicyBreakGlass))
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Message != message {
+		r.recorder.Event(policy, corev1.EventTypeWarning, "BreakGlassActivated", message)
+	}
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyBreakGlass),
+			Status:  metav1.ConditionTrue,
+			Reason:  "BreakGlassActive",
+			Message: message,
+		},
+	)
+
+	return time.Until(breakGlass.Until) + time.Second, true, nil
+}
+
+// setBreakGlassInactiveCondition marks the break-glass condition as false.
+// When the policy mode is no longer downgraded for any other reason than the
+// deadline, the condition is only updated if it was true, so that the last
+// use of the break-glass downgrade is kept in the status.
+func setBreakGlassInactiveCondition(policy policiesv1.Policy, reason, message string) {
+	if reason != "BreakGlassReverted" && !apimeta.IsStatusConditionTrue(policy.GetStatus().Conditions, string(policiesv1.PolicyBreakGlass)) {
+		return
+	}
+
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyBreakGlass),
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		},
+	)
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Policy break-glass", func() {
+	ctx := context.Background()
+
+	var recorder *record.FakeRecorder
+	var subReconciler *policySubReconciler
+
+	BeforeEach(func() {
+		subReconciler, recorder = newTestPolicySubReconciler()
+	})
+
+	breakGlassPolicy := func(until time.Time) *policiesv1.ClusterAdmissionPolicy {
+		return policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithMode(policiesv1.PolicyModeMonitor).
+			WithAnnotations(map[string]string{
+				constants.BreakGlassReasonAnnotationKey: "incident",
+				constants.BreakGlassUntilAnnotationKey:  until.UTC().Format(time.RFC3339),
+			}).
+			Build()
+	}
+
+	It("should record the break-glass downgrade", func() {
+		policy := breakGlassPolicy(time.Now().Add(time.Hour))
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		requeueAfter, active, err := subReconciler.reconcileBreakGlass(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(active).To(BeTrue())
+		Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(apimeta.IsStatusConditionTrue(policy.Status.Conditions, string(policiesv1.PolicyBreakGlass))).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("BreakGlassActivated")))
+
+		_, _, err = subReconciler.reconcileBreakGlass(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should revert the policy to protect mode at the deadline", func() {
+		policy := breakGlassPolicy(time.Now().Add(-time.Minute))
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		_, active, err := subReconciler.reconcileBreakGlass(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(active).To(BeFalse())
+		Expect(apimeta.IsStatusConditionFalse(policy.Status.Conditions, string(policiesv1.PolicyBreakGlass))).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("BreakGlassReverted")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(policy.Spec.Mode).To(Equal(policiesv1.PolicyModeProtect))
+		Expect(policy.GetAnnotations()).ToNot(HaveKey(constants.BreakGlassReasonAnnotationKey))
+		Expect(policy.GetAnnotations()).ToNot(HaveKey(constants.BreakGlassUntilAnnotationKey))
+	})
+})
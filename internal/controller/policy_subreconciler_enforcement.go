@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// reconcileEnforcement drives the policy mode through the transitions of its
+// enforcement schedule. The transitions due are applied to the policy spec,
+// and the next one is published in the policy status. The next transition is
+// announced when it is near. It returns the duration until the next
+// enforcement step.
+func (r *policySubReconciler) reconcileEnforcement(ctx context.Context, policy policiesv1.Policy) (time.Duration, error) {
+	enforcement := policy.GetEnforcement()
+	if enforcement == nil {
+		policy.GetStatus().NextModeTransition = nil
+		apimeta.RemoveStatusCondition(&policy.GetStatus().Conditions, string(policiesv1.PolicyModeTransitionImminent))
+		return 0, nil
+	}
+
+	now := time.Now()
+	creationTime := policy.GetCreationTimestamp().Time
+
+	if scheduledMode := enforcement.ScheduledMode(creationTime, now); scheduledMode.IsStricterThan(policy.GetPolicyMode()) {
+		previousMode := policy.GetPolicyMode()
+		policy.SetPolicyMode(scheduledMode)
+		if err := r.updatePolicySpec(ctx, policy); err != nil {
+			return 0, fmt.Errorf("cannot update policy mode: %w", err)
+		}
+		r.recorder.Eventf(policy, corev1.EventTypeNormal, "PolicyModeTransitioned",
+			"Policy mode transitioned from %s to %s as scheduled by the enforcement", previousMode, scheduledMode)
+	}
+
+	nextTransition := enforcement.NextTransition(creationTime, now, policy.GetPolicyMode())
+	if nextTransition == nil {
+		policy.GetStatus().NextModeTransition = nil
+		apimeta.RemoveStatusCondition(&policy.GetStatus().Conditions, string(policiesv1.PolicyModeTransitionImminent))
+		return 0, nil
+	}
+	policy.GetStatus().NextModeTransition = nextTransition
+
+	message := fmt.Sprintf("Policy mode transitions from %s to %s at %s",
+		policy.GetPolicyMode(), nextTransition.Mode, nextTransition.Time.UTC().Format(time.RFC3339))
+	untilTransition := time.Until(nextTransition.Time.Time)
+	if untilTransition > constants.TimeToAnnouncePolicyModeTransition {
+		setPolicyModeTransitionImminentCondition(policy, metav1.ConditionFalse, "PolicyModeTransitionScheduled", message)
+
+		// Requeue when the transition is announced.
+		return untilTransition - constants.TimeToAnnouncePolicyModeTransition, nil
+	}
+
+	if condition := apimeta.FindStatusCondition(policy.GetStatus().Conditions, string(policiesv1.PolicyModeTransitionImminent)); condition == nil ||
+		condition.Status != metav1.ConditionTrue || condition.Message != message {
+		r.recorder.Event(policy, corev1.EventTypeNormal, "PolicyModeTransitionScheduled", message)
+	}
+	setPolicyModeTransitionImminentCondition(policy, metav1.ConditionTrue, "PolicyModeTransitionImminent", message)
+
+	return untilTransition + time.Second, nil
+}
+
+func setPolicyModeTransitionImminentCondition(policy policiesv1.Policy, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyModeTransitionImminent),
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		},
+	)
+}
+
+// shortestRequeueAfter returns the shortest of the given non-zero durations,
+// or zero when both are zero. The steps scheduling a deadline requeue one
+// second after it, the policy would be reconciled before it is due otherwise.
+func shortestRequeueAfter(a, b time.Duration) time.Duration {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+
+	return a
+}
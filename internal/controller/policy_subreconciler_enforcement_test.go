@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+var _ = Describe("Policy enforcement", func() {
+	ctx := context.Background()
+
+	var recorder *record.FakeRecorder
+	var subReconciler *policySubReconciler
+
+	BeforeEach(func() {
+		subReconciler, recorder = newTestPolicySubReconciler()
+	})
+
+	It("should apply the transitions due and schedule the next one", func() {
+		protectAt := metav1.NewTime(time.Now().Add(time.Hour)).Rfc3339Copy()
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithMode(policiesv1.PolicyModeMonitor).
+			Build()
+		policy.Spec.Enforcement = &policiesv1.PolicyEnforcement{
+			ProtectAt:  &protectAt,
+			WarnPeriod: &metav1.Duration{Duration: 2 * time.Hour},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		requeueAfter, err := subReconciler.reconcileEnforcement(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+		Expect(policy.Status.NextModeTransition).ToNot(BeNil())
+		Expect(policy.Status.NextModeTransition.Mode).To(Equal(policiesv1.PolicyModeProtect))
+		Expect(policy.Status.NextModeTransition.Time.Equal(&protectAt)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyModeTransitioned")))
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyModeTransitionScheduled")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(policy.Spec.Mode).To(Equal(policiesv1.PolicyModeWarn))
+	})
+
+	It("should keep the conditions set before applying a transition", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithMode(policiesv1.PolicyModeMonitor).
+			Build()
+		policy.Spec.Enforcement = &policiesv1.PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: time.Hour}}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		policy.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+		setPolicyExpiringCondition(policy, metav1.ConditionFalse, "PolicyNotExpiring", "The policy does not expire soon")
+		_, err := subReconciler.reconcileEnforcement(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyModeTransitioned")))
+		Expect(policy.Spec.Mode).To(Equal(policiesv1.PolicyModeProtect))
+		Expect(apimeta.IsStatusConditionFalse(policy.Status.Conditions, string(policiesv1.PolicyExpiring))).To(BeTrue())
+	})
+
+	It("should announce the next transition only when it is near", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithMode(policiesv1.PolicyModeMonitor).
+			Build()
+		policy.Spec.Enforcement = &policiesv1.PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: 7 * 24 * time.Hour}}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		requeueAfter, err := subReconciler.reconcileEnforcement(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeueAfter).To(BeNumerically("~", 6*24*time.Hour, time.Minute))
+		Expect(policy.Status.NextModeTransition).ToNot(BeNil())
+		Expect(apimeta.IsStatusConditionFalse(policy.Status.Conditions, string(policiesv1.PolicyModeTransitionImminent))).To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+
+		policy.Spec.Enforcement = &policiesv1.PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: time.Hour}}
+		_, err = subReconciler.reconcileEnforcement(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(apimeta.IsStatusConditionTrue(policy.Status.Conditions, string(policiesv1.PolicyModeTransitionImminent))).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyModeTransitionScheduled")))
+
+		_, err = subReconciler.reconcileEnforcement(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should not schedule transitions once the policy is in protect mode", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithMode(policiesv1.PolicyModeProtect).
+			Build()
+		policy.Spec.Enforcement = &policiesv1.PolicyEnforcement{MonitorPeriod: &metav1.Duration{Duration: time.Hour}}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		requeueAfter, err := subReconciler.reconcileEnforcement(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeueAfter).To(BeZero())
+		Expect(policy.Status.NextModeTransition).To(BeNil())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policyexceptions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=clusterpolicyexceptions,verbs=get;list;watch
+
+// maxWebhookMatchConditions is the maximum number of match conditions of a
+// webhook accepted by Kubernetes.
+const maxWebhookMatchConditions = 64
+
+// getPolicyExceptions returns the exceptions applied to the policy: the
+// PolicyExceptions and ClusterPolicyExceptions targeting it that are not
+// expired. The exceptions that do not fit in the match conditions of the
+// webhook are left out.
+func (r *policySubReconciler) getPolicyExceptions(ctx context.Context, policy policiesv1.Policy) ([]policiesv1.Exception, error) {
+	// Only the PolicyExceptions of the same namespace can target a
+	// namespaced policy. The namespace of the cluster-wide policies is empty,
+	// hence the PolicyExceptions of all the namespaces are listed.
+	policyExceptions := &policiesv1.PolicyExceptionList{}
+	if err := r.List(ctx, policyExceptions, client.InNamespace(policy.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("cannot list PolicyExceptions: %w", err)
+	}
+	clusterPolicyExceptions := &policiesv1.ClusterPolicyExceptionList{}
+	if err := r.List(ctx, clusterPolicyExceptions); err != nil {
+		return nil, fmt.Errorf("cannot list ClusterPolicyExceptions: %w", err)
+	}
+
+	candidates := []policiesv1.Exception{}
+	for i := range policyExceptions.Items {
+		candidates = append(candidates, &policyExceptions.Items[i])
+	}
+	for i := range clusterPolicyExceptions.Items {
+		candidates = append(candidates, &clusterPolicyExceptions.Items[i])
+	}
+
+	now := time.Now()
+	exceptions := []policiesv1.Exception{}
+	for _, exception := range candidates {
+		if exception.GetDeletionTimestamp() != nil || exception.GetExceptionSpec().IsExpired(now) {
+			continue
+		}
+		if policiesv1.ExceptionTargets(exception, policy) {
+			exceptions = append(exceptions, exception)
+		}
+	}
+
+	// Sort the exceptions, the webhook configurations would be updated at
+	// every reconciliation otherwise.
+	slices.SortFunc(exceptions, func(a, b policiesv1.Exception) int {
+		return strings.Compare(exceptionKey(a), exceptionKey(b))
+	})
+
+	available := max(maxWebhookMatchConditions-len(policy.GetMatchConditions()), 0)
+	if len(exceptions) > available {
+		r.Log.Error(nil, "Too many exceptions for the policy webhook, some exceptions are not applied",
+			"policy", policy.GetName(), "exceptions", len(exceptions), "applied", available)
+		exceptions = exceptions[:available]
+	}
+
+	return exceptions, nil
+}
+
+// setPolicyExceptionsStatus lists the exceptions applied to the policy
+// webhook in the policy status.
+func (r *policySubReconciler) setPolicyExceptionsStatus(policy policiesv1.Policy, exceptions []policiesv1.Exception) {
+	if !r.featureGateAdmissionWebhookMatchConditions || len(exceptions) == 0 {
+		policy.GetStatus().Exceptions = nil
+		return
+	}
+
+	references := make([]policiesv1.PolicyExceptionReference, 0, len(exceptions))
+	for _, exception := range exceptions {
+		references = append(references, policiesv1.PolicyExceptionReference{
+			Kind:      exception.GetKind(),
+			Name:      exception.GetName(),
+			Namespace: exception.GetNamespace(),
+			ExpiresAt: exception.GetExceptionSpec().ExpiresAt.DeepCopy(),
+		})
+	}
+	policy.GetStatus().Exceptions = references
+}
+
+// nextExceptionExpiry returns the duration until the first of the given
+// exceptions expires, or zero when none of them expires.
+func nextExceptionExpiry(exceptions []policiesv1.Exception) time.Duration {
+	var next time.Duration
+	for _, exception := range exceptions {
+		expiresAt := exception.GetExceptionSpec().ExpiresAt
+		if expiresAt == nil {
+			continue
+		}
+		// Requeue right after the expiry, the exception is still applied
+		// otherwise.
+		untilExpiry := time.Until(expiresAt.Time) + time.Second
+		if next == 0 || untilExpiry < next {
+			next = untilExpiry
+		}
+	}
+
+	return next
+}
+
+func exceptionKey(exception policiesv1.Exception) string {
+	return exception.GetKind() + "/" + exception.GetNamespace() + "/" + exception.GetName()
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+// reconcileExpiration applies the expiration of the policy: once it expires,
+// the policy is suspended or deleted, according to the expiration action.
+// Warnings are emitted when the expiration is near. It returns the duration
+// until the next expiration step, and whether the policy has been deleted.
+func (r *policySubReconciler) reconcileExpiration(ctx context.Context, policy policiesv1.Policy) (time.Duration, bool, error) {
+	expiration := policy.GetExpiration()
+	if expiration == nil {
+		apimeta.RemoveStatusCondition(&policy.GetStatus().Conditions, string(policiesv1.PolicyExpiring))
+		return 0, false, nil
+	}
+
+	expiryTime := expiration.ExpiryTime(policy.GetCreationTimestamp().Time)
+	expiry := expiryTime.UTC().Format(time.RFC3339)
+	untilExpiry := time.Until(expiryTime)
+
+	if untilExpiry <= 0 {
+		if expiration.GetAction() == policiesv1.PolicyExpirationActionDelete {
+			r.recorder.Eventf(policy, corev1.EventTypeNormal, "PolicyExpired", "Policy expired at %s, deleting it", expiry)
+			if err := r.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+				return 0, false, fmt.Errorf("cannot delete the expired policy: %w", err)
+			}
+
+			return 0, true, nil
+		}
+
+		if !policy.IsSuspended() {
+			policy.SetSuspended(true)
+			if err := r.updatePolicySpec(ctx, policy); err != nil {
+				return 0, false, fmt.Errorf("cannot suspend the expired policy: %w", err)
+			}
+			r.recorder.Eventf(policy, corev1.EventTypeNormal, "PolicyExpired", "Policy expired at %s, suspending it", expiry)
+		}
+		setPolicyExpiringCondition(policy, metav1.ConditionTrue, "PolicyExpired",
+			fmt.Sprintf("The policy expired at %s", expiry))
+
+		return 0, false, nil
+	}
+
+	if untilExpiry > constants.TimeToWarnBeforePolicyExpiration {
+		setPolicyExpiringCondition(policy, metav1.ConditionFalse, "PolicyExpirationScheduled",
+			fmt.Sprintf("The policy expires at %s", expiry))
+
+		// Requeue when the warnings start.
+		return untilExpiry - constants.TimeToWarnBeforePolicyExpiration, false, nil
+	}
+
+	message := fmt.Sprintf("The policy expires at %s, it will then be %s", expiry, expirationActionPastTense(expiration.GetAction()))
+	if !apimeta.IsStatusConditionTrue(policy.GetStatus().Conditions, string(policiesv1.PolicyExpiring)) {
+		r.recorder.Event(policy, corev1.EventTypeWarning, "PolicyExpiringSoon", message)
+	}
+	setPolicyExpiringCondition(policy, metav1.ConditionTrue, "PolicyExpiringSoon", message)
+
+	// Requeue exactly at the expiry time.
+	return untilExpiry, false, nil
+}
+
+func setPolicyExpiringCondition(policy policiesv1.Policy, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyExpiring),
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		},
+	)
+}
+
+func expirationActionPastTense(action policiesv1.PolicyExpirationAction) string {
+	if action == policiesv1.PolicyExpirationActionDelete {
+		return "deleted"
+	}
+
+	return "suspended"
+}
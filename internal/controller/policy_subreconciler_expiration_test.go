@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Policy expiration", func() {
+	ctx := context.Background()
+
+	var recorder *record.FakeRecorder
+	var subReconciler *policySubReconciler
+
+	BeforeEach(func() {
+		subReconciler, recorder = newTestPolicySubReconciler()
+	})
+
+	expiringPolicy := func(ttl time.Duration, action policiesv1.PolicyExpirationAction) *policiesv1.ClusterAdmissionPolicy {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().WithName(newName("policy")).Build()
+		policy.Spec.Expiration = &policiesv1.PolicyExpiration{
+			TTL:    &metav1.Duration{Duration: ttl},
+			Action: action,
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		return policy
+	}
+
+	It("should requeue when the expiration warnings start", func() {
+		policy := expiringPolicy(constants.TimeToWarnBeforePolicyExpiration+time.Hour, policiesv1.PolicyExpirationActionSuspend)
+
+		requeueAfter, deleted, err := subReconciler.reconcileExpiration(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+		Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(apimeta.IsStatusConditionFalse(policy.Status.Conditions, string(policiesv1.PolicyExpiring))).To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should warn once before the expiration and requeue at the expiry", func() {
+		policy := expiringPolicy(time.Hour, policiesv1.PolicyExpirationActionSuspend)
+
+		requeueAfter, _, err := subReconciler.reconcileExpiration(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(apimeta.IsStatusConditionTrue(policy.Status.Conditions, string(policiesv1.PolicyExpiring))).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyExpiringSoon")))
+
+		_, _, err = subReconciler.reconcileExpiration(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should suspend the expired policy", func() {
+		policy := expiringPolicy(time.Hour, policiesv1.PolicyExpirationActionSuspend)
+		policy.Spec.Expiration.TTL = nil
+		policy.Spec.Expiration.ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+		_, deleted, err := subReconciler.reconcileExpiration(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyExpired")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+		Expect(policy.Spec.Suspended).To(BeTrue())
+	})
+
+	It("should delete the expired policy", func() {
+		policy := expiringPolicy(time.Hour, policiesv1.PolicyExpirationActionDelete)
+		policy.Spec.Expiration.TTL = &metav1.Duration{Duration: time.Nanosecond}
+
+		_, deleted, err := subReconciler.reconcileExpiration(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyExpired")))
+
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)
+			return apierrors.IsNotFound(err) || policy.GetDeletionTimestamp() != nil
+		}).Should(BeTrue())
+	})
+})
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+// reconcilePolicyModules checks that the PolicyModules referenced by the
+// policy exist. It returns false when some of them are missing: the policy is
+// not served by its Policy Server until they are created.
+func (r *policySubReconciler) reconcilePolicyModules(ctx context.Context, policy policiesv1.Policy) (bool, error) {
+	moduleRefs := policiesv1.ModuleRefs(policy)
+	if len(moduleRefs) == 0 {
+		apimeta.RemoveStatusCondition(&policy.GetStatus().Conditions, string(policiesv1.PolicyModuleResolved))
+		return true, nil
+	}
+
+	missingModuleRefs := []string{}
+	for _, moduleRef := range moduleRefs {
+		var policyModule policiesv1.PolicyModule
+		if err := r.Get(ctx, client.ObjectKey{Name: moduleRef}, &policyModule); err != nil {
+			if apierrors.IsNotFound(err) {
+				missingModuleRefs = append(missingModuleRefs, moduleRef)
+				continue
+			}
+			return false, errors.Join(fmt.Errorf("cannot get PolicyModule %s", moduleRef), err)
+		}
+	}
+
+	if len(missingModuleRefs) != 0 {
+		apimeta.SetStatusCondition(
+			&policy.GetStatus().Conditions,
+			metav1.Condition{
+				Type:    string(policiesv1.PolicyModuleResolved),
+				Status:  metav1.ConditionFalse,
+				Reason:  "PolicyModuleNotFound",
+				Message: "The referenced PolicyModules do not exist: " + strings.Join(missingModuleRefs, ", "),
+			},
+		)
+		return false, nil
+	}
+
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyModuleResolved),
+			Status:  metav1.ConditionTrue,
+			Reason:  "PolicyModuleResolved",
+			Message: "The referenced PolicyModules exist",
+		},
+	)
+
+	return true, nil
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+// reconcilePolicyRefs checks that the policies referenced by the members of
+// the policy group exist. It returns false when some of them are missing: the
+// policy group is not served by its Policy Server until they are created.
+func (r *policySubReconciler) reconcilePolicyRefs(ctx context.Context, policy policiesv1.Policy) (bool, error) {
+	policyGroup, ok := policy.(policiesv1.PolicyGroup)
+	if !ok {
+		return true, nil
+	}
+	policyRefs := policiesv1.PolicyRefs(policyGroup)
+	if len(policyRefs) == 0 {
+		apimeta.RemoveStatusCondition(&policy.GetStatus().Conditions, string(policiesv1.PolicyRefsResolved))
+		return true, nil
+	}
+
+	missingPolicyRefs := []string{}
+	for _, policyRef := range policyRefs {
+		if _, err := policiesv1.GetReferencedPolicy(ctx, r.Client, policyRef); err != nil {
+			if apierrors.IsNotFound(err) {
+				missingPolicyRefs = append(missingPolicyRefs, policyRef.Name)
+				continue
+			}
+			return false, errors.Join(fmt.Errorf("cannot get the referenced policy %s", policyRef), err)
+		}
+	}
+
+	if len(missingPolicyRefs) != 0 {
+		apimeta.SetStatusCondition(
+			&policy.GetStatus().Conditions,
+			metav1.Condition{
+				Type:    string(policiesv1.PolicyRefsResolved),
+				Status:  metav1.ConditionFalse,
+				Reason:  "PolicyNotFound",
+				Message: "The referenced policies do not exist: " + strings.Join(missingPolicyRefs, ", "),
+			},
+		)
+		return false, nil
+	}
+
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyRefsResolved),
+			Status:  metav1.ConditionTrue,
+			Reason:  "PolicyRefsResolved",
+			Message: "The referenced policies exist",
+		},
+	)
+
+	return true, nil
+}
+
+// findPolicyGroupsForReferencedPolicy returns a reconcile request for every
+// policy group with members referencing the given policy.
+func findPolicyGroupsForReferencedPolicy(ctx context.Context, k8sClient client.Client, logger logr.Logger, object client.Object) []reconcile.Request {
+	policy, ok := object.(policiesv1.Policy)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	policyGroups, err := policiesv1.ListReferencingPolicyGroups(ctx, k8sClient, policy)
+	if err != nil {
+		logger.Error(err, "cannot list the policy groups referencing the policy", "policy", policy.GetUniqueName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(policyGroups))
+	for _, policyGroup := range policyGroups {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policyGroup)})
+	}
+
+	return requests
+}
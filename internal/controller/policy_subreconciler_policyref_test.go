@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+var _ = Describe("Policy group members referencing policies", func() {
+	ctx := context.Background()
+
+	var subReconciler *policySubReconciler
+	var referencedPolicy *policiesv1.ClusterAdmissionPolicy
+	var policyGroup *policiesv1.ClusterAdmissionPolicyGroup
+
+	BeforeEach(func() {
+		subReconciler = &policySubReconciler{
+			Client:               k8sClient,
+			Log:                  GinkgoLogr,
+			deploymentsNamespace: deploymentsNamespace,
+		}
+
+		referencedPolicy = policiesv1.NewClusterAdmissionPolicyFactory().WithName(newName("policy")).Build()
+		referencedPolicy.Spec.Settings = runtime.RawExtension{Raw: []byte(`{"allowed": true}`)}
+		Expect(k8sClient.Create(ctx, referencedPolicy)).To(Succeed())
+
+		policyGroup = policiesv1.NewClusterAdmissionPolicyGroupFactory().WithName(newName("policy-group")).Build()
+		policyGroup.Spec.Policies = policiesv1.PolicyGroupMembersWithContext{
+			"referenced": {PolicyGroupMember: policiesv1.PolicyGroupMember{PolicyRef: referencedPolicy.GetName()}},
+		}
+		Expect(k8sClient.Create(ctx, policyGroup)).To(Succeed())
+	})
+
+	It("should resolve the referenced policies", func() {
+		resolved, err := subReconciler.reconcilePolicyRefs(ctx, policyGroup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(BeTrue())
+		Expect(apimeta.IsStatusConditionTrue(policyGroup.Status.Conditions, string(policiesv1.PolicyRefsResolved))).To(BeTrue())
+
+		references := policyReferences{
+			policies: map[client.ObjectKey]policiesv1.Policy{client.ObjectKeyFromObject(referencedPolicy): referencedPolicy},
+		}
+		members := buildPolicyGroupMembersWithContext(policyGroup, references)
+		Expect(members).To(HaveKey("referenced"))
+		Expect(members["referenced"].Module).To(Equal(referencedPolicy.Spec.Module))
+		Expect(members["referenced"].Settings.Raw).To(MatchJSON(`{"allowed": true}`))
+		Expect(references.missingReferences(policyGroup)).To(BeEmpty())
+	})
+
+	It("should report the missing referenced policies", func() {
+		policyGroup.Spec.Policies["missing"] = policiesv1.PolicyGroupMemberWithContext{
+			PolicyGroupMember: policiesv1.PolicyGroupMember{PolicyRef: newName("missing-policy")},
+		}
+
+		resolved, err := subReconciler.reconcilePolicyRefs(ctx, policyGroup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(BeFalse())
+		Expect(apimeta.IsStatusConditionFalse(policyGroup.Status.Conditions, string(policiesv1.PolicyRefsResolved))).To(BeTrue())
+		Expect(policyReferences{}.missingReferences(policyGroup)).To(HaveLen(2))
+	})
+
+	It("should enqueue the policy groups referencing a policy", func() {
+		Expect(findPolicyGroupsForReferencedPolicy(ctx, k8sClient, GinkgoLogr, referencedPolicy)).
+			To(ConsistOf(HaveField("NamespacedName", client.ObjectKeyFromObject(policyGroup))))
+	})
+})
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+// reconcileServedResources checks that the resources targeted by the policy
+// are served by the cluster. The check is repeated periodically, so that the
+// CRDs removed after the creation of the policy are reported in its status.
+// It returns the duration after which the check has to be repeated.
+func (r *policySubReconciler) reconcileServedResources(policy policiesv1.Policy) time.Duration {
+	if r.restMapper == nil {
+		return 0
+	}
+
+	allErrors, err := policiesv1.ValidateServedResources(policy, r.restMapper)
+	if err != nil {
+		r.Log.Error(err, "Cannot check the resources served by the cluster", "policy", policy.GetUniqueName())
+		return constants.ServedResourcesValidationInterval
+	}
+
+	if len(allErrors) != 0 {
+		apimeta.SetStatusCondition(
+			&policy.GetStatus().Conditions,
+			metav1.Condition{
+				Type:    string(policiesv1.PolicyResourcesServed),
+				Status:  metav1.ConditionFalse,
+				Reason:  "ResourcesNotServed",
+				Message: allErrors.ToAggregate().Error(),
+			},
+		)
+		return constants.ServedResourcesValidationInterval
+	}
+
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyResourcesServed),
+			Status:  metav1.ConditionTrue,
+			Reason:  "ResourcesServed",
+			Message: "The resources targeted by the policy are served by the cluster",
+		},
+	)
+
+	return constants.ServedResourcesValidationInterval
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Resources served by the cluster", func() {
+	var subReconciler *policySubReconciler
+	var restMapper *apimeta.DefaultRESTMapper
+	var policy *policiesv1.ClusterAdmissionPolicy
+
+	BeforeEach(func() {
+		restMapper = apimeta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}, {Group: "example.com", Version: "v1"}})
+		restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, apimeta.RESTScopeNamespace)
+		subReconciler = &policySubReconciler{
+			Log:                  GinkgoLogr,
+			deploymentsNamespace: deploymentsNamespace,
+			restMapper:           restMapper,
+		}
+
+		policy = policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithRules([]admissionregistrationv1.RuleWithOperations{{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+				},
+			}}).
+			WithContextAwareResources([]policiesv1.ContextAwareResource{{APIVersion: "example.com/v1", Kind: "Widget"}}).
+			Build()
+	})
+
+	It("should report the resources that are not served", func() {
+		Expect(subReconciler.reconcileServedResources(policy)).To(Equal(constants.ServedResourcesValidationInterval))
+
+		condition := apimeta.FindStatusCondition(policy.Status.Conditions, string(policiesv1.PolicyResourcesServed))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal("ResourcesNotServed"))
+		Expect(condition.Message).To(ContainSubstring("spec.contextAwareResources[0].kind"))
+	})
+
+	It("should report the resources once they are served", func() {
+		Expect(subReconciler.reconcileServedResources(policy)).To(Equal(constants.ServedResourcesValidationInterval))
+		Expect(apimeta.IsStatusConditionFalse(policy.Status.Conditions, string(policiesv1.PolicyResourcesServed))).To(BeTrue())
+
+		restMapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, apimeta.RESTScopeNamespace)
+		Expect(subReconciler.reconcileServedResources(policy)).To(Equal(constants.ServedResourcesValidationInterval))
+		Expect(apimeta.IsStatusConditionTrue(policy.Status.Conditions, string(policiesv1.PolicyResourcesServed))).To(BeTrue())
+	})
+
+	It("should not check the resources without a RESTMapper", func() {
+		subReconciler.restMapper = nil
+		Expect(subReconciler.reconcileServedResources(policy)).To(BeZero())
+		Expect(apimeta.FindStatusCondition(policy.Status.Conditions, string(policiesv1.PolicyResourcesServed))).To(BeNil())
+	})
+
+	It("should keep the immediate requeues when merging the periodic checks", func() {
+		Expect(requeueAfterShortest(ctrl.Result{Requeue: true}, constants.ServedResourcesValidationInterval)).
+			To(Equal(ctrl.Result{Requeue: true}))
+		Expect(requeueAfterShortest(ctrl.Result{}, constants.ServedResourcesValidationInterval, 0, time.Minute)).
+			To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+		Expect(requeueAfterShortest(ctrl.Result{RequeueAfter: time.Second}, constants.ServedResourcesValidationInterval)).
+			To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	})
+})
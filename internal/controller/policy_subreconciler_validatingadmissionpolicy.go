@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=create;delete;get;list;patch;watch
+
+// celPolicyModuleRepository is the repository of the Kubewarden CEL policy
+// module, whose settings can be translated into a ValidatingAdmissionPolicy.
+const celPolicyModuleRepository = "kubewarden/policies/cel-policy"
+
+// celPolicySettings are the settings of the Kubewarden CEL policy module.
+type celPolicySettings struct {
+	Variables   []admissionregistrationv1.Variable   `json:"variables,omitempty"`
+	Validations []admissionregistrationv1.Validation `json:"validations"`
+}
+
+// isCELPolicyModule returns true when the module is the Kubewarden CEL policy,
+// whatever its registry, tag or digest.
+func isCELPolicyModule(module string) bool {
+	reference := strings.TrimPrefix(module, "registry://")
+	reference, _, _ = strings.Cut(reference, "@")
+	if tagIndex := strings.LastIndex(reference, ":"); tagIndex > strings.LastIndex(reference, "/") {
+		reference = reference[:tagIndex]
+	}
+
+	return strings.HasSuffix(reference, "/"+celPolicyModuleRepository)
+}
+
+// validatingAdmissionPolicySettings returns the CEL settings of the policy
+// when it is enforced through a ValidatingAdmissionPolicy. Otherwise, when the
+// ValidatingAdmissionPolicy backend is requested, it returns the reason why
+// the policy falls back to the webhook backend. The module is the resolved
+// module of the policy.
+func (r *policySubReconciler) validatingAdmissionPolicySettings(policy policiesv1.Policy, module string) (*celPolicySettings, string) {
+	clusterAdmissionPolicy, ok := policy.(*policiesv1.ClusterAdmissionPolicy)
+	if !ok || clusterAdmissionPolicy.GetBackend() != policiesv1.PolicyBackendValidatingAdmissionPolicy {
+		return nil, ""
+	}
+
+	switch {
+	case !r.featureGateValidatingAdmissionPolicy:
+		return nil, "The cluster does not serve ValidatingAdmissionPolicies"
+	case policy.IsMutating():
+		return nil, "Mutating policies cannot be translated into a ValidatingAdmissionPolicy"
+	case !isCELPolicyModule(module):
+		return nil, "The policy module is not the Kubewarden CEL policy"
+	case len(policy.GetContextAwareResources()) > 0:
+		return nil, "Context aware policies cannot be translated into a ValidatingAdmissionPolicy"
+	}
+
+	settings := &celPolicySettings{}
+	if err := json.Unmarshal(policy.GetSettings().Raw, settings); err != nil || len(settings.Validations) == 0 {
+		return nil, "The policy settings have no validations"
+	}
+	expressions := []string{}
+	for _, variable := range settings.Variables {
+		expressions = append(expressions, variable.Expression)
+	}
+	for _, validation := range settings.Validations {
+		expressions = append(expressions, validation.Expression, validation.MessageExpression)
+	}
+	if slices.ContainsFunc(expressions, func(expression string) bool { return strings.Contains(expression, "kw.") }) {
+		return nil, "The policy expressions use the Kubewarden host capabilities"
+	}
+
+	return settings, ""
+}
+
+// reconcileAdmissionBackend enforces the policy through a
+// ValidatingAdmissionPolicy when requested and possible, and through its
+// webhook otherwise. The resources of the other backend are removed once the
+// new ones are in place, so that the policy is always enforced.
+func (r *policySubReconciler) reconcileAdmissionBackend(
+	ctx context.Context,
+	policy policiesv1.Policy,
+	admissionSecret *corev1.Secret,
+	policyServer *policiesv1.PolicyServer,
+	exceptions []policiesv1.Exception,
+) error {
+	module, err := policiesv1.ResolvePolicyModule(ctx, r.Client, policy)
+	if err != nil {
+		return errors.Join(errors.New("cannot resolve the policy module"), err)
+	}
+
+	settings, fallbackReason := r.validatingAdmissionPolicySettings(policy, module)
+	if settings == nil {
+		if fallbackReason == "" {
+			apimeta.RemoveStatusCondition(&policy.GetStatus().Conditions, string(policiesv1.PolicyValidatingAdmissionPolicy))
+		} else {
+			setPolicyValidatingAdmissionPolicyCondition(policy, metav1.ConditionFalse, "WebhookFallback", fallbackReason)
+		}
+		if err := r.reconcileWebhookConfiguration(ctx, policy, admissionSecret, policyServer, exceptions); err != nil {
+			return err
+		}
+
+		return r.reconcileValidatingAdmissionPolicyDeletion(ctx, policy)
+	}
+
+	if err := r.reconcileValidatingAdmissionPolicy(ctx, policy, settings, exceptions); err != nil {
+		return err
+	}
+	setPolicyValidatingAdmissionPolicyCondition(policy, metav1.ConditionTrue, "ValidatingAdmissionPolicyReconciled",
+		"The policy is enforced through a ValidatingAdmissionPolicy")
+
+	return r.reconcileWebhookConfigurationDeletion(ctx, policy)
+}
+
+// reconcileValidatingAdmissionPolicy creates or updates the
+// ValidatingAdmissionPolicy translated from the policy, and its binding.
+func (r *policySubReconciler) reconcileValidatingAdmissionPolicy(
+	ctx context.Context,
+	policy policiesv1.Policy,
+	settings *celPolicySettings,
+	exceptions []policiesv1.Exception,
+) error {
+	name := policy.GetUniqueName()
+	labels := map[string]string{
+		constants.PartOfLabelKey: constants.PartOfLabelValue,
+	}
+	annotations := map[string]string{
+		constants.WebhookConfigurationPolicyNameAnnotationKey:      policy.GetName(),
+		constants.WebhookConfigurationPolicyNamespaceAnnotationKey: policy.GetNamespace(),
+	}
+
+	// Unlike the webhooks, the match conditions of the
+	// ValidatingAdmissionPolicies are always supported.
+	matchConditions := slices.Clone(policy.GetMatchConditions())
+	for _, exception := range exceptions {
+		matchConditions = append(matchConditions, policiesv1.ExceptionMatchCondition(exception))
+	}
+	resourceRules := make([]admissionregistrationv1.NamedRuleWithOperations, 0, len(policy.GetRules()))
+	for _, rule := range policy.GetRules() {
+		resourceRules = append(resourceRules, admissionregistrationv1.NamedRuleWithOperations{RuleWithOperations: rule})
+	}
+
+	validatingAdmissionPolicy := &admissionregistrationv1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, validatingAdmissionPolicy, func() error {
+		validatingAdmissionPolicy.Labels = labels
+		validatingAdmissionPolicy.Annotations = annotations
+		validatingAdmissionPolicy.Spec = admissionregistrationv1.ValidatingAdmissionPolicySpec{
+			MatchConstraints: &admissionregistrationv1.MatchResources{
+				NamespaceSelector: r.namespaceSelector(policy),
+				ObjectSelector:    policy.GetObjectSelector(),
+				ResourceRules:     resourceRules,
+				MatchPolicy:       policy.GetMatchPolicy(),
+			},
+			Validations:     settings.Validations,
+			Variables:       settings.Variables,
+			FailurePolicy:   policy.GetFailurePolicy(),
+			MatchConditions: matchConditions,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot reconcile validating admission policy: %w", err)
+	}
+
+	binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.Client, binding, func() error {
+		binding.Labels = labels
+		binding.Annotations = annotations
+		binding.Spec.PolicyName = name
+		binding.Spec.ValidationActions = validationActions(policy.GetPolicyMode())
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot reconcile validating admission policy binding: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileValidatingAdmissionPolicyDeletion deletes the
+// ValidatingAdmissionPolicy of the policy, and its binding.
+func (r *policySubReconciler) reconcileValidatingAdmissionPolicyDeletion(ctx context.Context, policy policiesv1.Policy) error {
+	if !r.featureGateValidatingAdmissionPolicy {
+		return nil
+	}
+
+	objects := []client.Object{
+		&admissionregistrationv1.ValidatingAdmissionPolicyBinding{},
+		&admissionregistrationv1.ValidatingAdmissionPolicy{},
+	}
+	for _, object := range objects {
+		err := r.Get(ctx, types.NamespacedName{Name: policy.GetUniqueName()}, object)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot retrieve validating admission policy: %w", err)
+		}
+		if err = r.Delete(ctx, object); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete validating admission policy: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validationActions returns the actions of the ValidatingAdmissionPolicy
+// binding matching the mode of the policy.
+func validationActions(mode policiesv1.PolicyMode) []admissionregistrationv1.ValidationAction {
+	switch mode {
+	case policiesv1.PolicyModeWarn:
+		return []admissionregistrationv1.ValidationAction{admissionregistrationv1.Warn}
+	case policiesv1.PolicyModeMonitor:
+		return []admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit}
+	default:
+		return []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}
+	}
+}
+
+func setPolicyValidatingAdmissionPolicyCondition(policy policiesv1.Policy, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(
+		&policy.GetStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyValidatingAdmissionPolicy),
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		},
+	)
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("ValidatingAdmissionPolicy backend", func() {
+	ctx := context.Background()
+
+	var policyServer *policiesv1.PolicyServer
+	var admissionSecret *corev1.Secret
+	var subReconciler *policySubReconciler
+
+	BeforeEach(func() {
+		policyServer = policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		admissionSecret = &corev1.Secret{
+			Data: map[string][]byte{constants.CARootCert: []byte("ca")},
+		}
+		subReconciler = &policySubReconciler{
+			Client:                               k8sClient,
+			Log:                                  GinkgoLogr,
+			deploymentsNamespace:                 deploymentsNamespace,
+			featureGateValidatingAdmissionPolicy: true,
+		}
+	})
+
+	celPolicy := func(settings string) *policiesv1.ClusterAdmissionPolicy {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("cel-policy")).
+			WithPolicyServer(policyServer.GetName()).
+			WithMode(policiesv1.PolicyModeMonitor).
+			Build()
+		policy.Spec.Module = "registry://ghcr.io/kubewarden/policies/cel-policy:v1.0.0"
+		policy.Spec.Settings = runtime.RawExtension{Raw: []byte(settings)}
+		policy.Spec.Backend = policiesv1.PolicyBackendValidatingAdmissionPolicy
+
+		return policy
+	}
+
+	getValidatingAdmissionPolicy := func(name string) (*admissionregistrationv1.ValidatingAdmissionPolicy, error) {
+		validatingAdmissionPolicy := &admissionregistrationv1.ValidatingAdmissionPolicy{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: name}, validatingAdmissionPolicy)
+
+		return validatingAdmissionPolicy, err
+	}
+
+	It("should translate the CEL policy into a ValidatingAdmissionPolicy", func() {
+		policy := celPolicy(`{"validations": [{"expression": "object.spec.replicas <= 5", "message": "too many replicas"}]}`)
+
+		Expect(subReconciler.reconcileAdmissionBackend(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		validatingAdmissionPolicy, err := getValidatingAdmissionPolicy(policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validatingAdmissionPolicy.Spec.Validations).To(HaveLen(1))
+		Expect(validatingAdmissionPolicy.Spec.Validations[0].Expression).To(Equal("object.spec.replicas <= 5"))
+		Expect(validatingAdmissionPolicy.Spec.MatchConstraints.ResourceRules).To(HaveLen(len(policy.GetRules())))
+
+		binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: policy.GetUniqueName()}, binding)).To(Succeed())
+		Expect(binding.Spec.PolicyName).To(Equal(policy.GetUniqueName()))
+		Expect(binding.Spec.ValidationActions).To(Equal([]admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit}))
+
+		Expect(apimeta.IsStatusConditionTrue(policy.Status.Conditions, string(policiesv1.PolicyValidatingAdmissionPolicy))).To(BeTrue())
+		_, err = getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should translate the CEL policy referencing a PolicyModule", func() {
+		policyModule := &policiesv1.PolicyModule{
+			ObjectMeta: metav1.ObjectMeta{Name: newName("cel-policy-module")},
+			Spec:       policiesv1.PolicyModuleSpec{Module: "registry://ghcr.io/kubewarden/policies/cel-policy:v1.0.0"},
+		}
+		Expect(k8sClient.Create(ctx, policyModule)).To(Succeed())
+		policy := celPolicy(`{"validations": [{"expression": "object.spec.replicas <= 5"}]}`)
+		policy.Spec.Module = ""
+		policy.Spec.ModuleRef = policyModule.GetName()
+
+		Expect(subReconciler.reconcileAdmissionBackend(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		_, err := getValidatingAdmissionPolicy(policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(apimeta.IsStatusConditionTrue(policy.Status.Conditions, string(policiesv1.PolicyValidatingAdmissionPolicy))).To(BeTrue())
+	})
+
+	It("should fall back to the webhook when the policy uses the host capabilities", func() {
+		policy := celPolicy(`{"validations": [{"expression": "kw.k8s.apiVersion('v1').kind('Pod').list().items.size() < 10"}]}`)
+
+		Expect(subReconciler.reconcileAdmissionBackend(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		_, err := getValidatingAdmissionPolicy(policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		_, err = getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(apimeta.IsStatusConditionFalse(policy.Status.Conditions, string(policiesv1.PolicyValidatingAdmissionPolicy))).To(BeTrue())
+	})
+
+	It("should switch back to the webhook when the backend is changed", func() {
+		policy := celPolicy(`{"validations": [{"expression": "true"}]}`)
+		Expect(subReconciler.reconcileAdmissionBackend(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		policy.Spec.Backend = policiesv1.PolicyBackendWebhook
+		Expect(subReconciler.reconcileAdmissionBackend(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		_, err := getValidatingAdmissionPolicy(policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		_, err = getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(apimeta.FindStatusCondition(policy.Status.Conditions, string(policiesv1.PolicyValidatingAdmissionPolicy))).To(BeNil())
+	})
+
+	It("should recognize the CEL policy module", func() {
+		Expect(isCELPolicyModule("registry://ghcr.io/kubewarden/policies/cel-policy:v1.0.0")).To(BeTrue())
+		Expect(isCELPolicyModule("ghcr.io/kubewarden/policies/cel-policy@sha256:abcd")).To(BeTrue())
+		Expect(isCELPolicyModule("localhost:5000/kubewarden/policies/cel-policy")).To(BeTrue())
+		Expect(isCELPolicyModule("registry://ghcr.io/kubewarden/policies/pod-privileged:v1.0.0")).To(BeFalse())
+	})
+})
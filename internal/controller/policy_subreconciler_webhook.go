@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
@@ -24,6 +27,7 @@ func (r *policySubReconciler) reconcileValidatingWebhookConfiguration(
 	policy policiesv1.Policy,
 	admissionSecret *corev1.Secret,
 	policyServerNameWithPrefix string,
+	exceptions []policiesv1.Exception,
 ) error {
 	webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
@@ -31,22 +35,6 @@ func (r *policySubReconciler) reconcileValidatingWebhookConfiguration(
 		},
 	}
 	_, err := controllerutil.CreateOrPatch(ctx, r.Client, webhook, func() error {
-		admissionPath := filepath.Join("/validate", policy.GetUniqueName())
-		admissionPort := int32(constants.PolicyServerPort)
-
-		service := admissionregistrationv1.ServiceReference{
-			Namespace: r.deploymentsNamespace,
-			Name:      policyServerNameWithPrefix,
-			Path:      &admissionPath,
-			Port:      &admissionPort,
-		}
-
-		sideEffects := policy.GetSideEffects()
-		if sideEffects == nil {
-			noneSideEffects := admissionregistrationv1.SideEffectClassNone
-			sideEffects = &noneSideEffects
-		}
-
 		webhook.Name = policy.GetUniqueName()
 		webhook.Labels = map[string]string{
 			constants.PartOfLabelKey: constants.PartOfLabelValue,
@@ -55,30 +43,8 @@ func (r *policySubReconciler) reconcileValidatingWebhookConfiguration(
 			constants.WebhookConfigurationPolicyNameAnnotationKey:      policy.GetName(),
 			constants.WebhookConfigurationPolicyNamespaceAnnotationKey: policy.GetNamespace(),
 		}
-
 		webhook.Webhooks = []admissionregistrationv1.ValidatingWebhook{
-			{
-				Name: policy.GetUniqueName() + ".kubewarden.admission",
-				ClientConfig: admissionregistrationv1.WebhookClientConfig{
-					Service:  &service,
-					CABundle: admissionSecret.Data[constants.CARootCert],
-				},
-				Rules:                   policy.GetRules(),
-				FailurePolicy:           policy.GetFailurePolicy(),
-				MatchPolicy:             policy.GetMatchPolicy(),
-				NamespaceSelector:       r.namespaceSelector(policy),
-				ObjectSelector:          policy.GetObjectSelector(),
-				SideEffects:             sideEffects,
-				TimeoutSeconds:          policy.GetTimeoutSeconds(),
-				AdmissionReviewVersions: []string{"v1"},
-			},
-		}
-
-		if r.featureGateAdmissionWebhookMatchConditions {
-			webhook.Webhooks[0].MatchConditions = policy.GetMatchConditions()
-		} else if len(policy.GetMatchConditions()) > 0 {
-			r.Log.Info("Skipping matchConditions for policy as the feature gate AdmissionWebhookMatchConditions is disabled",
-				"policy", policy.GetName())
+			r.validatingWebhook(policy, admissionSecret, policyServerNameWithPrefix, exceptions),
 		}
 
 		return nil
@@ -112,29 +78,16 @@ func (r *policySubReconciler) reconcileMutatingWebhookConfiguration(
 	policy policiesv1.Policy,
 	admissionSecret *corev1.Secret,
 	policyServerNameWithPrefix string,
+	exceptions []policiesv1.Exception,
 ) error {
+	name := mutatingWebhookConfigurationName(policy)
 	webhook := &admissionregistrationv1.MutatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: policy.GetUniqueName(),
+			Name: name,
 		},
 	}
 	_, err := controllerutil.CreateOrPatch(ctx, r.Client, webhook, func() error {
-		admissionPath := filepath.Join("/validate", policy.GetUniqueName())
-		admissionPort := int32(constants.PolicyServerPort)
-
-		service := admissionregistrationv1.ServiceReference{
-			Namespace: r.deploymentsNamespace,
-			Name:      policyServerNameWithPrefix,
-			Path:      &admissionPath,
-			Port:      &admissionPort,
-		}
-
-		sideEffects := policy.GetSideEffects()
-		if sideEffects == nil {
-			noneSideEffects := admissionregistrationv1.SideEffectClassNone
-			sideEffects = &noneSideEffects
-		}
-		webhook.Name = policy.GetUniqueName()
+		webhook.Name = name
 		webhook.Labels = map[string]string{
 			constants.PartOfLabelKey: constants.PartOfLabelValue,
 		}
@@ -143,28 +96,7 @@ func (r *policySubReconciler) reconcileMutatingWebhookConfiguration(
 			constants.WebhookConfigurationPolicyNamespaceAnnotationKey: policy.GetNamespace(),
 		}
 		webhook.Webhooks = []admissionregistrationv1.MutatingWebhook{
-			{
-				Name: policy.GetUniqueName() + ".kubewarden.admission",
-				ClientConfig: admissionregistrationv1.WebhookClientConfig{
-					Service:  &service,
-					CABundle: admissionSecret.Data[constants.CARootCert],
-				},
-				Rules:                   policy.GetRules(),
-				FailurePolicy:           policy.GetFailurePolicy(),
-				MatchPolicy:             policy.GetMatchPolicy(),
-				NamespaceSelector:       r.namespaceSelector(policy),
-				ObjectSelector:          policy.GetObjectSelector(),
-				SideEffects:             sideEffects,
-				TimeoutSeconds:          policy.GetTimeoutSeconds(),
-				AdmissionReviewVersions: []string{"v1"},
-			},
-		}
-
-		if r.featureGateAdmissionWebhookMatchConditions {
-			webhook.Webhooks[0].MatchConditions = policy.GetMatchConditions()
-		} else if len(policy.GetMatchConditions()) > 0 {
-			r.Log.Info("Skipping matchConditions for policy as the feature gate AdmissionWebhookMatchConditions is disabled",
-				"policy", policy.GetName())
+			r.mutatingWebhook(policy, admissionSecret, policyServerNameWithPrefix, exceptions),
 		}
 
 		return nil
@@ -173,7 +105,9 @@ func (r *policySubReconciler) reconcileMutatingWebhookConfiguration(
 		return fmt.Errorf("cannot reconcile mutating webhook: %w", err)
 	}
 
-	return nil
+	// The webhook configuration is renamed when the priority of the policy
+	// changes: the previous one is removed once the new one is in place.
+	return r.reconcileMutatingWebhookConfigurationsDeletion(ctx, policy, name)
 }
 
 func (r *policySubReconciler) reconcileMutatingWebhookConfigurationDeletion(ctx context.Context, admissionPolicy policiesv1.Policy) error {
@@ -187,25 +121,214 @@ func (r *policySubReconciler) reconcileMutatingWebhookConfigurationDeletion(ctx
 		return fmt.Errorf("cannot retrieve mutating webhook: %w", err)
 	}
 
+	return r.reconcileMutatingWebhookConfigurationsDeletion(ctx, admissionPolicy, "")
+}
+
+// reconcileMutatingWebhookConfigurationsDeletion deletes the mutating webhook
+// configurations of the policy, named after any of its priorities, except
+// the one named keep.
+func (r *policySubReconciler) reconcileMutatingWebhookConfigurationsDeletion(ctx context.Context, policy policiesv1.Policy, keep string) error {
+	webhookConfigurations := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := r.List(ctx, webhookConfigurations, client.MatchingLabels{constants.PartOfLabelKey: constants.PartOfLabelValue}); err != nil {
+		return fmt.Errorf("cannot list mutating webhooks: %w", err)
+	}
+
+	for i := range webhookConfigurations.Items {
+		webhookConfiguration := &webhookConfigurations.Items[i]
+		if webhookConfiguration.Name == keep || !isMutatingWebhookConfigurationNameOf(webhookConfiguration.Name, policy) {
+			continue
+		}
+		if err := r.Delete(ctx, webhookConfiguration); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete mutating webhook: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileLegacyWebhookConfigurationDeletion deletes the webhook
+// configurations named after the legacy unique name of the policy.
+// The webhook configurations annotated with another policy are left untouched,
+// as the legacy naming scheme could give the same name to different policies.
+func (r *policySubReconciler) reconcileLegacyWebhookConfigurationDeletion(ctx context.Context, policy policiesv1.Policy) error {
+	webhookConfigurations := []client.Object{
+		&admissionregistrationv1.ValidatingWebhookConfiguration{},
+		&admissionregistrationv1.MutatingWebhookConfiguration{},
+	}
+	for _, webhookConfiguration := range webhookConfigurations {
+		err := r.Get(ctx, types.NamespacedName{Name: policy.GetLegacyUniqueName()}, webhookConfiguration)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot retrieve legacy webhook configuration: %w", err)
+		}
+
+		annotations := webhookConfiguration.GetAnnotations()
+		if annotations[constants.WebhookConfigurationPolicyNameAnnotationKey] != policy.GetName() ||
+			annotations[constants.WebhookConfigurationPolicyNamespaceAnnotationKey] != policy.GetNamespace() {
+			continue
+		}
+
+		if err = r.Delete(ctx, webhookConfiguration); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete legacy webhook configuration: %w", err)
+		}
+		r.Log.Info("Deleted legacy webhook configuration", "name", policy.GetLegacyUniqueName(), "policy", policy.GetName())
+	}
+
 	return nil
 }
 
+// mutatingWebhookConfigurationName returns the name of the per-policy
+// mutating webhook configuration of the given policy. The API server invokes
+// the mutating webhook configurations ordered by name, hence the priority of
+// the policy is encoded as a zero-padded prefix: the prioritized policies are
+// invoked before the others, which are named after their unique name only.
+func mutatingWebhookConfigurationName(policy policiesv1.Policy) string {
+	priority := policy.GetPriority()
+	if priority == nil {
+		return policy.GetUniqueName()
+	}
+
+	return fmt.Sprintf("%03d-%s", *priority, policy.GetUniqueName())
+}
+
+// isMutatingWebhookConfigurationNameOf returns true when the name is the
+// name of a mutating webhook configuration of the policy, with any priority.
+func isMutatingWebhookConfigurationNameOf(name string, policy policiesv1.Policy) bool {
+	if name == policy.GetUniqueName() {
+		return true
+	}
+
+	priority, uniqueName, found := strings.Cut(name, "-")
+	return found && uniqueName == policy.GetUniqueName() &&
+		len(priority) == 3 && strings.Trim(priority, "0123456789") == ""
+}
+
+// webhookName returns the name of the webhook entry of the given policy.
+func webhookName(policy policiesv1.Policy) string {
+	return policy.GetUniqueName() + constants.WebhookNameSuffix
+}
+
+// legacyWebhookName returns the name of the webhook entry of the given policy
+// generated by the legacy naming scheme.
+func legacyWebhookName(policy policiesv1.Policy) string {
+	return policy.GetLegacyUniqueName() + constants.WebhookNameSuffix
+}
+
+func webhookClientConfig(policy policiesv1.Policy, admissionSecret *corev1.Secret, deploymentsNamespace, policyServerNameWithPrefix string) admissionregistrationv1.WebhookClientConfig {
+	admissionPath := filepath.Join("/validate", policy.GetUniqueName())
+	admissionPort := int32(constants.PolicyServerPort)
+
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: deploymentsNamespace,
+			Name:      policyServerNameWithPrefix,
+			Path:      &admissionPath,
+			Port:      &admissionPort,
+		},
+		CABundle: admissionSecret.Data[constants.CARootCert],
+	}
+}
+
+func webhookSideEffects(policy policiesv1.Policy) *admissionregistrationv1.SideEffectClass {
+	sideEffects := policy.GetSideEffects()
+	if sideEffects == nil {
+		noneSideEffects := admissionregistrationv1.SideEffectClassNone
+		sideEffects = &noneSideEffects
+	}
+
+	return sideEffects
+}
+
+// webhookMatchConditions returns the match conditions of the policy webhook,
+// which are the match conditions of the policy followed by the ones compiled
+// from the exceptions applied to the policy.
+func (r *policySubReconciler) webhookMatchConditions(policy policiesv1.Policy, exceptions []policiesv1.Exception) []admissionregistrationv1.MatchCondition {
+	if !r.featureGateAdmissionWebhookMatchConditions {
+		if len(policy.GetMatchConditions()) > 0 {
+			r.Log.Info("Skipping matchConditions for policy as the feature gate AdmissionWebhookMatchConditions is disabled",
+				"policy", policy.GetName())
+		}
+		if len(exceptions) > 0 {
+			r.Log.Info("Skipping exceptions for policy as the feature gate AdmissionWebhookMatchConditions is disabled",
+				"policy", policy.GetName())
+		}
+
+		return nil
+	}
+
+	if len(exceptions) == 0 {
+		return policy.GetMatchConditions()
+	}
+
+	matchConditions := slices.Clone(policy.GetMatchConditions())
+	for _, exception := range exceptions {
+		matchConditions = append(matchConditions, policiesv1.ExceptionMatchCondition(exception))
+	}
+
+	return matchConditions
+}
+
+// validatingWebhook builds the webhook entry of a validating policy.
+func (r *policySubReconciler) validatingWebhook(
+	policy policiesv1.Policy,
+	admissionSecret *corev1.Secret,
+	policyServerNameWithPrefix string,
+	exceptions []policiesv1.Exception,
+) admissionregistrationv1.ValidatingWebhook {
+	return admissionregistrationv1.ValidatingWebhook{
+		Name:                    webhookName(policy),
+		ClientConfig:            webhookClientConfig(policy, admissionSecret, r.deploymentsNamespace, policyServerNameWithPrefix),
+		Rules:                   policy.GetRules(),
+		FailurePolicy:           policy.GetFailurePolicy(),
+		MatchPolicy:             policy.GetMatchPolicy(),
+		NamespaceSelector:       r.namespaceSelector(policy),
+		ObjectSelector:          policy.GetObjectSelector(),
+		SideEffects:             webhookSideEffects(policy),
+		TimeoutSeconds:          policy.GetTimeoutSeconds(),
+		AdmissionReviewVersions: []string{"v1"},
+		MatchConditions:         r.webhookMatchConditions(policy, exceptions),
+	}
+}
+
+// mutatingWebhook builds the webhook entry of a mutating policy.
+func (r *policySubReconciler) mutatingWebhook(
+	policy policiesv1.Policy,
+	admissionSecret *corev1.Secret,
+	policyServerNameWithPrefix string,
+	exceptions []policiesv1.Exception,
+) admissionregistrationv1.MutatingWebhook {
+	return admissionregistrationv1.MutatingWebhook{
+		Name:                    webhookName(policy),
+		ClientConfig:            webhookClientConfig(policy, admissionSecret, r.deploymentsNamespace, policyServerNameWithPrefix),
+		Rules:                   policy.GetRules(),
+		FailurePolicy:           policy.GetFailurePolicy(),
+		MatchPolicy:             policy.GetMatchPolicy(),
+		NamespaceSelector:       r.namespaceSelector(policy),
+		ObjectSelector:          policy.GetObjectSelector(),
+		SideEffects:             webhookSideEffects(policy),
+		TimeoutSeconds:          policy.GetTimeoutSeconds(),
+		AdmissionReviewVersions: []string{"v1"},
+		MatchConditions:         r.webhookMatchConditions(policy, exceptions),
+		ReinvocationPolicy:      policy.GetReinvocationPolicy(),
+	}
+}
+
+// namespaceSelector returns the namespaceSelector of the policy webhook. The
+// namespaces excluded by the controller are added to the namespaceSelector of
+// the cluster-wide policies.
 func (r *policySubReconciler) namespaceSelector(policy policiesv1.Policy) *metav1.LabelSelector {
 	switch policy.(type) {
 	case *policiesv1.ClusterAdmissionPolicyGroup, *policiesv1.ClusterAdmissionPolicy:
-		namespaceSelector := &metav1.LabelSelector{
-			MatchExpressions: []metav1.LabelSelectorRequirement{
-				{
-					Key:      "kubernetes.io/metadata.name",
-					Operator: "NotIn",
-					Values:   []string{r.deploymentsNamespace},
-				},
-			},
-		}
-
+		namespaceSelector := &metav1.LabelSelector{}
 		if policy.GetNamespaceSelector() != nil {
-			namespaceSelector.MatchExpressions = append(namespaceSelector.MatchExpressions, policy.GetNamespaceSelector().MatchExpressions...)
+			namespaceSelector = policy.GetNamespaceSelector().DeepCopy()
 		}
+		namespaceSelector.MatchExpressions = append(
+			r.namespaceExclusions.selectorRequirements(r.deploymentsNamespace),
+			namespaceSelector.MatchExpressions...,
+		)
 
 		return namespaceSelector
 
@@ -0,0 +1,321 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+// When sharding is enabled, all the policies of a PolicyServer are
+// aggregated into one ValidatingWebhookConfiguration and one
+// MutatingWebhookConfiguration named after the PolicyServer, each policy
+// being a separate entry of the webhooks list.
+// Every policy reconciler only touches its own entry, using optimistic
+// locking to avoid overwriting the changes made by the other reconcilers.
+//
+// The mutating policies with a priority always use the per-policy layout, as
+// their priority is encoded into the name of their webhook configuration.
+//
+// Migrating between the per-policy and the sharded layouts is done by
+// creating the webhook in the new layout before removing it from the old
+// one, so that the policy is always enforced.
+
+// shardedWebhookConfigurationName returns the name of the webhook
+// configurations holding the webhooks of the given PolicyServer.
+func shardedWebhookConfigurationName(policyServerName string) string {
+	return policyServerDeploymentName(policyServerName)
+}
+
+func shardedWebhookConfigurationLabels(policyServerName string) map[string]string {
+	return map[string]string{
+		constants.PartOfLabelKey:       constants.PartOfLabelValue,
+		constants.PolicyServerLabelKey: policyServerName,
+	}
+}
+
+// isShardedWebhookConfigurationConflict returns true when the sharded webhook
+// configuration has been changed concurrently by another reconciler.
+func isShardedWebhookConfigurationConflict(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsNotFound(err) || apierrors.IsAlreadyExists(err)
+}
+
+// reconcileWebhookConfiguration creates or updates the webhook of the policy
+// using the configured layout, and removes it from the other layout.
+// The webhook configurations named after the legacy unique name of the policy
+// are removed only once the new ones are in place, so that the policy is
+// always enforced.
+func (r *policySubReconciler) reconcileWebhookConfiguration(
+	ctx context.Context,
+	policy policiesv1.Policy,
+	admissionSecret *corev1.Secret,
+	policyServer *policiesv1.PolicyServer,
+	exceptions []policiesv1.Exception,
+) error {
+	if err := r.reconcileWebhookConfigurationLayout(ctx, policy, admissionSecret, policyServer, exceptions); err != nil {
+		return err
+	}
+
+	return r.reconcileLegacyWebhookConfigurationDeletion(ctx, policy)
+}
+
+func (r *policySubReconciler) reconcileWebhookConfigurationLayout(
+	ctx context.Context,
+	policy policiesv1.Policy,
+	admissionSecret *corev1.Secret,
+	policyServer *policiesv1.PolicyServer,
+	exceptions []policiesv1.Exception,
+) error {
+	if policy.IsMutating() {
+		if r.shardWebhookConfigurations && policy.GetPriority() == nil {
+			if err := r.reconcileShardedMutatingWebhookConfiguration(ctx, policy, admissionSecret, policyServer, exceptions); err != nil {
+				return err
+			}
+			return r.reconcileMutatingWebhookConfigurationDeletion(ctx, policy)
+		}
+
+		if err := r.reconcileMutatingWebhookConfiguration(ctx, policy, admissionSecret, policyServer.NameWithPrefix(), exceptions); err != nil {
+			return err
+		}
+		return r.reconcileShardedMutatingWebhookConfigurationDeletion(ctx, policy)
+	}
+
+	if r.shardWebhookConfigurations {
+		if err := r.reconcileShardedValidatingWebhookConfiguration(ctx, policy, admissionSecret, policyServer, exceptions); err != nil {
+			return err
+		}
+		return r.reconcileValidatingWebhookConfigurationDeletion(ctx, policy)
+	}
+
+	if err := r.reconcileValidatingWebhookConfiguration(ctx, policy, admissionSecret, policyServer.NameWithPrefix(), exceptions); err != nil {
+		return err
+	}
+	return r.reconcileShardedValidatingWebhookConfigurationDeletion(ctx, policy)
+}
+
+// reconcileWebhookConfigurationDeletion removes the webhook of the policy
+// from both the per-policy and the sharded layouts.
+func (r *policySubReconciler) reconcileWebhookConfigurationDeletion(ctx context.Context, policy policiesv1.Policy) error {
+	if err := r.reconcileLegacyWebhookConfigurationDeletion(ctx, policy); err != nil {
+		return err
+	}
+
+	if policy.IsMutating() {
+		if err := r.reconcileMutatingWebhookConfigurationDeletion(ctx, policy); err != nil {
+			return err
+		}
+		return r.reconcileShardedMutatingWebhookConfigurationDeletion(ctx, policy)
+	}
+
+	if err := r.reconcileValidatingWebhookConfigurationDeletion(ctx, policy); err != nil {
+		return err
+	}
+	return r.reconcileShardedValidatingWebhookConfigurationDeletion(ctx, policy)
+}
+
+//nolint:dupl // This function is similar to the other reconcileShardedMutatingWebhookConfiguration
+func (r *policySubReconciler) reconcileShardedValidatingWebhookConfiguration(
+	ctx context.Context,
+	policy policiesv1.Policy,
+	admissionSecret *corev1.Secret,
+	policyServer *policiesv1.PolicyServer,
+	exceptions []policiesv1.Exception,
+) error {
+	webhook := r.validatingWebhook(policy, admissionSecret, policyServer.NameWithPrefix(), exceptions)
+	name := shardedWebhookConfigurationName(policyServer.GetName())
+
+	err := retry.OnError(retry.DefaultBackoff, isShardedWebhookConfigurationConflict, func() error {
+		webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		err := r.Get(ctx, types.NamespacedName{Name: name}, webhookConfiguration)
+		if apierrors.IsNotFound(err) {
+			webhookConfiguration = &admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: shardedWebhookConfigurationLabels(policyServer.GetName()),
+				},
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{webhook},
+			}
+			return r.Create(ctx, webhookConfiguration)
+		}
+		if err != nil {
+			return err
+		}
+
+		original := webhookConfiguration.DeepCopy()
+		for key, value := range shardedWebhookConfigurationLabels(policyServer.GetName()) {
+			metav1.SetMetaDataLabel(&webhookConfiguration.ObjectMeta, key, value)
+		}
+		index := slices.IndexFunc(webhookConfiguration.Webhooks, func(w admissionregistrationv1.ValidatingWebhook) bool {
+			return w.Name == webhook.Name
+		})
+		if index == -1 {
+			webhookConfiguration.Webhooks = append(webhookConfiguration.Webhooks, webhook)
+		} else {
+			webhookConfiguration.Webhooks[index] = webhook
+		}
+		webhookConfiguration.Webhooks = slices.DeleteFunc(webhookConfiguration.Webhooks, func(w admissionregistrationv1.ValidatingWebhook) bool {
+			return w.Name == legacyWebhookName(policy)
+		})
+
+		return r.Patch(ctx, webhookConfiguration, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return fmt.Errorf("cannot reconcile sharded validating webhook: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:dupl // This function is similar to the other reconcileShardedMutatingWebhookConfigurationDeletion
+func (r *policySubReconciler) reconcileShardedValidatingWebhookConfigurationDeletion(ctx context.Context, policy policiesv1.Policy) error {
+	name := shardedWebhookConfigurationName(policy.GetPolicyServer())
+
+	err := retry.OnError(retry.DefaultBackoff, apierrors.IsConflict, func() error {
+		webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, webhookConfiguration); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		original := webhookConfiguration.DeepCopy()
+		webhookConfiguration.Webhooks = slices.DeleteFunc(webhookConfiguration.Webhooks, func(w admissionregistrationv1.ValidatingWebhook) bool {
+			return w.Name == webhookName(policy) || w.Name == legacyWebhookName(policy)
+		})
+		if len(webhookConfiguration.Webhooks) == len(original.Webhooks) {
+			return nil
+		}
+
+		if len(webhookConfiguration.Webhooks) == 0 {
+			resourceVersion := webhookConfiguration.GetResourceVersion()
+			return client.IgnoreNotFound(r.Delete(ctx, webhookConfiguration, client.Preconditions{ResourceVersion: &resourceVersion}))
+		}
+
+		return r.Patch(ctx, webhookConfiguration, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete sharded validating webhook: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:dupl // This function is similar to the other reconcileShardedValidatingWebhookConfiguration
+func (r *policySubReconciler) reconcileShardedMutatingWebhookConfiguration(
+	ctx context.Context,
+	policy policiesv1.Policy,
+	admissionSecret *corev1.Secret,
+	policyServer *policiesv1.PolicyServer,
+	exceptions []policiesv1.Exception,
+) error {
+	webhook := r.mutatingWebhook(policy, admissionSecret, policyServer.NameWithPrefix(), exceptions)
+	name := shardedWebhookConfigurationName(policyServer.GetName())
+
+	err := retry.OnError(retry.DefaultBackoff, isShardedWebhookConfigurationConflict, func() error {
+		webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		err := r.Get(ctx, types.NamespacedName{Name: name}, webhookConfiguration)
+		if apierrors.IsNotFound(err) {
+			webhookConfiguration = &admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: shardedWebhookConfigurationLabels(policyServer.GetName()),
+				},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{webhook},
+			}
+			return r.Create(ctx, webhookConfiguration)
+		}
+		if err != nil {
+			return err
+		}
+
+		original := webhookConfiguration.DeepCopy()
+		for key, value := range shardedWebhookConfigurationLabels(policyServer.GetName()) {
+			metav1.SetMetaDataLabel(&webhookConfiguration.ObjectMeta, key, value)
+		}
+		index := slices.IndexFunc(webhookConfiguration.Webhooks, func(w admissionregistrationv1.MutatingWebhook) bool {
+			return w.Name == webhook.Name
+		})
+		if index == -1 {
+			webhookConfiguration.Webhooks = append(webhookConfiguration.Webhooks, webhook)
+		} else {
+			webhookConfiguration.Webhooks[index] = webhook
+		}
+		webhookConfiguration.Webhooks = slices.DeleteFunc(webhookConfiguration.Webhooks, func(w admissionregistrationv1.MutatingWebhook) bool {
+			return w.Name == legacyWebhookName(policy)
+		})
+
+		return r.Patch(ctx, webhookConfiguration, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return fmt.Errorf("cannot reconcile sharded mutating webhook: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:dupl // This function is similar to the other reconcileShardedValidatingWebhookConfigurationDeletion
+func (r *policySubReconciler) reconcileShardedMutatingWebhookConfigurationDeletion(ctx context.Context, policy policiesv1.Policy) error {
+	name := shardedWebhookConfigurationName(policy.GetPolicyServer())
+
+	err := retry.OnError(retry.DefaultBackoff, apierrors.IsConflict, func() error {
+		webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, webhookConfiguration); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		original := webhookConfiguration.DeepCopy()
+		webhookConfiguration.Webhooks = slices.DeleteFunc(webhookConfiguration.Webhooks, func(w admissionregistrationv1.MutatingWebhook) bool {
+			return w.Name == webhookName(policy) || w.Name == legacyWebhookName(policy)
+		})
+		if len(webhookConfiguration.Webhooks) == len(original.Webhooks) {
+			return nil
+		}
+
+		if len(webhookConfiguration.Webhooks) == 0 {
+			resourceVersion := webhookConfiguration.GetResourceVersion()
+			return client.IgnoreNotFound(r.Delete(ctx, webhookConfiguration, client.Preconditions{ResourceVersion: &resourceVersion}))
+		}
+
+		return r.Patch(ctx, webhookConfiguration, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete sharded mutating webhook: %w", err)
+	}
+
+	return nil
+}
+
+// findPoliciesForShardedWebhookConfiguration returns a reconcile request for
+// every policy, of the kind of the given list, bound to the PolicyServer
+// owning a sharded webhook configuration.
+func findPoliciesForShardedWebhookConfiguration(ctx context.Context, k8sClient client.Client, webhookConfiguration client.Object, policies client.ObjectList) []reconcile.Request {
+	policyServerName, ok := webhookConfiguration.GetLabels()[constants.PolicyServerLabelKey]
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	if err := k8sClient.List(ctx, policies, client.MatchingFields{constants.PolicyServerIndexKey: policyServerName}); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	_ = apimeta.EachListItem(policies, func(object runtime.Object) error {
+		if policy, isPolicy := object.(client.Object); isPolicy {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+		}
+		return nil
+	})
+
+	return requests
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Sharded webhook configurations", func() {
+	ctx := context.Background()
+
+	var policyServer *policiesv1.PolicyServer
+	var admissionSecret *corev1.Secret
+	var firstPolicy, secondPolicy *policiesv1.ClusterAdmissionPolicy
+
+	BeforeEach(func() {
+		policyServer = policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		admissionSecret = &corev1.Secret{
+			Data: map[string][]byte{constants.CARootCert: []byte("ca")},
+		}
+		firstPolicy = policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("first-policy")).
+			WithPolicyServer(policyServer.GetName()).
+			Build()
+		secondPolicy = policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("second-policy")).
+			WithPolicyServer(policyServer.GetName()).
+			Build()
+	})
+
+	newSubReconciler := func(shardWebhookConfigurations bool) *policySubReconciler {
+		return &policySubReconciler{
+			Client:                     k8sClient,
+			Log:                        GinkgoLogr,
+			deploymentsNamespace:       deploymentsNamespace,
+			shardWebhookConfigurations: shardWebhookConfigurations,
+		}
+	}
+
+	It("should aggregate the webhooks of the policies of a PolicyServer into one configuration", func() {
+		subReconciler := newSubReconciler(true)
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, firstPolicy, admissionSecret, policyServer, nil)).To(Succeed())
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, secondPolicy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		webhookConfiguration, err := getTestValidatingWebhookConfiguration(ctx, shardedWebhookConfigurationName(policyServer.GetName()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(webhookConfiguration.Labels).To(HaveKeyWithValue(constants.PolicyServerLabelKey, policyServer.GetName()))
+		Expect(webhookConfiguration.Webhooks).To(ConsistOf(
+			HaveField("Name", webhookName(firstPolicy)),
+			HaveField("Name", webhookName(secondPolicy)),
+		))
+
+		By("removing the webhook of a deleted policy")
+		Expect(subReconciler.reconcileWebhookConfigurationDeletion(ctx, firstPolicy)).To(Succeed())
+		webhookConfiguration, err = getTestValidatingWebhookConfiguration(ctx, shardedWebhookConfigurationName(policyServer.GetName()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(webhookConfiguration.Webhooks).To(ConsistOf(
+			HaveField("Name", webhookName(secondPolicy)),
+		))
+
+		By("deleting the configuration once it has no webhooks left")
+		Expect(subReconciler.reconcileWebhookConfigurationDeletion(ctx, secondPolicy)).To(Succeed())
+		_, err = getTestValidatingWebhookConfiguration(ctx, shardedWebhookConfigurationName(policyServer.GetName()))
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should migrate from the per-policy layout to the sharded layout and back", func() {
+		Expect(newSubReconciler(false).reconcileWebhookConfiguration(ctx, firstPolicy, admissionSecret, policyServer, nil)).To(Succeed())
+		_, err := getTestValidatingWebhookConfiguration(ctx, firstPolicy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+
+		By("enabling sharding")
+		Expect(newSubReconciler(true).reconcileWebhookConfiguration(ctx, firstPolicy, admissionSecret, policyServer, nil)).To(Succeed())
+		_, err = getTestValidatingWebhookConfiguration(ctx, firstPolicy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		webhookConfiguration, err := getTestValidatingWebhookConfiguration(ctx, shardedWebhookConfigurationName(policyServer.GetName()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(webhookConfiguration.Webhooks).To(ConsistOf(
+			HaveField("Name", webhookName(firstPolicy)),
+		))
+
+		By("disabling sharding")
+		Expect(newSubReconciler(false).reconcileWebhookConfiguration(ctx, firstPolicy, admissionSecret, policyServer, nil)).To(Succeed())
+		_, err = getTestValidatingWebhookConfiguration(ctx, firstPolicy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = getTestValidatingWebhookConfiguration(ctx, shardedWebhookConfigurationName(policyServer.GetName()))
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
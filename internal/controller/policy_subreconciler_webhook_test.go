@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Legacy webhook configurations", func() {
+	ctx := context.Background()
+
+	var policyServer *policiesv1.PolicyServer
+	var admissionSecret *corev1.Secret
+	var subReconciler *policySubReconciler
+
+	BeforeEach(func() {
+		policyServer = policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		admissionSecret = &corev1.Secret{
+			Data: map[string][]byte{constants.CARootCert: []byte("ca")},
+		}
+		subReconciler = &policySubReconciler{
+			Client:               k8sClient,
+			Log:                  GinkgoLogr,
+			deploymentsNamespace: deploymentsNamespace,
+		}
+	})
+
+	legacyWebhookConfiguration := func(policy policiesv1.Policy, policyName string) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: policy.GetLegacyUniqueName(),
+				Labels: map[string]string{
+					constants.PartOfLabelKey: constants.PartOfLabelValue,
+				},
+				Annotations: map[string]string{
+					constants.WebhookConfigurationPolicyNameAnnotationKey:      policyName,
+					constants.WebhookConfigurationPolicyNamespaceAnnotationKey: policy.GetNamespace(),
+				},
+			},
+		}
+	}
+
+	It("should replace the legacy webhook configuration of the policy", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("legacy-policy")).
+			WithPolicyServer(policyServer.GetName()).
+			Build()
+		Expect(k8sClient.Create(ctx, legacyWebhookConfiguration(policy, policy.GetName()))).To(Succeed())
+
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		_, err := getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = getTestValidatingWebhookConfiguration(ctx, policy.GetLegacyUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should keep the legacy webhook configuration of another policy", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("legacy-policy")).
+			WithPolicyServer(policyServer.GetName()).
+			Build()
+		Expect(k8sClient.Create(ctx, legacyWebhookConfiguration(policy, newName("other-policy")))).To(Succeed())
+
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+
+		_, err := getTestValidatingWebhookConfiguration(ctx, policy.GetLegacyUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
+
+var _ = Describe("Webhook namespace selector", func() {
+	var subReconciler *policySubReconciler
+
+	BeforeEach(func() {
+		namespaceExclusions, err := ParseNamespaceExclusions("kube-system", "kubewarden.io/exempt=true,example.com/skip")
+		Expect(err).ToNot(HaveOccurred())
+
+		subReconciler = &policySubReconciler{
+			Client:               k8sClient,
+			Log:                  GinkgoLogr,
+			deploymentsNamespace: deploymentsNamespace,
+			namespaceExclusions:  namespaceExclusions,
+		}
+	})
+
+	It("should merge the policy namespace selector with the excluded namespaces", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().WithName(newName("policy")).Build()
+		policy.Spec.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "a"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+			},
+		}
+
+		Expect(subReconciler.namespaceSelector(policy)).To(Equal(&metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "a"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{deploymentsNamespace, "kube-system"}},
+				{Key: "example.com/skip", Operator: metav1.LabelSelectorOpDoesNotExist},
+				{Key: "kubewarden.io/exempt", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"true"}},
+				{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+			},
+		}))
+		Expect(policy.Spec.NamespaceSelector.MatchExpressions).To(HaveLen(1))
+	})
+
+	It("should not exclude namespaces from the namespaced policies", func() {
+		policy := policiesv1.NewAdmissionPolicyFactory().WithName(newName("policy")).WithNamespace("team-a").Build()
+
+		Expect(subReconciler.namespaceSelector(policy)).To(Equal(policy.GetNamespaceSelector()))
+	})
+
+	It("should reject invalid namespace exclusions", func() {
+		_, err := ParseNamespaceExclusions("Invalid_Namespace", "")
+		Expect(err).To(HaveOccurred())
+		_, err = ParseNamespaceExclusions("", "kubewarden.io/exempt=not a value")
+		Expect(err).To(HaveOccurred())
+		_, err = ParseNamespaceExclusions("", "exempt=true,exempt=false")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Suspended policies", func() {
+	ctx := context.Background()
+
+	It("should delete the webhook configuration of the suspended policy", func() {
+		policyServer := policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		admissionSecret := &corev1.Secret{
+			Data: map[string][]byte{constants.CARootCert: []byte("ca")},
+		}
+		subReconciler := &policySubReconciler{
+			Client:               k8sClient,
+			Log:                  GinkgoLogr,
+			deploymentsNamespace: deploymentsNamespace,
+		}
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("suspended-policy")).
+			WithPolicyServer(policyServer.GetName()).
+			Build()
+
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+		_, err := getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+
+		policy.Spec.Suspended = true
+		_, err = subReconciler.reconcilePolicy(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(policy.Status.PolicyStatus).To(Equal(policiesv1.PolicyStatusSuspended))
+	})
+})
+
+var _ = Describe("Mutating policies ordering", func() {
+	ctx := context.Background()
+
+	It("should encode the priority of the policy into the webhook configuration name", func() {
+		policyServer := policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		admissionSecret := &corev1.Secret{
+			Data: map[string][]byte{constants.CARootCert: []byte("ca")},
+		}
+		subReconciler := &policySubReconciler{
+			Client:                     k8sClient,
+			Log:                        GinkgoLogr,
+			deploymentsNamespace:       deploymentsNamespace,
+			shardWebhookConfigurations: true,
+		}
+		reinvocationPolicy := admissionregistrationv1.IfNeededReinvocationPolicy
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("prioritized-policy")).
+			WithPolicyServer(policyServer.GetName()).
+			WithMutating(true).
+			Build()
+		policy.Spec.ReinvocationPolicy = &reinvocationPolicy
+		policy.Spec.Priority = ptr.To(int32(10))
+
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+		webhookConfiguration, err := getTestMutatingWebhookConfiguration(ctx, "010-"+policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(webhookConfiguration.Webhooks[0].ReinvocationPolicy).To(Equal(&reinvocationPolicy))
+
+		policy.Spec.Priority = ptr.To(int32(20))
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, policy, admissionSecret, policyServer, nil)).To(Succeed())
+		_, err = getTestMutatingWebhookConfiguration(ctx, "020-"+policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = getTestMutatingWebhookConfiguration(ctx, "010-"+policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		Expect(subReconciler.reconcileWebhookConfigurationDeletion(ctx, policy)).To(Succeed())
+		_, err = getTestMutatingWebhookConfiguration(ctx, "020-"+policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
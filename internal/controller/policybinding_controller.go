@@ -0,0 +1,258 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policytemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policybindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policybindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=clusterpolicybindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=clusterpolicybindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=admissionpolicies,verbs=create;patch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=clusteradmissionpolicies,verbs=create;patch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// PolicyBindingReconciler reconciles a PolicyBinding object.
+type PolicyBindingReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Log      logr.Logger
+}
+
+// Reconcile expands the PolicyBinding into an AdmissionPolicy.
+func (r *PolicyBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policyBinding policiesv1.PolicyBinding
+	if err := r.Get(ctx, req.NamespacedName, &policyBinding); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return reconcileBinding(ctx, r.Client, r.Scheme, r.Recorder, &policyBinding, &policiesv1.AdmissionPolicy{})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicyBindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&policiesv1.PolicyBinding{}).
+		Owns(&policiesv1.AdmissionPolicy{}).
+		Watches(
+			&policiesv1.PolicyTemplate{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, template client.Object) []reconcile.Request {
+				return findBindingsForTemplate(ctx, r.Client, r.Log, template, policiesv1.PolicyBindingKind)
+			}),
+		).
+		Complete(r)
+	if err != nil {
+		return errors.Join(errors.New("failed enrolling controller with manager"), err)
+	}
+
+	return nil
+}
+
+// ClusterPolicyBindingReconciler reconciles a ClusterPolicyBinding object.
+type ClusterPolicyBindingReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Log      logr.Logger
+}
+
+// Reconcile expands the ClusterPolicyBinding into a ClusterAdmissionPolicy.
+func (r *ClusterPolicyBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var clusterPolicyBinding policiesv1.ClusterPolicyBinding
+	if err := r.Get(ctx, req.NamespacedName, &clusterPolicyBinding); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return reconcileBinding(ctx, r.Client, r.Scheme, r.Recorder, &clusterPolicyBinding, &policiesv1.ClusterAdmissionPolicy{})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterPolicyBindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&policiesv1.ClusterPolicyBinding{}).
+		Owns(&policiesv1.ClusterAdmissionPolicy{}).
+		Watches(
+			&policiesv1.PolicyTemplate{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, template client.Object) []reconcile.Request {
+				return findBindingsForTemplate(ctx, r.Client, r.Log, template, policiesv1.ClusterPolicyBindingKind)
+			}),
+		).
+		Complete(r)
+	if err != nil {
+		return errors.Join(errors.New("failed enrolling controller with manager"), err)
+	}
+
+	return nil
+}
+
+// findBindingsForTemplate returns a reconcile request for every binding of
+// the given kind referencing the template, so that the template updates are
+// rolled out to all of them.
+func findBindingsForTemplate(ctx context.Context, k8sClient client.Client, logger logr.Logger, template client.Object, bindingKind string) []reconcile.Request {
+	bindings, err := policiesv1.ListTemplateBindings(ctx, k8sClient, template.GetName())
+	if err != nil {
+		logger.Error(err, "cannot list the bindings of the PolicyTemplate", "name", template.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, binding := range bindings {
+		if binding.GetKind() == bindingKind {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(binding)})
+		}
+	}
+
+	return requests
+}
+
+// reconcileBinding expands the binding into the given policy, which is
+// created with the name and namespace of the binding and owned by it. The
+// policy is then reconciled like any other policy, and it is garbage
+// collected when the binding is deleted. When the template is deleted, the
+// policy is left untouched.
+func reconcileBinding(
+	ctx context.Context,
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	binding policiesv1.Binding,
+	policy policiesv1.Policy,
+) (ctrl.Result, error) {
+	if binding.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	originalStatus := binding.GetBindingStatus().DeepCopy()
+
+	template := &policiesv1.PolicyTemplate{}
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: binding.GetBindingSpec().TemplateRef}, template)
+	if apierrors.IsNotFound(err) {
+		setBindingReadyCondition(binding, metav1.ConditionFalse, "PolicyTemplateNotFound",
+			fmt.Sprintf("PolicyTemplate %s not found", binding.GetBindingSpec().TemplateRef))
+		return ctrl.Result{}, updateBindingStatus(ctx, k8sClient, binding, originalStatus)
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot get PolicyTemplate %s: %w", binding.GetBindingSpec().TemplateRef, err)
+	}
+
+	desiredPolicy, err := template.Instantiate(binding)
+	if err != nil {
+		recorder.Event(binding, corev1.EventTypeWarning, "PolicyBindingExpansionFailed", err.Error())
+		setBindingReadyCondition(binding, metav1.ConditionFalse, "InvalidSettings", err.Error())
+		return ctrl.Result{}, updateBindingStatus(ctx, k8sClient, binding, originalStatus)
+	}
+
+	policy.SetName(desiredPolicy.GetName())
+	policy.SetNamespace(desiredPolicy.GetNamespace())
+	err = k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("cannot get the policy of the binding: %w", err)
+	}
+	if err == nil && !metav1.IsControlledBy(policy, binding) {
+		setBindingReadyCondition(binding, metav1.ConditionFalse, "PolicyConflict",
+			fmt.Sprintf("%s %s already exists and is not managed by the binding", policiesv1.PolicyKind(policy), policy.GetName()))
+		return ctrl.Result{}, updateBindingStatus(ctx, k8sClient, binding, originalStatus)
+	}
+
+	operationResult, err := controllerutil.CreateOrPatch(ctx, k8sClient, policy, func() error {
+		setBindingPolicySpec(policy, desiredPolicy)
+		labels := policy.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[constants.PolicyTemplateLabelKey] = template.GetName()
+		policy.SetLabels(labels)
+
+		return controllerutil.SetControllerReference(binding, policy, scheme)
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot reconcile the policy of the binding: %w", err)
+	}
+
+	if operationResult == controllerutil.OperationResultUpdated && originalStatus.TemplateGeneration != template.GetGeneration() {
+		recorder.Eventf(binding, corev1.EventTypeNormal, "PolicyTemplateRolledOut",
+			"Rolled out generation %d of PolicyTemplate %s", template.GetGeneration(), template.GetName())
+	}
+	binding.GetBindingStatus().TemplateGeneration = template.GetGeneration()
+	setBindingReadyCondition(binding, metav1.ConditionTrue, "PolicyReconciled",
+		fmt.Sprintf("Expanded into %s %s", policiesv1.PolicyKind(policy), policy.GetName()))
+
+	return ctrl.Result{}, updateBindingStatus(ctx, k8sClient, binding, originalStatus)
+}
+
+// setBindingPolicySpec sets the fields of the policy defined by the binding
+// and its template. The policy server is immutable, hence it is only set at
+// the creation of the policy.
+func setBindingPolicySpec(policy, desiredPolicy policiesv1.Policy) {
+	var spec, desiredSpec *policiesv1.PolicySpec
+	switch typedPolicy := policy.(type) {
+	case *policiesv1.AdmissionPolicy:
+		spec = &typedPolicy.Spec.PolicySpec
+		desiredSpec = &desiredPolicy.(*policiesv1.AdmissionPolicy).Spec.PolicySpec
+	case *policiesv1.ClusterAdmissionPolicy:
+		typedDesiredPolicy := desiredPolicy.(*policiesv1.ClusterAdmissionPolicy)
+		typedPolicy.Spec.NamespaceSelector = typedDesiredPolicy.Spec.NamespaceSelector
+		spec = &typedPolicy.Spec.PolicySpec
+		desiredSpec = &typedDesiredPolicy.Spec.PolicySpec
+	default:
+		return
+	}
+
+	if policy.GetResourceVersion() == "" {
+		spec.PolicyServer = desiredSpec.PolicyServer
+	}
+	if desiredSpec.Mode != "" {
+		spec.Mode = desiredSpec.Mode
+	}
+	spec.Module = desiredSpec.Module
+	spec.Settings = desiredSpec.Settings
+	spec.Rules = desiredSpec.Rules
+	spec.Mutating = desiredSpec.Mutating
+	spec.ObjectSelector = desiredSpec.ObjectSelector
+}
+
+func setBindingReadyCondition(binding policiesv1.Binding, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(
+		&binding.GetBindingStatus().Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyBindingReady),
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		},
+	)
+}
+
+func updateBindingStatus(ctx context.Context, k8sClient client.Client, binding policiesv1.Binding, originalStatus *policiesv1.PolicyBindingStatus) error {
+	if equality.Semantic.DeepEqual(originalStatus, binding.GetBindingStatus()) {
+		return nil
+	}
+	if err := k8sClient.Status().Update(ctx, binding); err != nil {
+		return fmt.Errorf("cannot update %s status: %w", binding.GetKind(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Policy bindings", func() {
+	ctx := context.Background()
+
+	var recorder *record.FakeRecorder
+	var template *policiesv1.PolicyTemplate
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		template = &policiesv1.PolicyTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: newName("template")},
+			Spec: policiesv1.PolicyTemplateSpec{
+				Module: "registry://ghcr.io/kubewarden/policies/trusted-repos:v0.2.0",
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods"},
+						},
+					},
+				},
+				Settings: runtime.RawExtension{Raw: []byte(`{"registries": {"reject": ["docker.io"]}}`)},
+			},
+		}
+		Expect(k8sClient.Create(ctx, template)).To(Succeed())
+	})
+
+	clusterPolicyBinding := func(templateRef string) *policiesv1.ClusterPolicyBinding {
+		binding := &policiesv1.ClusterPolicyBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: newName("binding")},
+			Spec: policiesv1.PolicyBindingSpec{
+				TemplateRef:  templateRef,
+				PolicyServer: "default",
+				Mode:         policiesv1.PolicyModeMonitor,
+				Settings:     runtime.RawExtension{Raw: []byte(`{"registries": {"allow": ["registry.example.com"]}}`)},
+			},
+		}
+		Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+
+		return binding
+	}
+
+	It("should expand the binding into a policy and roll out the template updates", func() {
+		binding := clusterPolicyBinding(template.GetName())
+
+		_, err := reconcileBinding(ctx, k8sClient, k8sClient.Scheme(), recorder, binding, &policiesv1.ClusterAdmissionPolicy{})
+		Expect(err).ToNot(HaveOccurred())
+
+		policy := &policiesv1.ClusterAdmissionPolicy{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: binding.GetName()}, policy)).To(Succeed())
+		Expect(metav1.IsControlledBy(policy, binding)).To(BeTrue())
+		Expect(policy.GetLabels()).To(HaveKeyWithValue(constants.PolicyTemplateLabelKey, template.GetName()))
+		Expect(policy.Spec.Module).To(Equal(template.Spec.Module))
+		Expect(policy.Spec.Mode).To(Equal(policiesv1.PolicyModeMonitor))
+		Expect(policy.Spec.Settings.Raw).To(MatchJSON(`{"registries": {"allow": ["registry.example.com"], "reject": ["docker.io"]}}`))
+		Expect(binding.Status.TemplateGeneration).To(Equal(template.GetGeneration()))
+		Expect(apimeta.IsStatusConditionTrue(binding.Status.Conditions, string(policiesv1.PolicyBindingReady))).To(BeTrue())
+
+		Expect(findBindingsForTemplate(ctx, k8sClient, GinkgoLogr, template, policiesv1.ClusterPolicyBindingKind)).
+			To(ContainElement(HaveField("NamespacedName", client.ObjectKeyFromObject(binding))))
+
+		template.Spec.Module = "registry://ghcr.io/kubewarden/policies/trusted-repos:v0.3.0"
+		Expect(k8sClient.Update(ctx, template)).To(Succeed())
+
+		_, err = reconcileBinding(ctx, k8sClient, k8sClient.Scheme(), recorder, binding, &policiesv1.ClusterAdmissionPolicy{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: binding.GetName()}, policy)).To(Succeed())
+		Expect(policy.Spec.Module).To(Equal(template.Spec.Module))
+		Expect(binding.Status.TemplateGeneration).To(Equal(template.GetGeneration()))
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyTemplateRolledOut")))
+	})
+
+	It("should report a missing template", func() {
+		binding := clusterPolicyBinding(newName("missing-template"))
+
+		_, err := reconcileBinding(ctx, k8sClient, k8sClient.Scheme(), recorder, binding, &policiesv1.ClusterAdmissionPolicy{})
+		Expect(err).ToNot(HaveOccurred())
+
+		condition := apimeta.FindStatusCondition(binding.Status.Conditions, string(policiesv1.PolicyBindingReady))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal("PolicyTemplateNotFound"))
+	})
+
+	It("should not take over an existing policy", func() {
+		binding := clusterPolicyBinding(template.GetName())
+		existingPolicy := policiesv1.NewClusterAdmissionPolicyFactory().WithName(binding.GetName()).Build()
+		Expect(k8sClient.Create(ctx, existingPolicy)).To(Succeed())
+
+		_, err := reconcileBinding(ctx, k8sClient, k8sClient.Scheme(), recorder, binding, &policiesv1.ClusterAdmissionPolicy{})
+		Expect(err).ToNot(HaveOccurred())
+
+		condition := apimeta.FindStatusCondition(binding.Status.Conditions, string(policiesv1.PolicyBindingReady))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal("PolicyConflict"))
+
+		policy := &policiesv1.ClusterAdmissionPolicy{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existingPolicy), policy)).To(Succeed())
+		Expect(policy.Spec.Module).To(Equal(existingPolicy.Spec.Module))
+	})
+})
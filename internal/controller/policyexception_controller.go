@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policyexceptions,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policyexceptions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=clusterpolicyexceptions,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=clusterpolicyexceptions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// PolicyExceptionReconciler reconciles a PolicyException object.
+type PolicyExceptionReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+}
+
+// Reconcile reconciles PolicyExceptions.
+func (r *PolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policyException policiesv1.PolicyException
+	if err := r.Get(ctx, req.NamespacedName, &policyException); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return reconcileException(ctx, r.Client, r.Recorder, &policyException)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicyExceptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&policiesv1.PolicyException{}).
+		Complete(r)
+	if err != nil {
+		return errors.Join(errors.New("failed enrolling controller with manager"), err)
+	}
+
+	return nil
+}
+
+// ClusterPolicyExceptionReconciler reconciles a ClusterPolicyException object.
+type ClusterPolicyExceptionReconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+}
+
+// Reconcile reconciles ClusterPolicyExceptions.
+func (r *ClusterPolicyExceptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var clusterPolicyException policiesv1.ClusterPolicyException
+	if err := r.Get(ctx, req.NamespacedName, &clusterPolicyException); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	return reconcileException(ctx, r.Client, r.Recorder, &clusterPolicyException)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterPolicyExceptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&policiesv1.ClusterPolicyException{}).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findClusterPolicyExceptionsForNamespace),
+		).
+		Complete(r)
+	if err != nil {
+		return errors.Join(errors.New("failed enrolling controller with manager"), err)
+	}
+
+	return nil
+}
+
+// findClusterPolicyExceptionsForNamespace returns a reconcile request for
+// every ClusterPolicyException selecting the namespaces by labels.
+func (r *ClusterPolicyExceptionReconciler) findClusterPolicyExceptionsForNamespace(ctx context.Context, _ client.Object) []reconcile.Request {
+	var clusterPolicyExceptions policiesv1.ClusterPolicyExceptionList
+	if err := r.List(ctx, &clusterPolicyExceptions); err != nil {
+		r.Log.Error(err, "cannot list ClusterPolicyExceptions")
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, clusterPolicyException := range clusterPolicyExceptions.Items {
+		if clusterPolicyException.Spec.NamespaceSelector != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterPolicyException)})
+		}
+	}
+
+	return requests
+}
+
+// reconcileException deletes the expired exception, and resolves the
+// namespaces matching its namespaceSelector. The policies targeted by the
+// exception are reconciled when it changes.
+func reconcileException(ctx context.Context, k8sClient client.Client, recorder record.EventRecorder, exception policiesv1.Exception) (ctrl.Result, error) {
+	if exception.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	spec := exception.GetExceptionSpec()
+	if spec.IsExpired(time.Now()) {
+		if err := k8sClient.Delete(ctx, exception); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot delete expired %s: %w", exception.GetKind(), err)
+		}
+		recorder.Eventf(exception, corev1.EventTypeNormal, "PolicyExceptionExpired", "Deleted %s expired at %s", exception.GetKind(), spec.ExpiresAt.UTC().Format(time.RFC3339))
+
+		return ctrl.Result{}, nil
+	}
+
+	matchedNamespaces, err := exceptionMatchedNamespaces(ctx, k8sClient, spec.NamespaceSelector)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !slices.Equal(matchedNamespaces, exception.GetExceptionStatus().MatchedNamespaces) {
+		exception.GetExceptionStatus().MatchedNamespaces = matchedNamespaces
+		if err = k8sClient.Status().Update(ctx, exception); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot update %s status: %w", exception.GetKind(), err)
+		}
+	}
+
+	if spec.ExpiresAt != nil {
+		return ctrl.Result{RequeueAfter: time.Until(spec.ExpiresAt.Time)}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// exceptionMatchedNamespaces returns the sorted names of the namespaces
+// matching the given selector.
+func exceptionMatchedNamespaces(ctx context.Context, k8sClient client.Client, namespaceSelector *metav1.LabelSelector) ([]string, error) {
+	if namespaceSelector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(namespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+
+	var namespaces corev1.NamespaceList
+	if err = k8sClient.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("cannot list namespaces: %w", err)
+	}
+
+	matchedNamespaces := []string{}
+	for _, namespace := range namespaces.Items {
+		matchedNamespaces = append(matchedNamespaces, namespace.GetName())
+	}
+	slices.Sort(matchedNamespaces)
+
+	return matchedNamespaces, nil
+}
+
+// findPoliciesForException returns a reconcile request for every policy of
+// the given kind targeted by the exception.
+func findPoliciesForException(exception client.Object, policyKind string) []reconcile.Request {
+	policyException, ok := exception.(policiesv1.Exception)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, key := range policiesv1.ExceptionTargetKeys(policyException, policyKind) {
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+
+	return requests
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Policy exceptions", func() {
+	ctx := context.Background()
+
+	var policyServer *policiesv1.PolicyServer
+	var admissionSecret *corev1.Secret
+	var subReconciler *policySubReconciler
+	var policy *policiesv1.ClusterAdmissionPolicy
+
+	BeforeEach(func() {
+		policyServer = policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		admissionSecret = &corev1.Secret{
+			Data: map[string][]byte{constants.CARootCert: []byte("ca")},
+		}
+		subReconciler = &policySubReconciler{
+			Client:               k8sClient,
+			Log:                  GinkgoLogr,
+			deploymentsNamespace: deploymentsNamespace,
+			featureGateAdmissionWebhookMatchConditions: true,
+		}
+		policy = policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithPolicyServer(policyServer.GetName()).
+			Build()
+	})
+
+	clusterPolicyException := func(expiresAt *metav1.Time) *policiesv1.ClusterPolicyException {
+		return &policiesv1.ClusterPolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: newName("exception")},
+			Spec: policiesv1.PolicyExceptionSpec{
+				Policies:   []policiesv1.PolicyExceptionTarget{{Kind: policiesv1.ClusterAdmissionPolicyKind, Name: policy.GetName()}},
+				Namespaces: []string{"team-a"},
+				ExpiresAt:  expiresAt,
+			},
+		}
+	}
+
+	It("should add the exceptions targeting the policy to its webhook", func() {
+		exception := clusterPolicyException(&metav1.Time{Time: time.Now().Add(time.Hour)})
+		Expect(k8sClient.Create(ctx, exception)).To(Succeed())
+		Expect(k8sClient.Create(ctx, clusterPolicyException(&metav1.Time{Time: time.Now().Add(-time.Hour)}))).To(Succeed())
+
+		exceptions, err := subReconciler.getPolicyExceptions(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exceptions).To(HaveLen(1))
+		Expect(exceptions[0].GetName()).To(Equal(exception.GetName()))
+
+		Expect(subReconciler.reconcileWebhookConfiguration(ctx, policy, admissionSecret, policyServer, exceptions)).To(Succeed())
+
+		webhookConfiguration, err := getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(webhookConfiguration.Webhooks[0].MatchConditions).To(ContainElement(policiesv1.ExceptionMatchCondition(exception)))
+
+		subReconciler.setPolicyExceptionsStatus(policy, exceptions)
+		Expect(policy.Status.Exceptions).To(HaveLen(1))
+		Expect(policy.Status.Exceptions[0].Kind).To(Equal(policiesv1.ClusterPolicyExceptionKind))
+		Expect(policy.Status.Exceptions[0].Name).To(Equal(exception.GetName()))
+		Expect(nextExceptionExpiry(exceptions)).To(BeNumerically("~", time.Hour, time.Minute))
+	})
+
+	It("should delete the expired exceptions", func() {
+		recorder := record.NewFakeRecorder(10)
+		exception := clusterPolicyException(&metav1.Time{Time: time.Now().Add(-time.Minute)})
+		Expect(k8sClient.Create(ctx, exception)).To(Succeed())
+
+		_, err := reconcileException(ctx, k8sClient, recorder, exception)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(exception), &policiesv1.ClusterPolicyException{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolicyExceptionExpired")))
+	})
+
+	It("should resolve the namespaces matching the namespace selector", func() {
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   newName("exempted"),
+				Labels: map[string]string{"kubewarden.io/exempt": "true"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+		exception := clusterPolicyException(nil)
+		exception.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"kubewarden.io/exempt": "true"}}
+		Expect(k8sClient.Create(ctx, exception)).To(Succeed())
+
+		result, err := reconcileException(ctx, k8sClient, record.NewFakeRecorder(10), exception)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(exception), exception)).To(Succeed())
+		Expect(exception.Status.MatchedNamespaces).To(ContainElement(namespace.GetName()))
+	})
+})
@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policymodules,verbs=get;list;watch
+//+kubebuilder:rbac:groups=policies.kubewarden.io,resources=policymodules/status,verbs=get;update;patch
+
+// PolicyModuleReconciler reconciles a PolicyModule object.
+type PolicyModuleReconciler struct {
+	client.Client
+	Log logr.Logger
+}
+
+// Reconcile reports the module served to the Policy Servers and the policies
+// using it.
+func (r *PolicyModuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var policyModule policiesv1.PolicyModule
+	if err := r.Get(ctx, req.NamespacedName, &policyModule); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	originalStatus := policyModule.Status.DeepCopy()
+
+	consumers, err := policiesv1.ListPolicyModuleConsumers(ctx, r.Client, policyModule.GetName())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	policyModule.Status.ResolvedModule = policyModule.ResolvedModule()
+	policyModule.Status.Digest = policyModule.ResolvedDigest()
+	policyModule.Status.Consumers = buildPolicyModuleConsumers(policyModule.GetName(), consumers)
+
+	if err = r.setSignaturesVerifiedCondition(ctx, &policyModule); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(originalStatus, &policyModule.Status) {
+		return ctrl.Result{}, nil
+	}
+	if err = r.Status().Update(ctx, &policyModule); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot update PolicyModule status: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// setSignaturesVerifiedCondition reports whether the signatures of the module
+// are verified by every Policy Server running it. The Policy Servers with a
+// verificationConfig verify the modules against it, instead of the
+// verification configuration generated from the PolicyModules.
+func (r *PolicyModuleReconciler) setSignaturesVerifiedCondition(ctx context.Context, policyModule *policiesv1.PolicyModule) error {
+	if !policyModule.HasSignatures() {
+		apimeta.RemoveStatusCondition(&policyModule.Status.Conditions, string(policiesv1.PolicyModuleSignaturesVerified))
+		return nil
+	}
+
+	overriddenPolicyServers := []string{}
+	for _, consumer := range policyModule.Status.Consumers {
+		if consumer.PolicyServer == "" || slices.Contains(overriddenPolicyServers, consumer.PolicyServer) {
+			continue
+		}
+		var policyServer policiesv1.PolicyServer
+		if err := r.Get(ctx, client.ObjectKey{Name: consumer.PolicyServer}, &policyServer); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Join(fmt.Errorf("cannot get PolicyServer %s", consumer.PolicyServer), err)
+		}
+		if policyServer.Spec.VerificationConfig != "" {
+			overriddenPolicyServers = append(overriddenPolicyServers, consumer.PolicyServer)
+		}
+	}
+
+	if len(overriddenPolicyServers) != 0 {
+		apimeta.SetStatusCondition(
+			&policyModule.Status.Conditions,
+			metav1.Condition{
+				Type:    string(policiesv1.PolicyModuleSignaturesVerified),
+				Status:  metav1.ConditionFalse,
+				Reason:  "VerificationConfigOverridden",
+				Message: "The module is run by Policy Servers verifying the modules against their verificationConfig: " + strings.Join(overriddenPolicyServers, ", "),
+			},
+		)
+		return nil
+	}
+
+	apimeta.SetStatusCondition(
+		&policyModule.Status.Conditions,
+		metav1.Condition{
+			Type:    string(policiesv1.PolicyModuleSignaturesVerified),
+			Status:  metav1.ConditionTrue,
+			Reason:  "VerificationConfigGenerated",
+			Message: "The module is run by Policy Servers verifying the signatures",
+		},
+	)
+
+	return nil
+}
+
+// buildPolicyModuleConsumers returns the consumers of the module, sorted by
+// kind, namespace and name.
+func buildPolicyModuleConsumers(moduleName string, policies []policiesv1.Policy) []policiesv1.PolicyModuleConsumer {
+	consumers := make([]policiesv1.PolicyModuleConsumer, 0, len(policies))
+	for _, policy := range policies {
+		consumer := policiesv1.PolicyModuleConsumer{
+			Kind:         policiesv1.PolicyKind(policy),
+			Name:         policy.GetName(),
+			Namespace:    policy.GetNamespace(),
+			PolicyServer: policy.GetPolicyServer(),
+		}
+		if policyGroup, ok := policy.(policiesv1.PolicyGroup); ok {
+			for memberName, member := range policyGroup.GetPolicyGroupMembersWithContext() {
+				if member.ModuleRef == moduleName {
+					consumer.Members = append(consumer.Members, memberName)
+				}
+			}
+			slices.Sort(consumer.Members)
+		}
+		consumers = append(consumers, consumer)
+	}
+
+	slices.SortFunc(consumers, func(a, b policiesv1.PolicyModuleConsumer) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return consumers
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicyModuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&policiesv1.PolicyModule{}).
+		Watches(&policiesv1.AdmissionPolicy{}, handler.EnqueueRequestsFromMapFunc(r.findPolicyModulesForPolicy)).
+		Watches(&policiesv1.AdmissionPolicyGroup{}, handler.EnqueueRequestsFromMapFunc(r.findPolicyModulesForPolicy)).
+		Watches(&policiesv1.ClusterAdmissionPolicy{}, handler.EnqueueRequestsFromMapFunc(r.findPolicyModulesForPolicy)).
+		Watches(&policiesv1.ClusterAdmissionPolicyGroup{}, handler.EnqueueRequestsFromMapFunc(r.findPolicyModulesForPolicy)).
+		Watches(&policiesv1.PolicyServer{}, handler.EnqueueRequestsFromMapFunc(r.findPolicyModulesForPolicyServer)).
+		Complete(r)
+	if err != nil {
+		return errors.Join(errors.New("failed enrolling controller with manager"), err)
+	}
+
+	return nil
+}
+
+// findPolicyModulesForPolicy returns a reconcile request for every
+// PolicyModule referenced by the policy. The watch triggers with both the old
+// and the new object, so that the modules no longer referenced are
+// reconciled as well.
+func (r *PolicyModuleReconciler) findPolicyModulesForPolicy(_ context.Context, object client.Object) []reconcile.Request {
+	policy, ok := object.(policiesv1.Policy)
+	if !ok {
+		r.Log.Info("object is not a policy", "object", client.ObjectKeyFromObject(object))
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, moduleRef := range policiesv1.ModuleRefs(policy) {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: moduleRef}})
+	}
+
+	return requests
+}
+
+// findPolicyModulesForPolicyServer returns a reconcile request for every
+// PolicyModule requiring signatures, so that the changes of the
+// verificationConfig of the Policy Server are reported.
+func (r *PolicyModuleReconciler) findPolicyModulesForPolicyServer(ctx context.Context, _ client.Object) []reconcile.Request {
+	var policyModules policiesv1.PolicyModuleList
+	if err := r.List(ctx, &policyModules); err != nil {
+		r.Log.Error(err, "cannot list PolicyModules")
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, policyModule := range policyModules.Items {
+		if policyModule.HasSignatures() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policyModule)})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+var _ = Describe("Policy modules", func() {
+	ctx := context.Background()
+
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	var policyModule *policiesv1.PolicyModule
+
+	BeforeEach(func() {
+		policyModule = &policiesv1.PolicyModule{
+			ObjectMeta: metav1.ObjectMeta{Name: newName("module")},
+			Spec: policiesv1.PolicyModuleSpec{
+				Module: "registry://ghcr.io/kubewarden/policies/pod-privileged:v0.4.0",
+				Digest: digest,
+			},
+		}
+		Expect(k8sClient.Create(ctx, policyModule)).To(Succeed())
+	})
+
+	moduleRefPolicy := func(policyServer string) *policiesv1.ClusterAdmissionPolicy {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithPolicyServer(policyServer).
+			Build()
+		policy.Spec.Module = ""
+		policy.Spec.ModuleRef = policyModule.GetName()
+
+		return policy
+	}
+
+	It("should report the resolved module and its consumers", func() {
+		policyServer := policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		Expect(k8sClient.Create(ctx, policyServer)).To(Succeed())
+		policy := moduleRefPolicy(policyServer.GetName())
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		reconciler := &PolicyModuleReconciler{Client: k8sClient, Log: GinkgoLogr}
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policyModule)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policyModule), policyModule)).To(Succeed())
+		Expect(policyModule.Status.ResolvedModule).To(Equal("registry://ghcr.io/kubewarden/policies/pod-privileged@" + digest))
+		Expect(policyModule.Status.Digest).To(Equal(digest))
+		Expect(policyModule.Status.Consumers).To(ConsistOf(policiesv1.PolicyModuleConsumer{
+			Kind:         policiesv1.ClusterAdmissionPolicyKind,
+			Name:         policy.GetName(),
+			PolicyServer: policyServer.GetName(),
+		}))
+
+		Expect(reconciler.findPolicyModulesForPolicy(ctx, policy)).
+			To(ConsistOf(HaveField("NamespacedName", client.ObjectKeyFromObject(policyModule))))
+	})
+
+	It("should report whether the signatures are verified by the Policy Servers", func() {
+		policyModule.Spec.Signatures = &policiesv1.PolicyModuleSignatures{
+			Keyless: []policiesv1.PolicyModuleKeylessSignature{{Issuer: "https://token.actions.githubusercontent.com", Subject: "kubewarden"}},
+		}
+		Expect(k8sClient.Update(ctx, policyModule)).To(Succeed())
+		policyServer := policiesv1.NewPolicyServerFactory().WithName(newName("policy-server")).Build()
+		Expect(k8sClient.Create(ctx, policyServer)).To(Succeed())
+		Expect(k8sClient.Create(ctx, moduleRefPolicy(policyServer.GetName()))).To(Succeed())
+
+		reconciler := &PolicyModuleReconciler{Client: k8sClient, Log: GinkgoLogr}
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policyModule)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policyModule), policyModule)).To(Succeed())
+		Expect(apimeta.IsStatusConditionTrue(policyModule.Status.Conditions, string(policiesv1.PolicyModuleSignaturesVerified))).To(BeTrue())
+
+		policyServer.Spec.VerificationConfig = "verification-config"
+		Expect(k8sClient.Update(ctx, policyServer)).To(Succeed())
+		Expect(reconciler.findPolicyModulesForPolicyServer(ctx, policyServer)).
+			To(ContainElement(HaveField("NamespacedName", client.ObjectKeyFromObject(policyModule))))
+
+		_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policyModule)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policyModule), policyModule)).To(Succeed())
+		Expect(apimeta.IsStatusConditionFalse(policyModule.Status.Conditions, string(policiesv1.PolicyModuleSignaturesVerified))).To(BeTrue())
+	})
+
+	It("should generate the verification configuration of the module signatures", func() {
+		policy := moduleRefPolicy("default")
+		unsignedPolicy := policiesv1.NewClusterAdmissionPolicyFactory().WithName(newName("unsigned-policy")).Build()
+		signatures := &policiesv1.PolicyModuleSignatures{
+			PublicKeys: []string{"public-key"},
+			Keyless:    []policiesv1.PolicyModuleKeylessSignature{{Issuer: "https://token.actions.githubusercontent.com", Subject: "kubewarden"}},
+		}
+		references := policyReferences{
+			policyModules:          map[string]string{policyModule.GetName(): policyModule.ResolvedModule()},
+			policyModuleSignatures: map[string]*policiesv1.PolicyModuleSignatures{policyModule.GetName(): signatures},
+		}
+
+		Expect(buildVerificationConfig([]policiesv1.Policy{unsignedPolicy}, references)).To(BeNil())
+		Expect(buildVerificationConfig([]policiesv1.Policy{policy, unsignedPolicy}, references)).To(Equal(&policyServerVerificationConfig{
+			APIVersion: "v1",
+			AnyOf: policyServerVerificationConfigAnyOf{
+				MinimumMatches: 1,
+				Signatures: []policyServerSignature{
+					{Kind: "pubKey", Key: "public-key"},
+					{Kind: "genericIssuer", Issuer: "https://token.actions.githubusercontent.com", Subject: &policyServerSignatureSubject{Equal: "kubewarden"}},
+				},
+			},
+		}))
+	})
+
+	It("should resolve the module references in the Policy Server configuration", func() {
+		policy := moduleRefPolicy("default")
+		policyGroup := policiesv1.NewClusterAdmissionPolicyGroupFactory().WithName(newName("policy-group")).Build()
+		policyGroup.Spec.Policies = policiesv1.PolicyGroupMembersWithContext{
+			"privileged": {PolicyGroupMember: policiesv1.PolicyGroupMember{ModuleRef: policyModule.GetName()}},
+		}
+		references := policyReferences{policyModules: map[string]string{policyModule.GetName(): policyModule.ResolvedModule()}}
+
+		policiesMap := buildPoliciesMap([]policiesv1.Policy{policy, policyGroup}, sets.New[string](), references)
+		Expect(policiesMap[policy.GetUniqueName()].Module).To(Equal(policyModule.ResolvedModule()))
+		Expect(policiesMap[policyGroup.GetUniqueName()].Policies["privileged"].Module).To(Equal(policyModule.ResolvedModule()))
+	})
+
+	It("should report the missing module references of a policy", func() {
+		subReconciler := &policySubReconciler{
+			Client:               k8sClient,
+			Log:                  GinkgoLogr,
+			deploymentsNamespace: deploymentsNamespace,
+		}
+		policy := moduleRefPolicy("default")
+		policy.Spec.ModuleRef = newName("missing-module")
+
+		resolved, err := subReconciler.reconcilePolicyModules(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(BeFalse())
+		Expect(apimeta.IsStatusConditionFalse(policy.Status.Conditions, string(policiesv1.PolicyModuleResolved))).To(BeTrue())
+
+		policy.Spec.ModuleRef = policyModule.GetName()
+		resolved, err = subReconciler.reconcilePolicyModules(ctx, policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(BeTrue())
+		Expect(apimeta.IsStatusConditionTrue(policy.Status.Conditions, string(policiesv1.PolicyModuleResolved))).To(BeTrue())
+	})
+})
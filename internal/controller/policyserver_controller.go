@@ -23,14 +23,19 @@ import (
 
 	"github.com/go-logr/logr"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
@@ -62,6 +67,10 @@ type PolicyServerReconciler struct {
 	DeploymentsNamespace                               string
 	AlwaysAcceptAdmissionReviewsInDeploymentsNamespace bool
 	ClientCAConfigMapName                              string
+	// DropSuspendedPolicies removes the suspended policies from the Policy
+	// Server configuration. They are kept otherwise, so that suspending and
+	// unsuspending a policy does not roll out the Policy Server.
+	DropSuspendedPolicies bool
 }
 
 // TelemetryConfiguration is a struct that contains the configuration for the
@@ -216,6 +225,19 @@ func (r *PolicyServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&policiesv1.AdmissionPolicyGroup{}, handler.EnqueueRequestsFromMapFunc(r.enqueueAdmissionPolicyGroup)).
 		Watches(&policiesv1.ClusterAdmissionPolicy{}, handler.EnqueueRequestsFromMapFunc(r.enqueueClusterAdmissionPolicy)).
 		Watches(&policiesv1.ClusterAdmissionPolicyGroup{}, handler.EnqueueRequestsFromMapFunc(r.enqueueClusterAdmissionPolicyGroup)).
+		Watches(&policiesv1.PolicyModule{}, handler.EnqueueRequestsFromMapFunc(r.enqueuePolicyModule)).
+		// The deletion of a legacy webhook configuration allows to drop the
+		// legacy unique name of its policy from the Policy Server configuration.
+		Watches(
+			&admissionregistrationv1.ValidatingWebhookConfiguration{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueLegacyWebhookConfiguration),
+			builder.WithPredicates(onlyDeletePredicate),
+		).
+		Watches(
+			&admissionregistrationv1.MutatingWebhookConfiguration{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueLegacyWebhookConfiguration),
+			builder.WithPredicates(onlyDeletePredicate),
+		).
 		Complete(r)
 	if err != nil {
 		return errors.Join(errors.New("failed enrolling controller with manager"), err)
@@ -223,7 +245,45 @@ func (r *PolicyServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return nil
 }
 
-func (r *PolicyServerReconciler) enqueueAdmissionPolicy(_ context.Context, object client.Object) []reconcile.Request {
+//nolint:gochecknoglobals // This is a constant predicate
+var onlyDeletePredicate = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// enqueueLegacyWebhookConfiguration enqueues the PolicyServer of the policy
+// owning the given webhook configuration, when the latter is named after the
+// legacy unique name of the policy.
+func (r *PolicyServerReconciler) enqueueLegacyWebhookConfiguration(ctx context.Context, object client.Object) []reconcile.Request {
+	policyName := object.GetAnnotations()[constants.WebhookConfigurationPolicyNameAnnotationKey]
+	if policyName == "" {
+		return []ctrl.Request{}
+	}
+	policyNamespace := object.GetAnnotations()[constants.WebhookConfigurationPolicyNamespaceAnnotationKey]
+
+	for _, policy := range webhookConfigurationPolicyCandidates(policyName, policyNamespace) {
+		if policy.GetLegacyUniqueName() != object.GetName() {
+			continue
+		}
+		if err := r.Get(ctx, client.ObjectKey{Name: policyName, Namespace: policyNamespace}, policy); err != nil {
+			return []ctrl.Request{}
+		}
+
+		return []ctrl.Request{
+			{
+				NamespacedName: client.ObjectKey{
+					Name: policy.GetPolicyServer(),
+				},
+			},
+		}
+	}
+
+	return []ctrl.Request{}
+}
+
+func (r *PolicyServerReconciler) enqueueAdmissionPolicy(ctx context.Context, object client.Object) []reconcile.Request {
 	// The watch will trigger twice per object change; once with the old
 	// object, and once the new object. We need to be mindful when doing
 	// Updates since they will invalidate the newer versions of the
@@ -234,13 +294,13 @@ func (r *PolicyServerReconciler) enqueueAdmissionPolicy(_ context.Context, objec
 		return []ctrl.Request{}
 	}
 
-	return []ctrl.Request{
+	return append([]ctrl.Request{
 		{
 			NamespacedName: client.ObjectKey{
 				Name: policy.Spec.PolicyServer,
 			},
 		},
-	}
+	}, r.enqueueReferencingPolicyGroups(ctx, policy)...)
 }
 
 func (r *PolicyServerReconciler) enqueueAdmissionPolicyGroup(_ context.Context, object client.Object) []reconcile.Request {
@@ -263,7 +323,7 @@ func (r *PolicyServerReconciler) enqueueAdmissionPolicyGroup(_ context.Context,
 	}
 }
 
-func (r *PolicyServerReconciler) enqueueClusterAdmissionPolicy(_ context.Context, object client.Object) []reconcile.Request {
+func (r *PolicyServerReconciler) enqueueClusterAdmissionPolicy(ctx context.Context, object client.Object) []reconcile.Request {
 	// The watch will trigger twice per object change; once with the old
 	// object, and once the new object. We need to be mindful when doing
 	// Updates since they will invalidate the newer versions of the
@@ -274,13 +334,13 @@ func (r *PolicyServerReconciler) enqueueClusterAdmissionPolicy(_ context.Context
 		return []ctrl.Request{}
 	}
 
-	return []ctrl.Request{
+	return append([]ctrl.Request{
 		{
 			NamespacedName: client.ObjectKey{
 				Name: policy.Spec.PolicyServer,
 			},
 		},
-	}
+	}, r.enqueueReferencingPolicyGroups(ctx, policy)...)
 }
 
 func (r *PolicyServerReconciler) enqueueClusterAdmissionPolicyGroup(_ context.Context, object client.Object) []reconcile.Request {
@@ -303,6 +363,45 @@ func (r *PolicyServerReconciler) enqueueClusterAdmissionPolicyGroup(_ context.Co
 	}
 }
 
+// enqueueReferencingPolicyGroups enqueues the PolicyServers serving the
+// policy groups with members referencing the given policy.
+func (r *PolicyServerReconciler) enqueueReferencingPolicyGroups(ctx context.Context, policy policiesv1.Policy) []reconcile.Request {
+	policyGroups, err := policiesv1.ListReferencingPolicyGroups(ctx, r.Client, policy)
+	if err != nil {
+		r.Log.Error(err, "cannot list the policy groups referencing the policy", "policy", policy.GetUniqueName())
+		return []ctrl.Request{}
+	}
+
+	requests := []ctrl.Request{}
+	for _, policyGroup := range policyGroups {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKey{Name: policyGroup.GetPolicyServer()}})
+	}
+
+	return requests
+}
+
+// enqueuePolicyModule enqueues the PolicyServers serving the policies which
+// reference the given PolicyModule.
+func (r *PolicyServerReconciler) enqueuePolicyModule(ctx context.Context, object client.Object) []reconcile.Request {
+	consumers, err := policiesv1.ListPolicyModuleConsumers(ctx, r.Client, object.GetName())
+	if err != nil {
+		r.Log.Error(err, "cannot list the consumers of the PolicyModule", "policyModule", object.GetName())
+		return []ctrl.Request{}
+	}
+
+	policyServers := sets.New[string]()
+	for _, consumer := range consumers {
+		policyServers.Insert(consumer.GetPolicyServer())
+	}
+
+	requests := []ctrl.Request{}
+	for _, policyServer := range sets.List(policyServers) {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKey{Name: policyServer}})
+	}
+
+	return requests
+}
+
 // getPolicies returns all admission policies, cluster admission policy,
 // admission policies groups and cluster admission policy groups bound to the
 // given policyServer.
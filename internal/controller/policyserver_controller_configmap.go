@@ -1,17 +1,22 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -34,11 +39,22 @@ type policyServerConfigEntry struct {
 	PolicyMode            string                            `json:"policyMode"`
 	AllowedToMutate       bool                              `json:"allowedToMutate,omitempty"`
 	ContextAwareResources []policiesv1.ContextAwareResource `json:"contextAwareResources,omitempty"`
-	Settings              runtime.RawExtension              `json:"settings,omitempty"`
+	// ContextAwareResourcesNamespace restricts the access to the context
+	// aware resources to the given namespace. It is set for the namespaced
+	// policies only.
+	ContextAwareResourcesNamespace string               `json:"contextAwareResourcesNamespace,omitempty"`
+	Settings                       runtime.RawExtension `json:"settings,omitempty"`
 	// The following fields are used by policy groups only.
 	Policies   map[string]policyGroupMemberWithContext `json:"policies,omitempty"`
 	Expression string                                  `json:"expression,omitempty"`
-	Message    string                                  `json:"message,omitempty"`
+	// ExpressionVersion is only set for the expressions using the "v2"
+	// environment, so that the configuration of the "v1" expressions is
+	// unchanged.
+	ExpressionVersion string `json:"expressionVersion,omitempty"`
+	Message           string `json:"message,omitempty"`
+	// MessageTemplate is set when the message is a template, to be rendered
+	// by the Policy Server when the group rejects a request.
+	MessageTemplate bool `json:"messageTemplate,omitempty"`
 }
 
 // The following MarshalJSON and UnmarshalJSON methods are used to serialize
@@ -64,37 +80,68 @@ func (p *policyServerConfigEntry) UnmarshalJSON(b []byte) error {
 func (p policyServerConfigEntry) MarshalJSON() ([]byte, error) {
 	if len(p.Policies) > 0 {
 		return json.Marshal(struct {
-			NamespacedName types.NamespacedName                    `json:"namespacedName"`
-			PolicyMode     string                                  `json:"policyMode"`
-			Policies       map[string]policyGroupMemberWithContext `json:"policies"`
-			Expression     string                                  `json:"expression"`
-			Message        string                                  `json:"message"`
+			NamespacedName                 types.NamespacedName                    `json:"namespacedName"`
+			PolicyMode                     string                                  `json:"policyMode"`
+			Policies                       map[string]policyGroupMemberWithContext `json:"policies"`
+			ContextAwareResourcesNamespace string                                  `json:"contextAwareResourcesNamespace,omitempty"`
+			Expression                     string                                  `json:"expression"`
+			ExpressionVersion              string                                  `json:"expressionVersion,omitempty"`
+			Message                        string                                  `json:"message"`
+			MessageTemplate                bool                                    `json:"messageTemplate,omitempty"`
 		}{
-			NamespacedName: p.NamespacedName,
-			PolicyMode:     p.PolicyMode,
-			Policies:       p.Policies,
-			Expression:     p.Expression,
-			Message:        p.Message,
+			NamespacedName:                 p.NamespacedName,
+			PolicyMode:                     p.PolicyMode,
+			Policies:                       p.Policies,
+			ContextAwareResourcesNamespace: p.ContextAwareResourcesNamespace,
+			Expression:                     p.Expression,
+			ExpressionVersion:              p.ExpressionVersion,
+			Message:                        p.Message,
+			MessageTemplate:                p.MessageTemplate,
 		})
 	}
 
 	return json.Marshal(struct {
-		NamespacedName        types.NamespacedName              `json:"namespacedName"`
-		Module                string                            `json:"module"`
-		PolicyMode            string                            `json:"policyMode"`
-		AllowedToMutate       bool                              `json:"allowedToMutate"`
-		ContextAwareResources []policiesv1.ContextAwareResource `json:"contextAwareResources,omitempty"`
-		Settings              runtime.RawExtension              `json:"settings,omitempty"`
+		NamespacedName                 types.NamespacedName              `json:"namespacedName"`
+		Module                         string                            `json:"module"`
+		PolicyMode                     string                            `json:"policyMode"`
+		AllowedToMutate                bool                              `json:"allowedToMutate"`
+		ContextAwareResources          []policiesv1.ContextAwareResource `json:"contextAwareResources,omitempty"`
+		ContextAwareResourcesNamespace string                            `json:"contextAwareResourcesNamespace,omitempty"`
+		Settings                       runtime.RawExtension              `json:"settings,omitempty"`
 	}{
-		NamespacedName:        p.NamespacedName,
-		Module:                p.Module,
-		PolicyMode:            p.PolicyMode,
-		AllowedToMutate:       p.AllowedToMutate,
-		ContextAwareResources: p.ContextAwareResources,
-		Settings:              p.Settings,
+		NamespacedName:                 p.NamespacedName,
+		Module:                         p.Module,
+		PolicyMode:                     p.PolicyMode,
+		AllowedToMutate:                p.AllowedToMutate,
+		ContextAwareResources:          p.ContextAwareResources,
+		ContextAwareResourcesNamespace: p.ContextAwareResourcesNamespace,
+		Settings:                       p.Settings,
 	})
 }
 
+// policyServerVerificationConfig is the Sigstore verification configuration
+// of the Policy Server, generated from the signatures of the PolicyModules.
+type policyServerVerificationConfig struct {
+	APIVersion string                              `json:"apiVersion"`
+	AnyOf      policyServerVerificationConfigAnyOf `json:"anyOf"`
+}
+
+type policyServerVerificationConfigAnyOf struct {
+	MinimumMatches int                     `json:"minimumMatches"`
+	Signatures     []policyServerSignature `json:"signatures"`
+}
+
+type policyServerSignature struct {
+	Kind    string                        `json:"kind"`
+	Key     string                        `json:"key,omitempty"`
+	Issuer  string                        `json:"issuer,omitempty"`
+	Subject *policyServerSignatureSubject `json:"subject,omitempty"`
+}
+
+type policyServerSignatureSubject struct {
+	Equal string `json:"equal"`
+}
+
 type policyServerSourceAuthority struct {
 	Type string `json:"type"`
 	Data string `json:"data"` // contains a PEM encoded certificate
@@ -117,8 +164,30 @@ func (r *PolicyServerReconciler) reconcilePolicyServerConfigMap(
 			Namespace: r.DeploymentsNamespace,
 		},
 	}
-	_, err := controllerutil.CreateOrPatch(ctx, r.Client, cfg, func() error {
-		return r.updateConfigMapData(cfg, policyServer, policies)
+	if r.DropSuspendedPolicies {
+		policies = slices.DeleteFunc(slices.Clone(policies), func(policy policiesv1.Policy) bool {
+			return policy.IsSuspended()
+		})
+	}
+	references, err := r.getPolicyReferences(ctx, policies)
+	if err != nil {
+		return err
+	}
+	// The policies referencing a missing PolicyModule or policy cannot be
+	// served: their status is reported by the policy reconcilers.
+	policies = slices.DeleteFunc(slices.Clone(policies), func(policy policiesv1.Policy) bool {
+		if missing := references.missingReferences(policy); len(missing) != 0 {
+			r.Log.Info("Skipping policy with missing references", "policy", policy.GetUniqueName(), "missing", missing)
+			return true
+		}
+		return false
+	})
+	legacyUniqueNames, err := r.getLegacyUniqueNames(ctx, policies)
+	if err != nil {
+		return err
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.Client, cfg, func() error {
+		return r.updateConfigMapData(cfg, policyServer, policies, legacyUniqueNames, references)
 	})
 	if err != nil {
 		return fmt.Errorf("cannot create or update PolicyServer ConfigMap: %w", err)
@@ -127,8 +196,14 @@ func (r *PolicyServerReconciler) reconcilePolicyServerConfigMap(
 }
 
 // Function used to update the ConfigMap data when creating or updating it.
-func (r *PolicyServerReconciler) updateConfigMapData(cfg *corev1.ConfigMap, policyServer *policiesv1.PolicyServer, policies []policiesv1.Policy) error {
-	policiesMap := buildPoliciesMap(policies)
+func (r *PolicyServerReconciler) updateConfigMapData(
+	cfg *corev1.ConfigMap,
+	policyServer *policiesv1.PolicyServer,
+	policies []policiesv1.Policy,
+	legacyUniqueNames sets.Set[string],
+	references policyReferences,
+) error {
+	policiesMap := buildPoliciesMap(policies, legacyUniqueNames, references)
 	policiesYML, err := json.Marshal(policiesMap)
 	if err != nil {
 		return fmt.Errorf("cannot marshal policies: %w", err)
@@ -145,6 +220,16 @@ func (r *PolicyServerReconciler) updateConfigMapData(cfg *corev1.ConfigMap, poli
 		constants.PolicyServerConfigSourcesEntry:  string(sourcesYML),
 	}
 
+	// The verificationConfig of the Policy Server takes precedence over the
+	// verification configuration generated from the PolicyModules.
+	if verificationConfig := buildVerificationConfig(policies, references); policyServer.Spec.VerificationConfig == "" && verificationConfig != nil {
+		verificationYML, err := json.Marshal(verificationConfig)
+		if err != nil {
+			return fmt.Errorf("cannot marshal verification config: %w", err)
+		}
+		data[constants.PolicyServerVerificationConfigEntry] = string(verificationYML)
+	}
+
 	cfg.Data = data
 	cfg.ObjectMeta.Labels = map[string]string{
 		constants.PolicyServerLabelKey: policyServer.ObjectMeta.Name,
@@ -155,7 +240,10 @@ func (r *PolicyServerReconciler) updateConfigMapData(cfg *corev1.ConfigMap, poli
 	return nil
 }
 
-func (r *PolicyServerReconciler) policyServerConfigMapVersion(ctx context.Context, policyServer *policiesv1.PolicyServer) (string, error) {
+// policyServerConfigMapVersion returns the version of the Policy Server
+// ConfigMap, and whether it holds a verification configuration generated from
+// the PolicyModules.
+func (r *PolicyServerReconciler) policyServerConfigMapVersion(ctx context.Context, policyServer *policiesv1.PolicyServer) (string, bool, error) {
 	// By using Unstructured data we force the client to fetch fresh, uncached
 	// data from the API server
 	unstructuredObj := &unstructured.Unstructured{}
@@ -168,17 +256,179 @@ func (r *PolicyServerReconciler) policyServerConfigMapVersion(ctx context.Contex
 		Name:      policyServer.NameWithPrefix(),
 	}, unstructuredObj)
 	if err != nil {
-		return "", fmt.Errorf("cannot retrieve existing policies ConfigMap: %w", err)
+		return "", false, fmt.Errorf("cannot retrieve existing policies ConfigMap: %w", err)
+	}
+	_, hasVerificationConfig, err := unstructured.NestedString(unstructuredObj.Object, "data", constants.PolicyServerVerificationConfigEntry)
+	if err != nil {
+		return "", false, fmt.Errorf("cannot read the verification config of the policies ConfigMap: %w", err)
+	}
+
+	return unstructuredObj.GetResourceVersion(), hasVerificationConfig, nil
+}
+
+// getLegacyUniqueNames returns the legacy unique names of the given policies
+// that are still used by a webhook configuration. Such policies are served
+// under both their unique name and their legacy unique name, until their
+// webhook configurations are migrated by the policy reconcilers.
+func (r *PolicyServerReconciler) getLegacyUniqueNames(ctx context.Context, policies []policiesv1.Policy) (sets.Set[string], error) {
+	partOfKubewarden := client.MatchingLabels{constants.PartOfLabelKey: constants.PartOfLabelValue}
+	webhookConfigurations := map[string]map[string]string{}
+
+	validatingWebhookConfigurations := admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := r.List(ctx, &validatingWebhookConfigurations, partOfKubewarden); err != nil {
+		return nil, fmt.Errorf("cannot list validating webhook configurations: %w", err)
+	}
+	for _, webhookConfiguration := range validatingWebhookConfigurations.Items {
+		webhookConfigurations[webhookConfiguration.GetName()] = webhookConfiguration.GetAnnotations()
+	}
+
+	mutatingWebhookConfigurations := admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := r.List(ctx, &mutatingWebhookConfigurations, partOfKubewarden); err != nil {
+		return nil, fmt.Errorf("cannot list mutating webhook configurations: %w", err)
+	}
+	for _, webhookConfiguration := range mutatingWebhookConfigurations.Items {
+		webhookConfigurations[webhookConfiguration.GetName()] = webhookConfiguration.GetAnnotations()
+	}
+
+	legacyUniqueNames := sets.New[string]()
+	for _, policy := range policies {
+		annotations, ok := webhookConfigurations[policy.GetLegacyUniqueName()]
+		if !ok {
+			continue
+		}
+		if annotations[constants.WebhookConfigurationPolicyNameAnnotationKey] == policy.GetName() &&
+			annotations[constants.WebhookConfigurationPolicyNamespaceAnnotationKey] == policy.GetNamespace() {
+			legacyUniqueNames.Insert(policy.GetLegacyUniqueName())
+		}
+	}
+
+	return legacyUniqueNames, nil
+}
+
+// policyReferences holds the objects referenced by the policies, which are
+// resolved when building the Policy Server configuration.
+type policyReferences struct {
+	// policyModules are the modules served for the PolicyModules, indexed by
+	// the name of the PolicyModule.
+	policyModules map[string]string
+	// policyModuleSignatures are the signatures of the PolicyModules
+	// requiring them, indexed by the name of the PolicyModule.
+	policyModuleSignatures map[string]*policiesv1.PolicyModuleSignatures
+	// policies are the policies referenced by the policy group members.
+	policies map[types.NamespacedName]policiesv1.Policy
+}
+
+// getPolicyReferences returns the PolicyModules, and the policies referenced
+// by the members of the given policy groups.
+func (r *PolicyServerReconciler) getPolicyReferences(ctx context.Context, policies []policiesv1.Policy) (policyReferences, error) {
+	references := policyReferences{
+		policyModules:          map[string]string{},
+		policyModuleSignatures: map[string]*policiesv1.PolicyModuleSignatures{},
+		policies:               map[types.NamespacedName]policiesv1.Policy{},
 	}
 
-	return unstructuredObj.GetResourceVersion(), nil
+	var policyModuleList policiesv1.PolicyModuleList
+	if err := r.List(ctx, &policyModuleList); err != nil {
+		return references, fmt.Errorf("cannot list PolicyModules: %w", err)
+	}
+	for _, policyModule := range policyModuleList.Items {
+		references.policyModules[policyModule.GetName()] = policyModule.ResolvedModule()
+		if policyModule.HasSignatures() {
+			references.policyModuleSignatures[policyModule.GetName()] = policyModule.Spec.Signatures
+		}
+	}
+
+	for _, policy := range policies {
+		policyGroup, ok := policy.(policiesv1.PolicyGroup)
+		if !ok {
+			continue
+		}
+		for _, policyRef := range policiesv1.PolicyRefs(policyGroup) {
+			if _, ok = references.policies[policyRef]; ok {
+				continue
+			}
+			referencedPolicy, err := policiesv1.GetReferencedPolicy(ctx, r.Client, policyRef)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return references, fmt.Errorf("cannot get the policy %s referenced by %s: %w", policyRef, policy.GetUniqueName(), err)
+			}
+			references.policies[policyRef] = referencedPolicy
+		}
+	}
+
+	return references, nil
 }
 
-func buildPolicyGroupMembersWithContext(policies policiesv1.PolicyGroupMembersWithContext) map[string]policyGroupMemberWithContext {
+// missingReferences returns the PolicyModules and the policies referenced by
+// the policy which do not exist.
+func (r policyReferences) missingReferences(policy policiesv1.Policy) []string {
+	missing := []string{}
+	for _, moduleRef := range policiesv1.ModuleRefs(policy) {
+		if _, ok := r.policyModules[moduleRef]; !ok {
+			missing = append(missing, policiesv1.PolicyModuleKind+" "+moduleRef)
+		}
+	}
+	if policyGroup, ok := policy.(policiesv1.PolicyGroup); ok {
+		for _, policyRef := range policiesv1.PolicyRefs(policyGroup) {
+			referencedPolicy, found := r.policies[policyRef]
+			if !found {
+				missing = append(missing, "policy "+policyRef.String())
+				continue
+			}
+			missing = append(missing, r.missingReferences(referencedPolicy)...)
+		}
+	}
+
+	return missing
+}
+
+// moduleRefs returns the PolicyModules referenced by the policy, by the
+// members of the policy group, and by the policies they reference.
+func (r policyReferences) moduleRefs(policy policiesv1.Policy) []string {
+	moduleRefs := policiesv1.ModuleRefs(policy)
+	if policyGroup, ok := policy.(policiesv1.PolicyGroup); ok {
+		for _, policyRef := range policiesv1.PolicyRefs(policyGroup) {
+			if referencedPolicy, found := r.policies[policyRef]; found {
+				moduleRefs = append(moduleRefs, r.moduleRefs(referencedPolicy)...)
+			}
+		}
+	}
+
+	return moduleRefs
+}
+
+// resolveModule returns the module referenced by moduleRef, or module when no
+// PolicyModule is referenced.
+func (r policyReferences) resolveModule(module, moduleRef string) string {
+	if moduleRef == "" {
+		return module
+	}
+
+	return r.policyModules[moduleRef]
+}
+
+// buildPolicyGroupMembersWithContext builds the members of the policy group.
+// The members referencing a policy are resolved into its module, settings
+// and context aware resources.
+func buildPolicyGroupMembersWithContext(policyGroup policiesv1.PolicyGroup, references policyReferences) map[string]policyGroupMemberWithContext {
 	policyGroupMembers := map[string]policyGroupMemberWithContext{}
-	for name, policy := range policies {
+	for name, policy := range policyGroup.GetPolicyGroupMembersWithContext() {
+		if policy.PolicyRef != "" {
+			referencedPolicy, ok := references.policies[types.NamespacedName{Namespace: policyGroup.GetNamespace(), Name: policy.PolicyRef}]
+			if !ok {
+				continue
+			}
+			policyGroupMembers[name] = policyGroupMemberWithContext{
+				Module:                references.resolveModule(referencedPolicy.GetModule(), referencedPolicy.GetModuleRef()),
+				Settings:              referencedPolicy.GetSettings(),
+				ContextAwareResources: referencedPolicy.GetContextAwareResources(),
+			}
+			continue
+		}
 		policyGroupMembers[name] = policyGroupMemberWithContext{
-			Module:                policy.Module,
+			Module:                references.resolveModule(policy.Module, policy.ModuleRef),
 			Settings:              policy.Settings,
 			ContextAwareResources: policy.ContextAwareResources,
 		}
@@ -186,7 +436,21 @@ func buildPolicyGroupMembersWithContext(policies policiesv1.PolicyGroupMembersWi
 	return policyGroupMembers
 }
 
-func buildPoliciesMap(admissionPolicies []policiesv1.Policy) policyConfigEntryMap {
+// isContextAware returns true when the policy, or any of the members of the
+// policy group, accesses context aware resources.
+func (p policyServerConfigEntry) isContextAware() bool {
+	if len(p.ContextAwareResources) != 0 {
+		return true
+	}
+	for _, member := range p.Policies {
+		if len(member.ContextAwareResources) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func buildPoliciesMap(admissionPolicies []policiesv1.Policy, legacyUniqueNames sets.Set[string], references policyReferences) policyConfigEntryMap {
 	policies := policyConfigEntryMap{}
 	for _, admissionPolicy := range admissionPolicies {
 		configEntry := policyServerConfigEntry{
@@ -194,7 +458,7 @@ func buildPoliciesMap(admissionPolicies []policiesv1.Policy) policyConfigEntryMa
 				Namespace: admissionPolicy.GetNamespace(),
 				Name:      admissionPolicy.GetName(),
 			},
-			Module:                admissionPolicy.GetModule(),
+			Module:                references.resolveModule(admissionPolicy.GetModule(), admissionPolicy.GetModuleRef()),
 			PolicyMode:            string(admissionPolicy.GetPolicyMode()),
 			AllowedToMutate:       admissionPolicy.IsMutating(),
 			Settings:              admissionPolicy.GetSettings(),
@@ -202,16 +466,68 @@ func buildPoliciesMap(admissionPolicies []policiesv1.Policy) policyConfigEntryMa
 		}
 
 		if policyGroup, ok := admissionPolicy.(policiesv1.PolicyGroup); ok {
-			configEntry.Policies = buildPolicyGroupMembersWithContext(policyGroup.GetPolicyGroupMembersWithContext())
+			configEntry.Policies = buildPolicyGroupMembersWithContext(policyGroup, references)
 			configEntry.Expression = policyGroup.GetExpression()
+			if policyGroup.GetExpressionVersion() != policiesv1.GroupExpressionVersionV1 {
+				configEntry.ExpressionVersion = string(policyGroup.GetExpressionVersion())
+			}
 			configEntry.Message = policyGroup.GetMessage()
+			configEntry.MessageTemplate = policiesv1.IsGroupMessageTemplate(policyGroup.GetMessage())
+		}
+		if admissionPolicy.GetNamespace() != "" && configEntry.isContextAware() {
+			configEntry.ContextAwareResourcesNamespace = admissionPolicy.GetNamespace()
 		}
 
 		policies[admissionPolicy.GetUniqueName()] = configEntry
+		if legacyUniqueNames.Has(admissionPolicy.GetLegacyUniqueName()) {
+			policies[admissionPolicy.GetLegacyUniqueName()] = configEntry
+		}
 	}
 	return policies
 }
 
+// buildVerificationConfig returns the verification configuration accepting
+// the signatures of the PolicyModules used by the policies, or nil when none
+// of them requires signatures. The Policy Server verifies all its modules
+// against the same configuration, hence a module is accepted when it is
+// signed by any of the signatures.
+func buildVerificationConfig(policies []policiesv1.Policy, references policyReferences) *policyServerVerificationConfig {
+	publicKeys := sets.New[string]()
+	keyless := sets.New[policiesv1.PolicyModuleKeylessSignature]()
+	for _, policy := range policies {
+		for _, moduleRef := range references.moduleRefs(policy) {
+			signatures, ok := references.policyModuleSignatures[moduleRef]
+			if !ok {
+				continue
+			}
+			publicKeys.Insert(signatures.PublicKeys...)
+			keyless.Insert(signatures.Keyless...)
+		}
+	}
+	if publicKeys.Len() == 0 && keyless.Len() == 0 {
+		return nil
+	}
+
+	verificationConfig := &policyServerVerificationConfig{
+		APIVersion: "v1",
+		AnyOf:      policyServerVerificationConfigAnyOf{MinimumMatches: 1, Signatures: []policyServerSignature{}},
+	}
+	for _, publicKey := range sets.List(publicKeys) {
+		verificationConfig.AnyOf.Signatures = append(verificationConfig.AnyOf.Signatures,
+			policyServerSignature{Kind: "pubKey", Key: publicKey})
+	}
+	keylessSignatures := keyless.UnsortedList()
+	slices.SortFunc(keylessSignatures, func(a, b policiesv1.PolicyModuleKeylessSignature) int {
+		return cmp.Or(cmp.Compare(a.Issuer, b.Issuer), cmp.Compare(a.Subject, b.Subject))
+	})
+	for _, signature := range keylessSignatures {
+		verificationConfig.AnyOf.Signatures = append(verificationConfig.AnyOf.Signatures,
+			policyServerSignature{Kind: "genericIssuer", Issuer: signature.Issuer, Subject: &policyServerSignatureSubject{Equal: signature.Subject}})
+	}
+
+	return verificationConfig
+}
+
 func buildSourcesMap(policyServer *policiesv1.PolicyServer) policyServerSourcesEntry {
 	sourcesEntry := policyServerSourcesEntry{}
 	sourcesEntry.InsecureSources = policyServer.Spec.InsecureSources
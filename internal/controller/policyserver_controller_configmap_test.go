@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+var _ = Describe("Policy Server configuration of the policy groups", func() {
+	It("should only set the expression version of the v2 expressions", func() {
+		v1PolicyGroup := policiesv1.NewClusterAdmissionPolicyGroupFactory().WithName(newName("v1-group")).Build()
+		v2PolicyGroup := policiesv1.NewClusterAdmissionPolicyGroupFactory().WithName(newName("v2-group")).Build()
+		v2PolicyGroup.Spec.ExpressionVersion = policiesv1.GroupExpressionVersionV2
+		v2PolicyGroup.Spec.Expression = "[pod_privileged(), user_group_psp()].filter(allowed, allowed).size() >= 1"
+
+		policiesMap := buildPoliciesMap([]policiesv1.Policy{v1PolicyGroup, v2PolicyGroup}, sets.New[string](), policyReferences{})
+		policiesYML, err := json.Marshal(policiesMap)
+		Expect(err).ToNot(HaveOccurred())
+
+		policiesData := map[string]map[string]interface{}{}
+		Expect(json.Unmarshal(policiesYML, &policiesData)).To(Succeed())
+		Expect(policiesData[v1PolicyGroup.GetUniqueName()]).ToNot(HaveKey("expressionVersion"))
+		Expect(policiesData[v2PolicyGroup.GetUniqueName()]).To(HaveKeyWithValue("expressionVersion", "v2"))
+		Expect(policiesData[v2PolicyGroup.GetUniqueName()]).To(HaveKeyWithValue("expression", v2PolicyGroup.Spec.Expression))
+	})
+
+	It("should flag the message templates", func() {
+		plainPolicyGroup := policiesv1.NewClusterAdmissionPolicyGroupFactory().WithName(newName("plain-group")).Build()
+		plainPolicyGroup.Spec.Message = "The group rejected the request"
+		templatePolicyGroup := policiesv1.NewClusterAdmissionPolicyGroupFactory().WithName(newName("template-group")).Build()
+		templatePolicyGroup.Spec.Message = "{{ .namespace }}/{{ .name }} rejected by {{ range .rejections }}{{ .policy }} {{ end }}"
+
+		policiesMap := buildPoliciesMap([]policiesv1.Policy{plainPolicyGroup, templatePolicyGroup}, sets.New[string](), policyReferences{})
+		policiesYML, err := json.Marshal(policiesMap)
+		Expect(err).ToNot(HaveOccurred())
+
+		policiesData := map[string]map[string]interface{}{}
+		Expect(json.Unmarshal(policiesYML, &policiesData)).To(Succeed())
+		Expect(policiesData[plainPolicyGroup.GetUniqueName()]).ToNot(HaveKey("messageTemplate"))
+		Expect(policiesData[templatePolicyGroup.GetUniqueName()]).To(HaveKeyWithValue("messageTemplate", true))
+		Expect(policiesData[templatePolicyGroup.GetUniqueName()]).To(HaveKeyWithValue("message", templatePolicyGroup.Spec.Message))
+	})
+})
+
+var _ = Describe("Policy Server configuration of the context aware resources", func() {
+	It("should restrict the context aware resources of the namespaced policies to their namespace", func() {
+		contextAwareResources := []policiesv1.ContextAwareResource{{APIVersion: "v1", Kind: "ConfigMap"}}
+		admissionPolicy := policiesv1.NewAdmissionPolicyFactory().
+			WithName(newName("policy")).
+			WithNamespace("team-a").
+			WithContextAwareResources(contextAwareResources).
+			Build()
+		admissionPolicyGroup := policiesv1.NewAdmissionPolicyGroupFactory().
+			WithName(newName("group")).
+			WithNamespace("team-b").
+			WithMembers(policiesv1.PolicyGroupMembersWithContext{
+				"pod_privileged": {
+					PolicyGroupMember: policiesv1.PolicyGroupMember{
+						Module: "registry://ghcr.io/kubewarden/tests/pod-privileged:v0.2.5",
+					},
+					ContextAwareResources: contextAwareResources,
+				},
+			}).
+			Build()
+		plainAdmissionPolicy := policiesv1.NewAdmissionPolicyFactory().WithName(newName("plain-policy")).Build()
+		clusterAdmissionPolicy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("cluster-policy")).
+			WithContextAwareResources(contextAwareResources).
+			Build()
+
+		policiesMap := buildPoliciesMap(
+			[]policiesv1.Policy{admissionPolicy, admissionPolicyGroup, plainAdmissionPolicy, clusterAdmissionPolicy},
+			sets.New[string](), policyReferences{})
+		policiesYML, err := json.Marshal(policiesMap)
+		Expect(err).ToNot(HaveOccurred())
+
+		policiesData := map[string]map[string]interface{}{}
+		Expect(json.Unmarshal(policiesYML, &policiesData)).To(Succeed())
+		Expect(policiesData[admissionPolicy.GetUniqueName()]).To(HaveKeyWithValue("contextAwareResourcesNamespace", "team-a"))
+		Expect(policiesData[admissionPolicyGroup.GetUniqueName()]).To(HaveKeyWithValue("contextAwareResourcesNamespace", "team-b"))
+		Expect(policiesData[plainAdmissionPolicy.GetUniqueName()]).ToNot(HaveKey("contextAwareResourcesNamespace"))
+		Expect(policiesData[clusterAdmissionPolicy.GetUniqueName()]).ToNot(HaveKey("contextAwareResourcesNamespace"))
+	})
+
+	It("should serialize the fine-grained constraints of the context aware resources", func() {
+		clusterAdmissionPolicy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("cluster-policy")).
+			WithContextAwareResources([]policiesv1.ContextAwareResource{
+				{
+					APIVersion:        "v1",
+					Kind:              "ConfigMap",
+					Namespaces:        []string{"kube-system"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "settings"}},
+					Names:             []string{"settings"},
+				},
+			}).
+			Build()
+
+		policiesMap := buildPoliciesMap([]policiesv1.Policy{clusterAdmissionPolicy}, sets.New[string](), policyReferences{})
+		policiesYML, err := json.Marshal(policiesMap)
+		Expect(err).ToNot(HaveOccurred())
+
+		policiesData := map[string]map[string]interface{}{}
+		Expect(json.Unmarshal(policiesYML, &policiesData)).To(Succeed())
+		Expect(policiesData[clusterAdmissionPolicy.GetUniqueName()]).To(HaveKeyWithValue("contextAwareResources", ConsistOf(
+			map[string]interface{}{
+				"apiVersion":        "v1",
+				"kind":              "ConfigMap",
+				"namespaces":        []interface{}{"kube-system"},
+				"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"team": "a"}},
+				"labelSelector":     map[string]interface{}{"matchLabels": map[string]interface{}{"app": "settings"}},
+				"names":             []interface{}{"settings"},
+			},
+		)))
+	})
+})
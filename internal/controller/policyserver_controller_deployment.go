@@ -45,10 +45,14 @@ const (
 
 // reconcilePolicyServerDeployment reconciles the Deployment that runs the PolicyServer.
 func (r *PolicyServerReconciler) reconcilePolicyServerDeployment(ctx context.Context, policyServer *policiesv1.PolicyServer) error {
-	configMapVersion, err := r.policyServerConfigMapVersion(ctx, policyServer)
+	configMapVersion, hasGeneratedVerificationConfig, err := r.policyServerConfigMapVersion(ctx, policyServer)
 	if err != nil {
 		return fmt.Errorf("cannot get policy-server ConfigMap version: %w", err)
 	}
+	verificationConfigMap := policyServer.Spec.VerificationConfig
+	if verificationConfigMap == "" && hasGeneratedVerificationConfig {
+		verificationConfigMap = policyServer.NameWithPrefix()
+	}
 
 	policyServerDeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -57,7 +61,7 @@ func (r *PolicyServerReconciler) reconcilePolicyServerDeployment(ctx context.Con
 		},
 	}
 	_, err = controllerutil.CreateOrPatch(ctx, r.Client, policyServerDeployment, func() error {
-		return r.updatePolicyServerDeployment(ctx, policyServer, policyServerDeployment, configMapVersion)
+		return r.updatePolicyServerDeployment(ctx, policyServer, policyServerDeployment, configMapVersion, verificationConfigMap)
 	})
 	if err != nil {
 		return fmt.Errorf("error reconciling policy-server deployment: %w", err)
@@ -66,8 +70,12 @@ func (r *PolicyServerReconciler) reconcilePolicyServerDeployment(ctx context.Con
 	return nil
 }
 
-func configureVerificationConfig(policyServer *policiesv1.PolicyServer, admissionContainer *corev1.Container) {
-	if policyServer.Spec.VerificationConfig != "" {
+// configureVerificationConfig configures the Policy Server to verify the
+// modules against the verification configuration of the given ConfigMap: the
+// verificationConfig of the Policy Server, or its own ConfigMap when it holds
+// the verification configuration generated from the PolicyModules.
+func configureVerificationConfig(verificationConfigMap string, admissionContainer *corev1.Container) {
+	if verificationConfigMap != "" {
 		admissionContainer.VolumeMounts = append(admissionContainer.VolumeMounts,
 			corev1.VolumeMount{
 				Name:      verificationConfigVolumeName,
@@ -82,7 +90,7 @@ func configureVerificationConfig(policyServer *policiesv1.PolicyServer, admissio
 	}
 }
 
-func (r *PolicyServerReconciler) updatePolicyServerDeployment(ctx context.Context, policyServer *policiesv1.PolicyServer, policyServerDeployment *appsv1.Deployment, configMapVersion string) error {
+func (r *PolicyServerReconciler) updatePolicyServerDeployment(ctx context.Context, policyServer *policiesv1.PolicyServer, policyServerDeployment *appsv1.Deployment, configMapVersion, verificationConfigMap string) error {
 	admissionContainer := getPolicyServerContainer(policyServer)
 
 	if r.AlwaysAcceptAdmissionReviewsInDeploymentsNamespace {
@@ -92,7 +100,7 @@ func (r *PolicyServerReconciler) updatePolicyServerDeployment(ctx context.Contex
 		})
 	}
 
-	configureVerificationConfig(policyServer, &admissionContainer)
+	configureVerificationConfig(verificationConfigMap, &admissionContainer)
 	configureImagePullSecret(policyServer, &admissionContainer)
 	configuresInsecureSources(policyServer, &admissionContainer)
 
@@ -121,7 +129,7 @@ func (r *PolicyServerReconciler) updatePolicyServerDeployment(ctx context.Contex
 		podSecurityContext,
 	)
 	r.adaptDeploymentForMetricsAndTracingConfiguration(policyServerDeployment, templateAnnotations)
-	r.adaptDeploymentSettingsForPolicyServer(policyServerDeployment, policyServer)
+	r.adaptDeploymentSettingsForPolicyServer(policyServerDeployment, policyServer, verificationConfigMap)
 
 	if err := r.configureMutualTLS(ctx, policyServerDeployment); err != nil {
 		return fmt.Errorf("failed to configure mutual TLS: %w", err)
@@ -194,8 +202,8 @@ func (r *PolicyServerReconciler) adaptDeploymentForMetricsAndTracingConfiguratio
 	}
 }
 
-func (r *PolicyServerReconciler) adaptDeploymentSettingsForPolicyServer(policyServerDeployment *appsv1.Deployment, policyServer *policiesv1.PolicyServer) {
-	if policyServer.Spec.VerificationConfig != "" {
+func (r *PolicyServerReconciler) adaptDeploymentSettingsForPolicyServer(policyServerDeployment *appsv1.Deployment, policyServer *policiesv1.PolicyServer, verificationConfigMap string) {
+	if verificationConfigMap != "" {
 		policyServerDeployment.Spec.Template.Spec.Volumes = append(
 			policyServerDeployment.Spec.Template.Spec.Volumes,
 			corev1.Volume{
@@ -203,7 +211,7 @@ func (r *PolicyServerReconciler) adaptDeploymentSettingsForPolicyServer(policySe
 				VolumeSource: corev1.VolumeSource{
 					ConfigMap: &corev1.ConfigMapVolumeSource{
 						LocalObjectReference: corev1.LocalObjectReference{
-							Name: policyServer.Spec.VerificationConfig,
+							Name: verificationConfigMap,
 						},
 						Items: []corev1.KeyToPath{
 							{
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const storageVersionMigrationPageSize = 500
+
+// policyCRDs are the CRDs owned by Kubewarden whose objects are rewritten by
+// the StorageVersionMigrator.
+//
+//nolint:gochecknoglobals // This is a constant list of CRD names
+var policyCRDs = []string{
+	"policyservers.policies.kubewarden.io",
+	"admissionpolicies.policies.kubewarden.io",
+	"clusteradmissionpolicies.policies.kubewarden.io",
+	"admissionpolicygroups.policies.kubewarden.io",
+	"clusteradmissionpolicygroups.policies.kubewarden.io",
+}
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// StorageVersionMigrator rewrites all the PolicyServers and policies at the
+// storage version of their CRD, then trims the CRD status.storedVersions list
+// down to the storage version. This allows older API versions to be removed
+// from the CRDs without relying on external tooling.
+// It runs once, when the manager starts.
+type StorageVersionMigrator struct {
+	client.Client
+	// APIReader is used to list the objects to be migrated without going
+	// through the cache.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Log       logr.Logger
+}
+
+// Start runs the migration.
+// Implements the Runnable inteface, see https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/manager#Runnable.
+func (r *StorageVersionMigrator) Start(ctx context.Context) error {
+	r.Log.Info("Starting storage version migration")
+
+	for _, crdName := range policyCRDs {
+		if err := r.migrate(ctx, crdName); err != nil {
+			// Do not stop the manager: the migration is retried on the next start.
+			r.Log.Error(err, "Storage version migration failed", "crd", crdName)
+		}
+	}
+
+	r.Log.Info("Storage version migration completed")
+
+	return nil
+}
+
+// NeedLeaderElection returns true to ensure that only one instance of the migration is running at a time.
+// Implements the LeaderElectionRunnable interface, see https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/manager#LeaderElectionRunnable.
+func (r *StorageVersionMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *StorageVersionMigrator) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(r); err != nil {
+		return fmt.Errorf("failed enrolling storage version migrator with manager: %w", err)
+	}
+
+	return nil
+}
+
+// migrate rewrites all the objects of the given CRD at its storage version
+// and updates the CRD status.storedVersions accordingly.
+func (r *StorageVersionMigrator) migrate(ctx context.Context, crdName string) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("CRD not found, skipping storage version migration", "crd", crdName)
+			return nil
+		}
+		return fmt.Errorf("failed to get CRD: %w", err)
+	}
+
+	storageVersion := ""
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			storageVersion = version.Name
+			break
+		}
+	}
+	if storageVersion == "" {
+		return fmt.Errorf("CRD %s has no storage version", crdName)
+	}
+
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == storageVersion {
+		r.Log.V(1).Info("Storage version migration not needed", "crd", crdName, "storageVersion", storageVersion)
+		return nil
+	}
+
+	r.Log.Info("Migrating objects to storage version", "crd", crdName, "storedVersions", crd.Status.StoredVersions, "storageVersion", storageVersion)
+
+	gvk := schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: storageVersion,
+		Kind:    crd.Spec.Names.Kind,
+	}
+	migrated, err := r.rewriteObjects(ctx, gvk, crd.Spec.Names.ListKind)
+	if err != nil {
+		r.Recorder.Eventf(crd, corev1.EventTypeWarning, "StorageVersionMigrationFailed",
+			"Failed to migrate objects to storage version %s: %s", storageVersion, err)
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err = r.APIReader.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{storageVersion}
+		return r.Status().Update(ctx, crd)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update CRD stored versions: %w", err)
+	}
+
+	r.Log.Info("Objects migrated to storage version", "crd", crdName, "storageVersion", storageVersion, "count", migrated)
+	r.Recorder.Eventf(crd, corev1.EventTypeNormal, "StorageVersionMigrated",
+		"Migrated %d objects to storage version %s", migrated, storageVersion)
+
+	return nil
+}
+
+// rewriteObjects lists all the objects of the given kind and issues an empty
+// patch against each one of them. The API server persists the object again,
+// encoding it at the current storage version.
+func (r *StorageVersionMigrator) rewriteObjects(ctx context.Context, gvk schema.GroupVersionKind, listKind string) (int, error) {
+	migrated := 0
+	continueToken := ""
+
+	for {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(listKind))
+		if err := r.APIReader.List(ctx, list, client.Limit(storageVersionMigrationPageSize), client.Continue(continueToken)); err != nil {
+			return migrated, fmt.Errorf("failed to list %s: %w", listKind, err)
+		}
+
+		for i := range list.Items {
+			object := &list.Items[i]
+			object.SetGroupVersionKind(gvk)
+
+			err := r.Patch(ctx, object, client.RawPatch(types.MergePatchType, []byte("{}")))
+			if err != nil && !apierrors.IsNotFound(err) {
+				return migrated, fmt.Errorf("failed to migrate %s %s: %w", object.Kind, client.ObjectKeyFromObject(object), err)
+			}
+			migrated++
+		}
+
+		r.Log.V(1).Info("Storage version migration progress", "kind", gvk.Kind, "migrated", migrated)
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return migrated, nil
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+)
+
+var _ = Describe("Storage version migrator", func() {
+	ctx := context.Background()
+	const crdName = "policyservers.policies.kubewarden.io"
+
+	It("should rewrite the objects and trim the CRD stored versions", func() {
+		policyServerName := newName("policy-server")
+		Expect(
+			k8sClient.Create(ctx, policiesv1.NewPolicyServerFactory().WithName(policyServerName).Build()),
+		).To(haveSucceededOrAlreadyExisted())
+
+		By("marking v1alpha2 as a stored version")
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+		crd.Status.StoredVersions = []string{"v1alpha2", "v1"}
+		Expect(k8sClient.Status().Update(ctx, crd)).To(Succeed())
+
+		By("running the migration")
+		recorder := record.NewFakeRecorder(10)
+		migrator := StorageVersionMigrator{
+			Client:    k8sClient,
+			APIReader: k8sClient,
+			Recorder:  recorder,
+			Log:       GinkgoLogr,
+		}
+		Expect(migrator.migrate(ctx, crdName)).To(Succeed())
+
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+		Expect(crd.Status.StoredVersions).To(Equal([]string{"v1"}))
+		Expect(recorder.Events).To(Receive(ContainSubstring("StorageVersionMigrated")))
+
+		_, err := getTestPolicyServer(ctx, policyServerName)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not do anything when the CRD only stores the storage version", func() {
+		recorder := record.NewFakeRecorder(10)
+		migrator := StorageVersionMigrator{
+			Client:    k8sClient,
+			APIReader: k8sClient,
+			Recorder:  recorder,
+			Log:       GinkgoLogr,
+		}
+		Expect(migrator.migrate(ctx, crdName)).To(Succeed())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+})
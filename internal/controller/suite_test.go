@@ -28,6 +28,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/modules/k3s"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
@@ -103,6 +104,8 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 
 	err = policiesv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = apiextensionsv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:scheme
 
@@ -120,6 +123,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 		Scheme:               k8sManager.GetScheme(),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: true,
+		Recorder: k8sManager.GetEventRecorderFor("kubewarden-admission-policy-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -128,6 +132,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 		Scheme:               k8sManager.GetScheme(),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: true,
+		Recorder: k8sManager.GetEventRecorderFor("kubewarden-cluster-admission-policy-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -136,6 +141,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 		Scheme:               k8sManager.GetScheme(),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: true,
+		Recorder: k8sManager.GetEventRecorderFor("kubewarden-admission-policy-group-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -144,6 +150,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 		Scheme:               k8sManager.GetScheme(),
 		DeploymentsNamespace: deploymentsNamespace,
 		FeatureGateAdmissionWebhookMatchConditions: true,
+		Recorder: k8sManager.GetEventRecorderFor("kubewarden-cluster-admission-policy-group-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -250,6 +257,8 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 
 	err = policiesv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = apiextensionsv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	k8sClient, err = client.New(restConfig, client.Options{
 		Scheme: scheme.Scheme,
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math/rand"
 
+	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"github.com/onsi/gomega/types"
@@ -33,6 +34,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
@@ -45,6 +47,19 @@ const (
 	clientCAConfigMapName       = "client-ca"
 )
 
+// newTestPolicySubReconciler returns a policy sub-reconciler backed by the
+// test client, and the fake recorder collecting its events.
+func newTestPolicySubReconciler() (*policySubReconciler, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(10)
+
+	return &policySubReconciler{
+		Client:               k8sClient,
+		Log:                  GinkgoLogr,
+		deploymentsNamespace: deploymentsNamespace,
+		recorder:             recorder,
+	}, recorder
+}
+
 func getTestAdmissionPolicy(ctx context.Context, namespace, name string) (*policiesv1.AdmissionPolicy, error) {
 	admissionPolicy := policiesv1.AdmissionPolicy{}
 	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &admissionPolicy); err != nil {
@@ -0,0 +1,291 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+const webhookConfigurationGCTickerDuration = 10 * time.Minute
+
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// WebhookConfigurationGarbageCollector deletes the webhook configurations
+// created by Kubewarden whose policy does not exist anymore, is not of the
+// same kind (mutating or validating) of the webhook configuration, or has
+// changed its priority.
+// This happens when a policy changes its spec.mutating field, or when the
+// finalizer of a policy is removed by hand before its deletion.
+// The webhook configurations are checked every time they, or a policy,
+// change, and periodically swept as a safety net.
+type WebhookConfigurationGarbageCollector struct {
+	client.Client
+	// APIReader is used to confirm that a policy is missing without going
+	// through the cache, before deleting its webhook configuration.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Log       logr.Logger
+}
+
+// Reconcile checks the validating and the mutating webhook configurations
+// with the requested name.
+func (r *WebhookConfigurationGarbageCollector) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var errs error
+
+	validatingWebhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := r.Get(ctx, req.NamespacedName, validatingWebhookConfiguration); err == nil {
+		errs = errors.Join(errs, r.collect(ctx, validatingWebhookConfiguration, false))
+	} else if !apierrors.IsNotFound(err) {
+		errs = errors.Join(errs, fmt.Errorf("cannot retrieve validating webhook configuration: %w", err))
+	}
+
+	mutatingWebhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := r.Get(ctx, req.NamespacedName, mutatingWebhookConfiguration); err == nil {
+		errs = errors.Join(errs, r.collect(ctx, mutatingWebhookConfiguration, true))
+	} else if !apierrors.IsNotFound(err) {
+		errs = errors.Join(errs, fmt.Errorf("cannot retrieve mutating webhook configuration: %w", err))
+	}
+
+	return ctrl.Result{}, errs
+}
+
+// Start begins the periodic sweep of the webhook configurations.
+// Implements the Runnable inteface, see https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/manager#Runnable.
+func (r *WebhookConfigurationGarbageCollector) Start(ctx context.Context) error {
+	r.Log.Info("Starting webhook configuration garbage collector ticker")
+
+	ticker := time.NewTicker(webhookConfigurationGCTickerDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.Log.Info("Stopping webhook configuration garbage collector")
+			return nil
+		case <-ticker.C:
+			if err := r.sweep(ctx); err != nil {
+				r.Log.Error(err, "Failed to sweep webhook configurations")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns true to ensure that only one instance of the garbage collector is running at a time.
+// Implements the LeaderElectionRunnable interface, see https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/manager#LeaderElectionRunnable.
+func (r *WebhookConfigurationGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *WebhookConfigurationGarbageCollector) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(r); err != nil {
+		return fmt.Errorf("failed enrolling webhook configuration garbage collector with manager: %w", err)
+	}
+
+	partOfKubewarden := builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetLabels()[constants.PartOfLabelKey] == constants.PartOfLabelValue
+	}))
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("webhookconfiguration-gc").
+		For(&admissionregistrationv1.ValidatingWebhookConfiguration{}, partOfKubewarden).
+		Watches(
+			&admissionregistrationv1.MutatingWebhookConfiguration{},
+			&handler.EnqueueRequestForObject{},
+			partOfKubewarden,
+		).
+		Watches(
+			&policiesv1.AdmissionPolicy{},
+			handler.EnqueueRequestsFromMapFunc(findWebhookConfigurationsForPolicy),
+		).
+		Watches(
+			&policiesv1.ClusterAdmissionPolicy{},
+			handler.EnqueueRequestsFromMapFunc(findWebhookConfigurationsForPolicy),
+		).
+		Watches(
+			&policiesv1.AdmissionPolicyGroup{},
+			handler.EnqueueRequestsFromMapFunc(findWebhookConfigurationsForPolicy),
+		).
+		Watches(
+			&policiesv1.ClusterAdmissionPolicyGroup{},
+			handler.EnqueueRequestsFromMapFunc(findWebhookConfigurationsForPolicy),
+		).
+		Complete(r)
+	if err != nil {
+		return errors.Join(errors.New("failed enrolling webhook configuration garbage collector controller with manager"), err)
+	}
+
+	return nil
+}
+
+// sweep checks all the webhook configurations created by Kubewarden.
+func (r *WebhookConfigurationGarbageCollector) sweep(ctx context.Context) error {
+	var errs error
+	partOfKubewarden := client.MatchingLabels{constants.PartOfLabelKey: constants.PartOfLabelValue}
+
+	validatingWebhookConfigurations := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := r.List(ctx, validatingWebhookConfigurations, partOfKubewarden); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("cannot list validating webhook configurations: %w", err))
+	} else {
+		for i := range validatingWebhookConfigurations.Items {
+			errs = errors.Join(errs, r.collect(ctx, &validatingWebhookConfigurations.Items[i], false))
+		}
+	}
+
+	mutatingWebhookConfigurations := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := r.List(ctx, mutatingWebhookConfigurations, partOfKubewarden); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("cannot list mutating webhook configurations: %w", err))
+	} else {
+		for i := range mutatingWebhookConfigurations.Items {
+			errs = errors.Join(errs, r.collect(ctx, &mutatingWebhookConfigurations.Items[i], true))
+		}
+	}
+
+	return errs
+}
+
+// collect deletes the given webhook configuration if it is orphaned.
+func (r *WebhookConfigurationGarbageCollector) collect(ctx context.Context, webhookConfiguration client.Object, mutating bool) error {
+	if webhookConfiguration.GetLabels()[constants.PartOfLabelKey] != constants.PartOfLabelValue {
+		return nil
+	}
+	// Sharded webhook configurations hold the webhooks of many policies, and
+	// are not annotated with a policy. They are pruned by the policy reconcilers.
+	policyName := webhookConfiguration.GetAnnotations()[constants.WebhookConfigurationPolicyNameAnnotationKey]
+	if policyName == "" {
+		return nil
+	}
+	policyNamespace := webhookConfiguration.GetAnnotations()[constants.WebhookConfigurationPolicyNamespaceAnnotationKey]
+
+	reason, err := r.orphanedReason(ctx, r.Client, webhookConfiguration.GetName(), policyName, policyNamespace, mutating)
+	if err != nil || reason == "" {
+		return err
+	}
+	// Make sure the cache is not lagging behind before deleting anything.
+	reason, err = r.orphanedReason(ctx, r.APIReader, webhookConfiguration.GetName(), policyName, policyNamespace, mutating)
+	if err != nil || reason == "" {
+		return err
+	}
+
+	kind := "ValidatingWebhookConfiguration"
+	if mutating {
+		kind = "MutatingWebhookConfiguration"
+	}
+	err = r.Delete(ctx, webhookConfiguration, client.Preconditions{
+		UID:             ptr.To(webhookConfiguration.GetUID()),
+		ResourceVersion: ptr.To(webhookConfiguration.GetResourceVersion()),
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			return nil
+		}
+
+		return fmt.Errorf("cannot delete orphaned %s %s: %w", kind, webhookConfiguration.GetName(), err)
+	}
+
+	r.Log.Info("Deleted orphaned webhook configuration", "kind", kind, "name", webhookConfiguration.GetName(), "reason", reason)
+	r.Recorder.Eventf(webhookConfiguration, corev1.EventTypeNormal, "OrphanedWebhookConfigurationDeleted",
+		"Deleted orphaned %s %s: %s", kind, webhookConfiguration.GetName(), reason)
+
+	return nil
+}
+
+// orphanedReason returns why the webhook configuration is orphaned, or an
+// empty string if it still belongs to its policy.
+// Webhook configurations whose name does not match the unique name, possibly
+// prefixed by a priority, or the legacy unique name, of the annotated policy
+// are not managed by this controller and are never reported as orphaned.
+func (r *WebhookConfigurationGarbageCollector) orphanedReason(
+	ctx context.Context,
+	k8sReader client.Reader,
+	webhookConfigurationName, policyName, policyNamespace string,
+	mutating bool,
+) (string, error) {
+	for _, policy := range webhookConfigurationPolicyCandidates(policyName, policyNamespace) {
+		if !isMutatingWebhookConfigurationNameOf(webhookConfigurationName, policy) && policy.GetLegacyUniqueName() != webhookConfigurationName {
+			continue
+		}
+
+		if err := k8sReader.Get(ctx, types.NamespacedName{Name: policyName, Namespace: policyNamespace}, policy); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "policy not found", nil
+			}
+
+			return "", fmt.Errorf("cannot retrieve policy %s: %w", policyName, err)
+		}
+
+		if policy.IsMutating() != mutating {
+			if policy.IsMutating() {
+				return "policy is mutating", nil
+			}
+
+			return "policy is not mutating", nil
+		}
+
+		if mutating && webhookConfigurationName != policy.GetLegacyUniqueName() &&
+			webhookConfigurationName != mutatingWebhookConfigurationName(policy) {
+			return "policy priority changed", nil
+		}
+
+		return "", nil
+	}
+
+	return "", nil
+}
+
+// webhookConfigurationPolicyCandidates returns the policies that could own a
+// webhook configuration annotated with the given policy name and namespace.
+func webhookConfigurationPolicyCandidates(policyName, policyNamespace string) []policiesv1.Policy {
+	var candidates []policiesv1.Policy
+	if policyNamespace == "" {
+		candidates = []policiesv1.Policy{&policiesv1.ClusterAdmissionPolicy{}, &policiesv1.ClusterAdmissionPolicyGroup{}}
+	} else {
+		candidates = []policiesv1.Policy{&policiesv1.AdmissionPolicy{}, &policiesv1.AdmissionPolicyGroup{}}
+	}
+
+	for _, policy := range candidates {
+		policy.SetName(policyName)
+		policy.SetNamespace(policyNamespace)
+	}
+
+	return candidates
+}
+
+// findWebhookConfigurationsForPolicy enqueues the webhook configurations of
+// the given policy, so that they are checked when the policy changes kind or
+// is deleted.
+func findWebhookConfigurationsForPolicy(_ context.Context, object client.Object) []reconcile.Request {
+	policy, ok := object.(policiesv1.Policy)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{Name: policy.GetUniqueName()},
+		},
+		{
+			NamespacedName: types.NamespacedName{Name: policy.GetLegacyUniqueName()},
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	policiesv1 "github.com/kubewarden/kubewarden-controller/api/policies/v1"
+	"github.com/kubewarden/kubewarden-controller/internal/constants"
+)
+
+var _ = Describe("Webhook configuration garbage collector", func() {
+	ctx := context.Background()
+
+	var recorder *record.FakeRecorder
+	var garbageCollector *WebhookConfigurationGarbageCollector
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		garbageCollector = &WebhookConfigurationGarbageCollector{
+			Client:    k8sClient,
+			APIReader: k8sClient,
+			Recorder:  recorder,
+			Log:       GinkgoLogr,
+		}
+	})
+
+	webhookConfigurationMeta := func(name, policyName string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				constants.PartOfLabelKey: constants.PartOfLabelValue,
+			},
+			Annotations: map[string]string{
+				constants.WebhookConfigurationPolicyNameAnnotationKey:      policyName,
+				constants.WebhookConfigurationPolicyNamespaceAnnotationKey: "",
+			},
+		}
+	}
+
+	It("should delete the webhook configuration of a missing policy", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().WithName(newName("missing-policy")).Build()
+		webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: webhookConfigurationMeta(policy.GetUniqueName(), policy.GetName()),
+		}
+		Expect(k8sClient.Create(ctx, webhookConfiguration)).To(Succeed())
+
+		Expect(garbageCollector.sweep(ctx)).To(Succeed())
+
+		_, err := getTestValidatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("OrphanedWebhookConfigurationDeleted")))
+	})
+
+	It("should delete the webhook configuration of the wrong kind", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("validating-policy")).
+			WithMutating(false).
+			Build()
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: webhookConfigurationMeta(policy.GetUniqueName(), policy.GetName()),
+		}
+		Expect(k8sClient.Create(ctx, webhookConfiguration)).To(Succeed())
+
+		Expect(garbageCollector.collect(ctx, webhookConfiguration, true)).To(Succeed())
+
+		_, err := getTestMutatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("policy is not mutating")))
+	})
+
+	It("should keep the webhook configuration of an existing policy", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("existing-policy")).
+			WithMutating(true).
+			Build()
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: webhookConfigurationMeta(policy.GetUniqueName(), policy.GetName()),
+		}
+		Expect(k8sClient.Create(ctx, webhookConfiguration)).To(Succeed())
+
+		Expect(garbageCollector.collect(ctx, webhookConfiguration, true)).To(Succeed())
+
+		_, err := getTestMutatingWebhookConfiguration(ctx, policy.GetUniqueName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should delete the webhook configuration named after a previous priority", func() {
+		policy := policiesv1.NewClusterAdmissionPolicyFactory().
+			WithName(newName("prioritized-policy")).
+			WithMutating(true).
+			Build()
+		policy.Spec.Priority = ptr.To(int32(10))
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: webhookConfigurationMeta("005-"+policy.GetUniqueName(), policy.GetName()),
+		}
+		Expect(k8sClient.Create(ctx, webhookConfiguration)).To(Succeed())
+
+		Expect(garbageCollector.collect(ctx, webhookConfiguration, true)).To(Succeed())
+
+		_, err := getTestMutatingWebhookConfiguration(ctx, webhookConfiguration.GetName())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("policy priority changed")))
+	})
+
+	It("should keep sharded webhook configurations", func() {
+		policyServerName := newName("policy-server")
+		webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   shardedWebhookConfigurationName(policyServerName),
+				Labels: shardedWebhookConfigurationLabels(policyServerName),
+			},
+		}
+		Expect(k8sClient.Create(ctx, webhookConfiguration)).To(Succeed())
+
+		Expect(garbageCollector.collect(ctx, webhookConfiguration, false)).To(Succeed())
+
+		_, err := getTestValidatingWebhookConfiguration(ctx, webhookConfiguration.GetName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+})
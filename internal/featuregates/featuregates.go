@@ -58,3 +58,27 @@ func CheckAdmissionWebhookMatchConditions(config *rest.Config) (bool, error) {
 
 	return exists, nil
 }
+
+// CheckValidatingAdmissionPolicy returns true if the API server serves the
+// ValidatingAdmissionPolicy and ValidatingAdmissionPolicyBinding resources of
+// the admissionregistration.k8s.io/v1 API. It does this by looking at the
+// resources of the API group version returned by the discovery client. This
+// feature is stable since Kubernetes v1.30.
+func CheckValidatingAdmissionPolicy(config *rest.Config) (bool, error) {
+	resourceList, err := discovery.NewDiscoveryClientForConfigOrDie(config).ServerResourcesForGroupVersion("admissionregistration.k8s.io/v1")
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch the resources of admissionregistration.k8s.io/v1: %w", err)
+	}
+
+	var hasPolicies, hasBindings bool
+	for _, resource := range resourceList.APIResources {
+		switch resource.Name {
+		case "validatingadmissionpolicies":
+			hasPolicies = true
+		case "validatingadmissionpolicybindings":
+			hasBindings = true
+		}
+	}
+
+	return hasPolicies && hasBindings, nil
+}
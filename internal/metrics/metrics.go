@@ -40,7 +40,10 @@ func New() (func(context.Context) error, error) {
 	return meterProvider.Shutdown, nil
 }
 
-func RecordPolicyCount(ctx context.Context, policy policiesv1.Policy) error {
+// RecordPolicyCount records the policy, labeled with its module. The module
+// is the resolved module of the policy, since the policies referencing a
+// PolicyModule have no module.
+func RecordPolicyCount(ctx context.Context, policy policiesv1.Policy, module string) error {
 	failurePolicy := ""
 	if policy.GetFailurePolicy() != nil {
 		failurePolicy = string(*policy.GetFailurePolicy())
@@ -55,10 +58,11 @@ func RecordPolicyCount(ctx context.Context, policy policiesv1.Policy) error {
 	commonLabels := []attribute.KeyValue{
 		attribute.String("name", policy.GetUniqueName()),
 		attribute.String("policy_server", policy.GetPolicyServer()),
-		attribute.String("module", policy.GetModule()),
+		attribute.String("module", module),
 		attribute.Bool("mutating", policy.IsMutating()),
 		attribute.String("namespace", policy.GetNamespace()),
 		attribute.String("failure_policy", failurePolicy),
+		attribute.String("mode", string(policy.GetPolicyMode())),
 		attribute.String("policy_status", string(policy.GetStatus().PolicyStatus)),
 	}
 	counter.Add(ctx, 1, metric.WithAttributes(commonLabels...))